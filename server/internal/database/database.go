@@ -0,0 +1,86 @@
+// Package database opens the storage the server is configured to use, SQLite or PostgreSQL
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+var ErrUnknownDriver = errors.New("unknown database driver, use sqlite or postgres")
+
+type Config struct {
+	// Driver is DriverSQLite or DriverPostgres
+	Driver string
+	// DSN is the path of the SQLite file or the PostgreSQL connection string, as a URL or as key=value pairs
+	DSN string
+}
+
+// ConfigFromEnv reads DB_DRIVER, SQLite when empty, and DB_DSN. SQLite also accepts the file in DB_PATH.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Driver: strings.ToLower(os.Getenv("DB_DRIVER")),
+		DSN:    os.Getenv("DB_DSN"),
+	}
+	if cfg.Driver == "" {
+		cfg.Driver = DriverSQLite
+	}
+	if cfg.DSN == "" && cfg.Driver == DriverSQLite {
+		cfg.DSN = os.Getenv("DB_PATH")
+	}
+	if cfg.Driver != DriverSQLite && cfg.Driver != DriverPostgres {
+		return Config{}, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+	}
+	if cfg.DSN == "" {
+		return Config{}, errors.New("DB_DSN environment variable not set")
+	}
+	return cfg, nil
+}
+
+func Open(cfg Config) (*gorm.DB, error) {
+	switch cfg.Driver {
+	case DriverSQLite:
+		return gorm.Open(sqlite.Open(cfg.DSN), &gorm.Config{})
+	case DriverPostgres:
+		return openPostgres(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+	}
+}
+
+// openPostgres runs the sessions in the local time zone, unless the DSN sets TimeZone, so that the dates
+// are truncated and grouped by the same wall clock as in SQLite. The zone can only be passed on when it has
+// a name, as it does when TZ is set.
+func openPostgres(dsn string) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if zone := time.Local.String(); zone != "Local" && !hasParam(connConfig.RuntimeParams, "timezone") {
+		connConfig.RuntimeParams["timezone"] = zone
+	}
+	return gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig)}), &gorm.Config{})
+}
+
+// hasParam tells whether the runtime parameter is set, their names are case insensitive
+func hasParam(params map[string]string, name string) bool {
+	for key := range params {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
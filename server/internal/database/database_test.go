@@ -0,0 +1,67 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "sqlite file of DB_PATH",
+			env:  map[string]string{"DB_PATH": "app.db"},
+			want: Config{Driver: DriverSQLite, DSN: "app.db"},
+		},
+		{
+			name: "DB_DSN takes precedence",
+			env:  map[string]string{"DB_DRIVER": "SQLite", "DB_DSN": "other.db", "DB_PATH": "app.db"},
+			want: Config{Driver: DriverSQLite, DSN: "other.db"},
+		},
+		{
+			name: "postgres",
+			env:  map[string]string{"DB_DRIVER": "postgres", "DB_DSN": "postgres://localhost/salon", "DB_PATH": "app.db"},
+			want: Config{Driver: DriverPostgres, DSN: "postgres://localhost/salon"},
+		},
+		{
+			name:    "postgres without DSN",
+			env:     map[string]string{"DB_DRIVER": "postgres", "DB_PATH": "app.db"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown driver",
+			env:     map[string]string{"DB_DRIVER": "mysql", "DB_DSN": "root@/salon"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"DB_DRIVER", "DB_DSN", "DB_PATH"} {
+				t.Setenv(key, tt.env[key])
+			}
+			cfg, err := ConfigFromEnv()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestOpen_UnknownDriver(t *testing.T) {
+	_, err := Open(Config{Driver: "mysql", DSN: "root@/salon"})
+	assert.ErrorIs(t, err, ErrUnknownDriver)
+}
+
+func TestHasParam(t *testing.T) {
+	assert.True(t, hasParam(map[string]string{"TimeZone": "America/Sao_Paulo"}, "timezone"))
+	assert.False(t, hasParam(map[string]string{"search_path": "public"}, "timezone"))
+}
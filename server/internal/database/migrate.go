@@ -0,0 +1,319 @@
+package database
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSchemaBehind is returned when migrations of the binary were not applied to the database
+	ErrSchemaBehind = errors.New("database schema is behind, run the pending migrations")
+	// ErrSchemaAhead is returned when the database was migrated by a newer binary
+	ErrSchemaAhead = errors.New("database schema is ahead of this binary")
+	// ErrUnknownVersion is returned when migrating to a version the binary has no migration for
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// The migrations of each driver are in migrations/<driver>, as <version>_<name>.up.sql and <version>_<name>.down.sql.
+// Their statements end with a semicolon at the end of a line.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// Migration is a versioned change of the schema and the statements that revert it
+type Migration struct {
+	Version int
+	Name    string
+	up      []string
+	down    []string
+}
+
+// MigrationStatus tells when a migration was applied, AppliedAt is nil while it is pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of schema_migrations, one per applied migration
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and reverts the migrations of the driver of the database, each one in a transaction
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest is the version of the last migration of the binary
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version is the last migration applied to the database, 0 when none was
+func (m *Migrator) Version() (int, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version, nil
+}
+
+// Status lists the migrations of the binary in order
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &row.AppliedAt
+		}
+		list = append(list, status)
+	}
+	return list, nil
+}
+
+// Check fails with ErrSchemaBehind when a migration is pending and with ErrSchemaAhead when the database
+// has migrations the binary does not know
+func (m *Migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	if err := m.checkKnown(applied); err != nil {
+		return err
+	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			return fmt.Errorf("%w: %d_%s is pending", ErrSchemaBehind, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// Up applies the pending migrations
+func (m *Migrator) Up() error {
+	return m.To(m.Latest())
+}
+
+// Down reverts the last migration applied
+func (m *Migrator) Down() error {
+	version, err := m.Version()
+	if err != nil || version == 0 {
+		return err
+	}
+	previous := 0
+	for _, migration := range m.migrations {
+		if migration.Version < version {
+			previous = migration.Version
+		}
+	}
+	return m.To(previous)
+}
+
+// To applies the pending migrations up to the version and reverts the applied ones after it. Version 0 reverts them all.
+func (m *Migrator) To(version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	if err := m.checkKnown(applied); err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; ok && migration.Version > version {
+			if err := m.run(migration, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+			if err := m.run(migration, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// run applies or reverts the migration and records it in schema_migrations
+func (m *Migrator) run(migration Migration, up bool) error {
+	statements, direction := migration.down, "down"
+	if up {
+		statements, direction = migration.up, "up"
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		if up {
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		}
+		return tx.Delete(&schemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+	return nil
+}
+
+// applied reads schema_migrations, creating it on the first run
+func (m *Migrator) applied() (map[int]schemaMigration, error) {
+	err := m.db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, applied_at timestamp NOT NULL)").Error
+	if err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := m.db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) checkKnown(applied map[int]schemaMigration) error {
+	for version, row := range applied {
+		if m.find(version) == nil {
+			return fmt.Errorf("%w: %d_%s is not part of it", ErrSchemaAhead, version, row.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// loadMigrations reads the migrations of the driver, sorted by version
+func loadMigrations(driver string) ([]Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for the %s driver: %w", driver, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		base, direction, ok := cutDirection(entry.Name())
+		if !ok {
+			return nil, fmt.Errorf("migration file %s must end with .up.sql or .down.sql", entry.Name())
+		}
+		prefix, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 || name == "" {
+			return nil, fmt.Errorf("migration file %s must be named <version>_<name>", entry.Name())
+		}
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migrations %d_%s and %d_%s share a version", version, migration.Name, version, name)
+		}
+		if direction == "up" {
+			migration.up = splitStatements(string(content))
+		} else {
+			// An empty down file keeps the changes when the migration is reverted
+			migration.down = splitStatements(string(content))
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == nil || migration.down == nil {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func cutDirection(file string) (string, string, bool) {
+	if base, ok := strings.CutSuffix(file, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok := strings.CutSuffix(file, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// splitStatements splits a migration file into its statements, skipping the comment lines
+func splitStatements(content string) []string {
+	statements := []string{}
+	var current strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
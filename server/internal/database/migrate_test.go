@@ -0,0 +1,238 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupMigrator(t *testing.T) (*gorm.DB, *Migrator) {
+	dsn := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := Open(Config{Driver: DriverSQLite, DSN: dsn})
+	require.NoError(t, err)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	return db, migrator
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	assert.ErrorIs(t, migrator.Check(), ErrSchemaBehind)
+
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Check())
+	version, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), version)
+	assert.True(t, db.Migrator().HasTable("appointments"))
+
+	// Running it again has nothing to apply
+	require.NoError(t, migrator.Up())
+
+	require.NoError(t, migrator.Down())
+	assert.ErrorIs(t, migrator.Check(), ErrSchemaBehind)
+	status, err := migrator.Status()
+	require.NoError(t, err)
+	assert.NotNil(t, status[0].AppliedAt)
+	assert.Nil(t, status[len(status)-1].AppliedAt)
+
+	require.NoError(t, migrator.To(0))
+	assert.False(t, db.Migrator().HasTable("appointments"))
+	version, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Zero(t, version)
+
+	assert.ErrorIs(t, migrator.To(999), ErrUnknownVersion)
+}
+
+func TestMigrator_SchemaAhead(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	require.NoError(t, migrator.Up())
+	require.NoError(t, db.Create(&schemaMigration{Version: 999, Name: "from_a_newer_release", AppliedAt: time.Now()}).Error)
+
+	assert.ErrorIs(t, migrator.Check(), ErrSchemaAhead)
+	assert.ErrorIs(t, migrator.Up(), ErrSchemaAhead)
+}
+
+// baselineUser, baselineService and baselineAppointment are the models AutoMigrate created the schema from
+// before the migrations existed
+type baselineUser struct {
+	ID        uint   `gorm:"primaryKey"`
+	Email     string `gorm:"uniqueIndex"`
+	Password  string
+	Role      string
+	Name      string
+	Phone     string
+	IsActive  bool `gorm:"default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineUser) TableName() string {
+	return "users"
+}
+
+type baselineService struct {
+	ID              uint `gorm:"primaryKey"`
+	Name            string
+	Price           float64
+	DurationMinutes int
+}
+
+func (baselineService) TableName() string {
+	return "services"
+}
+
+type baselineAppointment struct {
+	ID        uint         `gorm:"primaryKey"`
+	User      baselineUser `gorm:"foreignKey:UserID"`
+	UserID    uint
+	Services  []baselineService `gorm:"many2many:appointment_services;joinForeignKey:AppointmentID;joinReferences:ServiceID"`
+	Date      time.Time
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineAppointment) TableName() string {
+	return "appointments"
+}
+
+// TestMigrator_UpgradesAutoMigrateSchema checks that the databases created by AutoMigrate are adopted and
+// brought up to date, keeping their data
+func TestMigrator_UpgradesAutoMigrateSchema(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	require.NoError(t, db.AutoMigrate(&baselineUser{}, &baselineService{}, &baselineAppointment{}))
+	user := baselineUser{Email: "maria@example.com", Role: string(models.RoleCustomer), Name: "Maria", IsActive: true}
+	require.NoError(t, db.Create(&user).Error)
+	service := baselineService{Name: "Corte", Price: 50, DurationMinutes: 30}
+	require.NoError(t, db.Create(&service).Error)
+	ap := baselineAppointment{UserID: user.ID, Services: []baselineService{service}, Date: time.Now(), Status: string(models.StatusDone)}
+	require.NoError(t, db.Create(&ap).Error)
+
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Check())
+	assertSchemaMatchesModels(t, db)
+
+	var stored models.User
+	require.NoError(t, db.First(&stored, user.ID).Error)
+	assert.Equal(t, "maria@example.com", stored.Email)
+	assert.True(t, stored.IsActive)
+	var booked models.Appointment
+	require.NoError(t, db.Preload("Services").Preload("Items").First(&booked, ap.ID).Error)
+	require.Len(t, booked.Services, 1)
+	require.Len(t, booked.Items, 1)
+	assert.Equal(t, "Corte", booked.Items[0].Name)
+
+	// Reverting every migration leaves nothing behind
+	require.NoError(t, migrator.To(0))
+	assert.False(t, db.Migrator().HasTable("appointments"))
+}
+
+// TestMigrator_BackfillsItems checks that the appointments booked before the booked items existed get them
+func TestMigrator_BackfillsItems(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	// The version before the backfill, the models already have columns added after it
+	require.NoError(t, migrator.To(7))
+
+	exec := func(sql string, values ...interface{}) {
+		require.NoError(t, db.Exec(sql, values...).Error)
+	}
+	exec("INSERT INTO users (id, email, role) VALUES (1, 'maria@example.com', ?)", models.RoleCustomer)
+	exec("INSERT INTO services (id, name, price, duration_minutes) VALUES (1, 'Corte', 50, 30)")
+	// Services removed from the catalog after the booking keep their place in the history
+	exec("INSERT INTO services (id, name, price, duration_minutes, deleted_at) VALUES (2, 'Escova', 40, 45, ?)", time.Now())
+	exec("INSERT INTO appointments (id, user_id, date, status) VALUES (1, 1, ?, ?), (2, 1, ?, ?)", time.Now(), models.StatusDone, time.Now(), models.StatusDone)
+	exec("INSERT INTO appointment_services (appointment_id, service_id) VALUES (1, 1), (2, 2)")
+
+	require.NoError(t, migrator.Up())
+	var items []models.AppointmentItem
+	require.NoError(t, db.Where("appointment_id = ?", 1).Find(&items).Error)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Corte", items[0].Name)
+	assert.Equal(t, 50.0, items[0].Price)
+
+	require.NoError(t, db.Where("appointment_id = ?", 2).Find(&items).Error)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Escova", items[0].Name)
+	assert.Equal(t, 45, items[0].DurationMinutes)
+}
+
+// TestMigrations_MatchModels fails when a model gains a field without a migration adding its column
+func TestMigrations_MatchModels(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	require.NoError(t, migrator.Up())
+	assertSchemaMatchesModels(t, db)
+}
+
+func assertSchemaMatchesModels(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	all := []interface{}{
+		&models.User{},
+		&models.Service{},
+		&models.Professional{},
+		&models.Appointment{},
+		&models.AppointmentItem{},
+		&models.AppointmentStatusHistory{},
+		&models.AppointmentSeries{},
+		&models.BusinessHours{},
+		&models.Holiday{},
+		&models.CancellationPolicy{},
+		&models.CustomerFee{},
+		&models.Job{},
+		&models.SweeperRules{},
+		&models.RefreshToken{},
+		&models.AccountToken{},
+		&models.LoginThrottle{},
+		&models.UserIdentity{},
+		&models.LoginState{},
+		&models.WaitlistEntry{},
+		&models.WaitlistOffer{},
+	}
+	for _, model := range all {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		if !assert.True(t, db.Migrator().HasTable(stmt.Schema.Table), stmt.Schema.Table) {
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+		}
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if rel.JoinTable != nil {
+				assert.True(t, db.Migrator().HasTable(rel.JoinTable.Table), rel.JoinTable.Table)
+			}
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	content := "-- comment\nCREATE TABLE a (\n    id bigint\n);\n\nCREATE INDEX i ON a(id);\n"
+	assert.Equal(t, []string{"CREATE TABLE a (\n    id bigint\n);", "CREATE INDEX i ON a(id);"}, splitStatements(content))
+	assert.Empty(t, splitStatements("-- nothing to revert\n"))
+}
+
+func TestLoadMigrations_BothDrivers(t *testing.T) {
+	sqlite, err := loadMigrations(DriverSQLite)
+	require.NoError(t, err)
+	postgres, err := loadMigrations(DriverPostgres)
+	require.NoError(t, err)
+
+	// Every migration is written for both drivers
+	require.Equal(t, len(sqlite), len(postgres))
+	for i := range sqlite {
+		assert.Equal(t, sqlite[i].Version, postgres[i].Version)
+		assert.Equal(t, sqlite[i].Name, postgres[i].Name)
+	}
+
+	_, err = loadMigrations("mysql")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+-- The schema AutoMigrate created before these migrations. The statements are skipped on the databases it
+-- created, so they are adopted as they are, and the later migrations bring them up to date.
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    email text,
+    password text,
+    role text,
+    name text,
+    phone text,
+    is_active boolean DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+CREATE TABLE IF NOT EXISTS services (
+    id bigserial PRIMARY KEY,
+    name text,
+    price decimal,
+    duration_minutes bigint
+);
+
+CREATE TABLE IF NOT EXISTS appointments (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    date timestamptz,
+    status text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_appointments_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS appointment_services (
+    appointment_id bigint,
+    service_id bigint,
+    PRIMARY KEY (appointment_id,service_id),
+    CONSTRAINT fk_appointment_services_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id),
+    CONSTRAINT fk_appointment_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
@@ -0,0 +1,22 @@
+-- Professionals, the services they perform and the professional each appointment is assigned to
+
+CREATE TABLE professionals (
+    id bigserial PRIMARY KEY,
+    name text,
+    phone text,
+    is_active boolean DEFAULT true,
+    user_id bigint,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX idx_professionals_user_id ON professionals(user_id);
+
+CREATE TABLE professional_services (
+    professional_id bigint,
+    service_id bigint,
+    PRIMARY KEY (professional_id,service_id),
+    CONSTRAINT fk_professional_services_professional FOREIGN KEY (professional_id) REFERENCES professionals(id),
+    CONSTRAINT fk_professional_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
+
+ALTER TABLE appointments ADD COLUMN professional_id bigint CONSTRAINT fk_appointments_professional REFERENCES professionals(id);
@@ -0,0 +1,2 @@
+DROP TABLE holidays;
+DROP TABLE business_hours;
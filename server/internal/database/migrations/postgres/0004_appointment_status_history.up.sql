@@ -0,0 +1,12 @@
+-- The status changes of each appointment
+
+CREATE TABLE appointment_status_history (
+    id bigserial PRIMARY KEY,
+    appointment_id bigint,
+    from_status text,
+    to_status text,
+    changed_by_id bigint,
+    changed_at timestamptz,
+    CONSTRAINT fk_appointment_status_history_changed_by FOREIGN KEY (changed_by_id) REFERENCES users(id)
+);
+CREATE INDEX idx_appointment_status_history_appointment_id ON appointment_status_history(appointment_id);
@@ -0,0 +1,3 @@
+ALTER TABLE appointments DROP COLUMN reschedule_count;
+DROP TABLE customer_fees;
+DROP TABLE cancellation_policies;
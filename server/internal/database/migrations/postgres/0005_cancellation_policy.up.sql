@@ -0,0 +1,24 @@
+-- The cancellation rules, the fees they charge and how often each appointment was rescheduled
+
+CREATE TABLE cancellation_policies (
+    id bigserial PRIMARY KEY,
+    reschedule_notice_hours bigint,
+    cancel_notice_hours bigint,
+    late_cancel_fee_percent decimal,
+    no_show_fee_percent decimal,
+    max_reschedules bigint,
+    updated_at timestamptz
+);
+
+CREATE TABLE customer_fees (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    appointment_id bigint,
+    reason text,
+    percent decimal,
+    amount decimal,
+    created_at timestamptz
+);
+CREATE INDEX idx_customer_fees_user_id ON customer_fees(user_id);
+
+ALTER TABLE appointments ADD COLUMN reschedule_count bigint;
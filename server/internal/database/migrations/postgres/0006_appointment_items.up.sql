@@ -0,0 +1,13 @@
+-- The name, price and duration of the services as they were booked
+
+CREATE TABLE appointment_items (
+    id bigserial PRIMARY KEY,
+    appointment_id bigint,
+    service_id bigint,
+    name text,
+    price decimal,
+    duration_minutes bigint,
+    CONSTRAINT fk_appointments_items FOREIGN KEY (appointment_id) REFERENCES appointments(id)
+);
+CREATE INDEX idx_appointment_items_service_id ON appointment_items(service_id);
+CREATE INDEX idx_appointment_items_appointment_id ON appointment_items(appointment_id);
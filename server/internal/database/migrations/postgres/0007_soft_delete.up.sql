@@ -0,0 +1,7 @@
+-- Services and users are archived instead of deleted
+
+ALTER TABLE users ADD COLUMN deleted_at timestamptz;
+CREATE INDEX idx_users_deleted_at ON users(deleted_at);
+
+ALTER TABLE services ADD COLUMN deleted_at timestamptz;
+CREATE INDEX idx_services_deleted_at ON services(deleted_at);
@@ -0,0 +1 @@
+-- The backfilled items cannot be told apart from the booked ones, they are kept
@@ -0,0 +1,18 @@
+-- Background jobs such as the appointment reminders
+
+CREATE TABLE jobs (
+    id bigserial PRIMARY KEY,
+    kind text,
+    appointment_id bigint,
+    payload text,
+    run_at timestamptz,
+    status text,
+    attempts bigint,
+    last_error text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX idx_jobs_status ON jobs(status);
+CREATE INDEX idx_jobs_run_at ON jobs(run_at);
+CREATE INDEX idx_jobs_appointment_id ON jobs(appointment_id);
+CREATE INDEX idx_jobs_kind ON jobs(kind);
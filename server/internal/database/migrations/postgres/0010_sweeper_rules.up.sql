@@ -0,0 +1,10 @@
+-- When overdue appointments expire or are completed
+
+CREATE TABLE sweeper_rules (
+    id bigserial PRIMARY KEY,
+    expire_pending boolean,
+    expire_after_minutes bigint,
+    auto_complete boolean,
+    complete_after_minutes bigint,
+    updated_at timestamptz
+);
@@ -0,0 +1,13 @@
+-- The failed logins of each account and IP address, and the accounts that must change the default password
+
+CREATE TABLE login_throttles (
+    id bigserial PRIMARY KEY,
+    "key" text,
+    failures bigint,
+    last_failure_at timestamptz,
+    locked_until timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX idx_login_throttles_key ON login_throttles("key");
+
+ALTER TABLE users ADD COLUMN must_change_password boolean;
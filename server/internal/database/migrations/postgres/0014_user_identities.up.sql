@@ -0,0 +1,24 @@
+-- The identity providers linked to each user and the logins in progress
+
+CREATE TABLE user_identities (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    provider text,
+    subject text,
+    email text,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX idx_identity_subject ON user_identities(provider,subject);
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE TABLE login_states (
+    id bigserial PRIMARY KEY,
+    state_hash text,
+    provider text,
+    nonce text,
+    code_verifier text,
+    expires_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX idx_login_states_expires_at ON login_states(expires_at);
+CREATE UNIQUE INDEX idx_login_states_state_hash ON login_states(state_hash);
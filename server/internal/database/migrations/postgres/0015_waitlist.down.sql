@@ -0,0 +1,3 @@
+DROP TABLE waitlist_offers;
+DROP TABLE waitlist_entry_services;
+DROP TABLE waitlist_entries;
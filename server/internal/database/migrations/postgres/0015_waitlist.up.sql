@@ -0,0 +1,48 @@
+-- The waitlist and the slots offered from it
+
+CREATE TABLE waitlist_entries (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    start_date text,
+    end_date text,
+    window_start text,
+    window_end text,
+    status text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_waitlist_entries_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX idx_waitlist_entries_status ON waitlist_entries(status);
+CREATE INDEX idx_waitlist_entries_end_date ON waitlist_entries(end_date);
+CREATE INDEX idx_waitlist_entries_start_date ON waitlist_entries(start_date);
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+
+CREATE TABLE waitlist_entry_services (
+    waitlist_entry_id bigint,
+    service_id bigint,
+    PRIMARY KEY (waitlist_entry_id,service_id),
+    CONSTRAINT fk_waitlist_entry_services_waitlist_entry FOREIGN KEY (waitlist_entry_id) REFERENCES waitlist_entries(id),
+    CONSTRAINT fk_waitlist_entry_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
+
+CREATE TABLE waitlist_offers (
+    id bigserial PRIMARY KEY,
+    entry_id bigint,
+    user_id bigint,
+    source_appointment_id bigint,
+    date timestamptz,
+    duration_minutes bigint,
+    professional_id bigint,
+    status text,
+    expires_at timestamptz,
+    appointment_id bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_waitlist_offers_entry FOREIGN KEY (entry_id) REFERENCES waitlist_entries(id)
+);
+CREATE INDEX idx_waitlist_offers_expires_at ON waitlist_offers(expires_at);
+CREATE INDEX idx_waitlist_offers_status ON waitlist_offers(status);
+CREATE INDEX idx_waitlist_offers_date ON waitlist_offers(date);
+CREATE INDEX idx_waitlist_offers_source_appointment_id ON waitlist_offers(source_appointment_id);
+CREATE INDEX idx_waitlist_offers_user_id ON waitlist_offers(user_id);
+CREATE INDEX idx_waitlist_offers_entry_id ON waitlist_offers(entry_id);
@@ -0,0 +1,15 @@
+-- Recurring appointments
+
+CREATE TABLE appointment_series (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    frequency text,
+    "interval" bigint,
+    count bigint,
+    until text,
+    created_at timestamptz
+);
+CREATE INDEX idx_appointment_series_user_id ON appointment_series(user_id);
+
+ALTER TABLE appointments ADD COLUMN series_id bigint;
+CREATE INDEX idx_appointments_series_id ON appointments(series_id);
@@ -0,0 +1,40 @@
+-- The schema AutoMigrate created before these migrations. The statements are skipped on the databases it
+-- created, so they are adopted as they are, and the later migrations bring them up to date.
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    email text,
+    password text,
+    role text,
+    name text,
+    phone text,
+    is_active numeric DEFAULT true,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+CREATE TABLE IF NOT EXISTS services (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text,
+    price real,
+    duration_minutes integer
+);
+
+CREATE TABLE IF NOT EXISTS appointments (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    date datetime,
+    status text,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_appointments_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS appointment_services (
+    appointment_id integer,
+    service_id integer,
+    PRIMARY KEY (appointment_id,service_id),
+    CONSTRAINT fk_appointment_services_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id),
+    CONSTRAINT fk_appointment_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE appointments DROP COLUMN professional_id;
+DROP TABLE professional_services;
+DROP TABLE professionals;
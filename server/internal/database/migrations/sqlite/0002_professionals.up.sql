@@ -0,0 +1,22 @@
+-- Professionals, the services they perform and the professional each appointment is assigned to
+
+CREATE TABLE professionals (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text,
+    phone text,
+    is_active numeric DEFAULT true,
+    user_id integer,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_professionals_user_id ON professionals(user_id);
+
+CREATE TABLE professional_services (
+    professional_id integer,
+    service_id integer,
+    PRIMARY KEY (professional_id,service_id),
+    CONSTRAINT fk_professional_services_professional FOREIGN KEY (professional_id) REFERENCES professionals(id),
+    CONSTRAINT fk_professional_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
+
+ALTER TABLE appointments ADD COLUMN professional_id integer CONSTRAINT fk_appointments_professional REFERENCES professionals(id);
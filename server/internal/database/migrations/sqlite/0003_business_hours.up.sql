@@ -0,0 +1,16 @@
+-- The opening hours of each weekday and the days the salon is closed
+
+CREATE TABLE business_hours (
+    weekday integer,
+    is_open numeric,
+    opens_at text,
+    closes_at text,
+    PRIMARY KEY (weekday)
+);
+
+CREATE TABLE holidays (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    date text,
+    description text
+);
+CREATE UNIQUE INDEX idx_holidays_date ON holidays(date);
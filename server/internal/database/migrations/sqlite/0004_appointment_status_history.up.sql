@@ -0,0 +1,12 @@
+-- The status changes of each appointment
+
+CREATE TABLE appointment_status_history (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    appointment_id integer,
+    from_status text,
+    to_status text,
+    changed_by_id integer,
+    changed_at datetime,
+    CONSTRAINT fk_appointment_status_history_changed_by FOREIGN KEY (changed_by_id) REFERENCES users(id)
+);
+CREATE INDEX idx_appointment_status_history_appointment_id ON appointment_status_history(appointment_id);
@@ -0,0 +1,24 @@
+-- The cancellation rules, the fees they charge and how often each appointment was rescheduled
+
+CREATE TABLE cancellation_policies (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    reschedule_notice_hours integer,
+    cancel_notice_hours integer,
+    late_cancel_fee_percent real,
+    no_show_fee_percent real,
+    max_reschedules integer,
+    updated_at datetime
+);
+
+CREATE TABLE customer_fees (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    appointment_id integer,
+    reason text,
+    percent real,
+    amount real,
+    created_at datetime
+);
+CREATE INDEX idx_customer_fees_user_id ON customer_fees(user_id);
+
+ALTER TABLE appointments ADD COLUMN reschedule_count integer;
@@ -0,0 +1,13 @@
+-- The name, price and duration of the services as they were booked
+
+CREATE TABLE appointment_items (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    appointment_id integer,
+    service_id integer,
+    name text,
+    price real,
+    duration_minutes integer,
+    CONSTRAINT fk_appointments_items FOREIGN KEY (appointment_id) REFERENCES appointments(id)
+);
+CREATE INDEX idx_appointment_items_service_id ON appointment_items(service_id);
+CREATE INDEX idx_appointment_items_appointment_id ON appointment_items(appointment_id);
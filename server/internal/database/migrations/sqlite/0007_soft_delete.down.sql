@@ -0,0 +1,4 @@
+DROP INDEX idx_services_deleted_at;
+ALTER TABLE services DROP COLUMN deleted_at;
+DROP INDEX idx_users_deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,8 @@
+-- Appointments booked before their services were copied into appointment_items get the current catalog values,
+-- including the services removed from the catalog since, so their history is kept
+INSERT INTO appointment_items (appointment_id, service_id, name, price, duration_minutes)
+SELECT appointment_services.appointment_id, services.id, services.name, services.price, services.duration_minutes
+FROM appointment_services
+JOIN services ON services.id = appointment_services.service_id
+WHERE NOT EXISTS (SELECT 1 FROM appointment_items WHERE appointment_items.appointment_id = appointment_services.appointment_id)
+ORDER BY appointment_services.appointment_id, services.id;
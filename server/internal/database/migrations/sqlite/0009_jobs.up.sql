@@ -0,0 +1,18 @@
+-- Background jobs such as the appointment reminders
+
+CREATE TABLE jobs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    kind text,
+    appointment_id integer,
+    payload text,
+    run_at datetime,
+    status text,
+    attempts integer,
+    last_error text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_jobs_status ON jobs(status);
+CREATE INDEX idx_jobs_run_at ON jobs(run_at);
+CREATE INDEX idx_jobs_appointment_id ON jobs(appointment_id);
+CREATE INDEX idx_jobs_kind ON jobs(kind);
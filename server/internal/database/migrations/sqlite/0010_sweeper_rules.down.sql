@@ -0,0 +1 @@
+DROP TABLE sweeper_rules;
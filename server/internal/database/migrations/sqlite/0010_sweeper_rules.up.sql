@@ -0,0 +1,10 @@
+-- When overdue appointments expire or are completed
+
+CREATE TABLE sweeper_rules (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    expire_pending numeric,
+    expire_after_minutes integer,
+    auto_complete numeric,
+    complete_after_minutes integer,
+    updated_at datetime
+);
@@ -0,0 +1,15 @@
+-- The refresh tokens of the sessions
+
+CREATE TABLE refresh_tokens (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    family_id text,
+    token_hash text,
+    expires_at datetime,
+    used_at datetime,
+    revoked_at datetime,
+    created_at datetime
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens(token_hash);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
@@ -0,0 +1,16 @@
+-- The password reset and email verification links, and when each email was verified
+
+CREATE TABLE account_tokens (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    purpose text,
+    token_hash text,
+    expires_at datetime,
+    used_at datetime,
+    created_at datetime
+);
+CREATE UNIQUE INDEX idx_account_tokens_token_hash ON account_tokens(token_hash);
+CREATE INDEX idx_account_tokens_purpose ON account_tokens(purpose);
+CREATE INDEX idx_account_tokens_user_id ON account_tokens(user_id);
+
+ALTER TABLE users ADD COLUMN email_verified_at datetime;
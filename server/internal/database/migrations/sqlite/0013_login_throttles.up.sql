@@ -0,0 +1,13 @@
+-- The failed logins of each account and IP address, and the accounts that must change the default password
+
+CREATE TABLE login_throttles (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    "key" text,
+    failures integer,
+    last_failure_at datetime,
+    locked_until datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_login_throttles_key ON login_throttles("key");
+
+ALTER TABLE users ADD COLUMN must_change_password numeric;
@@ -0,0 +1,48 @@
+-- The waitlist and the slots offered from it
+
+CREATE TABLE waitlist_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    start_date text,
+    end_date text,
+    window_start text,
+    window_end text,
+    status text,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_waitlist_entries_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX idx_waitlist_entries_status ON waitlist_entries(status);
+CREATE INDEX idx_waitlist_entries_end_date ON waitlist_entries(end_date);
+CREATE INDEX idx_waitlist_entries_start_date ON waitlist_entries(start_date);
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries(user_id);
+
+CREATE TABLE waitlist_entry_services (
+    waitlist_entry_id integer,
+    service_id integer,
+    PRIMARY KEY (waitlist_entry_id,service_id),
+    CONSTRAINT fk_waitlist_entry_services_waitlist_entry FOREIGN KEY (waitlist_entry_id) REFERENCES waitlist_entries(id),
+    CONSTRAINT fk_waitlist_entry_services_service FOREIGN KEY (service_id) REFERENCES services(id)
+);
+
+CREATE TABLE waitlist_offers (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    entry_id integer,
+    user_id integer,
+    source_appointment_id integer,
+    date datetime,
+    duration_minutes integer,
+    professional_id integer,
+    status text,
+    expires_at datetime,
+    appointment_id integer,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_waitlist_offers_entry FOREIGN KEY (entry_id) REFERENCES waitlist_entries(id)
+);
+CREATE INDEX idx_waitlist_offers_expires_at ON waitlist_offers(expires_at);
+CREATE INDEX idx_waitlist_offers_status ON waitlist_offers(status);
+CREATE INDEX idx_waitlist_offers_date ON waitlist_offers(date);
+CREATE INDEX idx_waitlist_offers_source_appointment_id ON waitlist_offers(source_appointment_id);
+CREATE INDEX idx_waitlist_offers_user_id ON waitlist_offers(user_id);
+CREATE INDEX idx_waitlist_offers_entry_id ON waitlist_offers(entry_id);
@@ -0,0 +1,3 @@
+DROP INDEX idx_appointments_series_id;
+ALTER TABLE appointments DROP COLUMN series_id;
+DROP TABLE appointment_series;
@@ -0,0 +1,15 @@
+-- Recurring appointments
+
+CREATE TABLE appointment_series (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    frequency text,
+    "interval" integer,
+    count integer,
+    until text,
+    created_at datetime
+);
+CREATE INDEX idx_appointment_series_user_id ON appointment_series(user_id);
+
+ALTER TABLE appointments ADD COLUMN series_id integer;
+CREATE INDEX idx_appointments_series_id ON appointments(series_id);
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ErrorResponse struct {
@@ -18,6 +21,8 @@ type ErrorResponse struct {
 // AppointmentHandler contém dependências (service).
 type AppointmentHandler struct {
 	svc service.AppointmentService
+	// users is set when customers must verify their email before booking
+	users repository.UserRepository
 }
 
 // NewAppointmentHandler cria um handler com a service injetada.
@@ -25,26 +30,125 @@ func NewAppointmentHandler(svc service.AppointmentService) *AppointmentHandler {
 	return &AppointmentHandler{svc: svc}
 }
 
+// RequireVerifiedEmail makes customers verify their email before booking. Staff booking for a customer are not affected.
+func (h *AppointmentHandler) RequireVerifiedEmail(users repository.UserRepository) {
+	h.users = users
+}
+
 // RegisterRoutes registra rotas no router (group).
 func (h *AppointmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("/appointments", h.CreateAppointment)
+	rg.POST("/appointments/series", h.CreateSeries)
 	rg.PUT("/appointments/:id", h.UpdateAppointment)
 	rg.POST("/appointments/:id/cancel", h.CancelAppointment)
 	rg.POST("/appointments/:id/merge", h.MergeAppointments)
+	rg.GET("/appointments/:id/history", h.GetStatusHistory)
 	// rg.GET("/appointments/:id", h.GetAppointment)
 	rg.GET("/appointments", h.ListUserAppointments)
+	rg.GET("/agenda", RequirePermission(models.PermViewOwnAgenda), h.ListMyAgenda)
 
 	// Operacional
-	rg.GET("/admin/incoming", h.ListIncoming)
-	rg.PATCH("/admin/appointments/:id/status", h.ChangeStatus)
+	rg.GET("/admin/incoming", RequirePermission(models.PermViewAppointments), h.ListIncoming)
+	rg.PATCH("/admin/appointments/:id/status", RequirePermission(models.PermManageAppointments), h.ChangeStatus)
 	// rg.PUT("/admin/appointments/:id/services/:serviceID/status", h.UpdateServiceStatus)
+}
+
+// ListAllAppointments godoc
+// @Summary      Busca agendamentos de todos os usuários
+// @Description  Filtra por status, serviços, cliente, profissional, período e texto livre no nome, email ou telefone do cliente. O resultado é paginado: envie o next_cursor recebido como cursor para obter a próxima página.
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        status        query  string  false  "Status separados por vírgula"
+// @Param        services      query  string  false  "IDs de serviços separados por vírgula, qualquer um deles"
+// @Param        user          query  int     false  "ID do cliente"
+// @Param        professional  query  int     false  "ID do profissional"
+// @Param        start_date    query  string  false  "Primeiro dia (YYYY-MM-DD)"
+// @Param        end_date      query  string  false  "Último dia (YYYY-MM-DD)"
+// @Param        q             query  string  false  "Parte do nome, email ou telefone do cliente"
+// @Param        sort          query  string  false  "date (padrão), -date, created_at ou -created_at"
+// @Param        cursor        query  string  false  "next_cursor da página anterior"
+// @Param        limit         query  int     false  "Tamanho da página (padrão 50, máximo 200)"
+// @Param        total         query  bool    false  "Envia o total de agendamentos no cabeçalho X-Total-Count"
+// @Success      200  {object}  PageResponse[models.Appointment]
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/appointments [get]
+func (h *AppointmentHandler) ListAllAppointments(c *gin.Context) {
+	query, ok := parseAppointmentQuery(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.svc.SearchAppointments(query)
+	if err != nil {
+		writeAppointmentError(c, err)
+		return
+	}
+	writePage(c, list.Items, list.PageInfo)
+}
+
+// parseAppointmentQuery reads the search filters from the query string, writing the response when one is malformed
+func parseAppointmentQuery(c *gin.Context) (models.AppointmentQuery, bool) {
+	page, ok := parsePageRequest(c)
+	if !ok {
+		return models.AppointmentQuery{}, false
+	}
+	query := models.AppointmentQuery{
+		Text: c.Query("q"),
+		Sort: models.AppointmentSort(c.Query("sort")),
+		Page: page,
+	}
+	fail := func(message string) (models.AppointmentQuery, bool) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return models.AppointmentQuery{}, false
+	}
+
+	for _, status := range strings.Split(c.Query("status"), ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			query.Statuses = append(query.Statuses, models.AppointmentStatus(strings.ToUpper(status)))
+		}
+	}
+	ids, err := parseIDList(c.Query("services"))
+	if err != nil {
+		return fail("invalid services, use comma-separated IDs")
+	}
+	query.ServiceIDs = ids
+
+	for param, target := range map[string]**uint{"user": &query.UserID, "professional": &query.ProfessionalID} {
+		if value := c.Query(param); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return fail("invalid " + param + " ID")
+			}
+			parsed := uint(id)
+			*target = &parsed
+		}
+	}
 
-	// Gerencial
-	// rg.GET("/admin/weekly-performance", h.WeeklyPerformance)
+	if value := c.Query("start_date"); value != "" {
+		day, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+		if err != nil {
+			return fail("invalid start_date, use YYYY-MM-DD")
+		}
+		query.From = &day
+	}
+	if value := c.Query("end_date"); value != "" {
+		day, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+		if err != nil {
+			return fail("invalid end_date, use YYYY-MM-DD")
+		}
+		// The whole last day is included
+		last := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		query.To = &last
+	}
+	return query, true
 }
 
 // ListUserAppointments godoc
-// @Summary      Lista agendamentos de todos usuarios
+// @Summary      Lista agendamentos
 // @Description  Retorna histórico ou agendamentos em um período
 // @Tags         appointments
 // @Security     Bearer
@@ -52,18 +156,18 @@ func (h *AppointmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
 // @Produce      json
 // @Param        start_date  query  string  false  "Data inicial"
 // @Param        end_date    query  string  false  "Data final"
-// @Success      200  {array}  models.Appointment
+// @Param        cursor      query  string  false  "next_cursor da página anterior"
+// @Param        limit       query  int     false  "Tamanho da página (padrão 50, máximo 200)"
+// @Param        total       query  bool    false  "Envia o total de agendamentos no cabeçalho X-Total-Count"
+// @Success      200  {object}  PageResponse[models.Appointment]
+// @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  map[string]string
 // @Failure      500  {object}  ErrorResponse
-// @Router       /admin/appointments [get]
-func (h *AppointmentHandler) ListAllAppointments(c *gin.Context) {
-	role, exists := c.Get("role")
+// @Router       /appointments [get]
+func (h *AppointmentHandler) ListUserAppointments(c *gin.Context) {
+	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing role in token"})
-		return
-	}
-	if role != models.RoleAdmin {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "you can only list your own appointments"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
 		return
 	}
 
@@ -74,37 +178,46 @@ func (h *AppointmentHandler) ListAllAppointments(c *gin.Context) {
 	}
 
 	if filter.StartDate == nil {
-		dftStart := time.Now().AddDate(0, -3, 0)
+		dftStart := time.Now().AddDate(0, -1, 0)
 		filter.StartDate = &dftStart
 	}
 	if filter.EndDate == nil {
-		dftEnd := time.Now().AddDate(0, 3, 0)
+		dftEnd := time.Now().AddDate(0, 1, 0)
 		filter.EndDate = &dftEnd
 	}
 
-	list, err := h.svc.ListHistory(*filter.StartDate, *filter.EndDate)
+	page, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
 
+	list, err := h.svc.ListUserHistory(userID.(uint), *filter.StartDate, *filter.EndDate, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, list)
+	writePage(c, list.Items, list.PageInfo)
 }
 
-// ListUserAppointments godoc
-// @Summary      Lista agendamentos
-// @Description  Retorna histórico ou agendamentos em um período
+// ListMyAgenda godoc
+// @Summary      Lista a agenda do profissional
+// @Description  Retorna os agendamentos atribuídos ao profissional vinculado ao usuário, por padrão da semana atual em diante
 // @Tags         appointments
 // @Security     Bearer
-// @Accept       json
 // @Produce      json
 // @Param        start_date  query  string  false  "Data inicial"
 // @Param        end_date    query  string  false  "Data final"
-// @Success      200  {array}  models.Appointment
-// @Failure      401  {object}  map[string]string
+// @Param        cursor      query  string  false  "next_cursor da página anterior"
+// @Param        limit       query  int     false  "Tamanho da página (padrão 50, máximo 200)"
+// @Param        total       query  bool    false  "Envia o total de agendamentos no cabeçalho X-Total-Count"
+// @Success      200  {object}  PageResponse[models.Appointment]
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
-// @Router       /appointments [get]
-func (h *AppointmentHandler) ListUserAppointments(c *gin.Context) {
+// @Router       /agenda [get]
+func (h *AppointmentHandler) ListMyAgenda(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
@@ -118,7 +231,7 @@ func (h *AppointmentHandler) ListUserAppointments(c *gin.Context) {
 	}
 
 	if filter.StartDate == nil {
-		dftStart := time.Now().AddDate(0, -1, 0)
+		dftStart := time.Now().AddDate(0, 0, -7)
 		filter.StartDate = &dftStart
 	}
 	if filter.EndDate == nil {
@@ -126,13 +239,17 @@ func (h *AppointmentHandler) ListUserAppointments(c *gin.Context) {
 		filter.EndDate = &dftEnd
 	}
 
-	list, err := h.svc.ListUserHistory(userID.(uint), *filter.StartDate, *filter.EndDate)
+	page, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
 
+	list, err := h.svc.ListProfessionalAgenda(userID.(uint), *filter.StartDate, *filter.EndDate, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, list)
+	writePage(c, list.Items, list.PageInfo)
 }
 
 type CreationAppointmentResponse struct {
@@ -151,13 +268,16 @@ type CreationAppointmentResponse struct {
 // @Success      201  {object}  models.Appointment
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /appointments [post]
 func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 	var req struct {
-		Services []models.Service `json:"services"`
-		Date     time.Time        `json:"date"`
-		UserID   *uint            `json:"user_id,omitempty"` // Optional, only for admins
+		Services       []models.Service `json:"services"`
+		Date           time.Time        `json:"date"`
+		UserID         *uint            `json:"user_id,omitempty"`         // Optional, only for staff who manage appointments
+		ProfessionalID *uint            `json:"professional_id,omitempty"` // Optional, any available professional when empty
 	}
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -169,47 +289,120 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 		return
 	}
 
+	appointmentUserID, ok := h.bookingUser(c, req.UserID)
+	if !ok {
+		return
+	}
+
+	ap, suggestion, err := h.svc.CreateAppointment(appointmentUserID, req.Services, req.Date, req.ProfessionalID)
+	if err != nil {
+		writeAppointmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreationAppointmentResponse{
+		Appointment: ap,
+		Suggestion:  suggestion,
+	})
+}
+
+type CreateSeriesRequest struct {
+	Services []models.Service `json:"services"`
+	// Date is the first appointment of the series, the others follow the recurrence at the same time of day
+	Date           time.Time         `json:"date"`
+	UserID         *uint             `json:"user_id,omitempty"`         // Optional, only for staff who manage appointments
+	ProfessionalID *uint             `json:"professional_id,omitempty"` // Optional, any available professional when empty
+	Recurrence     models.Recurrence `json:"recurrence"`
+}
+
+// CreateSeries godoc
+// @Summary      Cria uma série de agendamentos recorrentes
+// @Description  Agenda os serviços em todas as datas da recorrência (semanal, a cada N semanas ou mensal pelo dia da semana). As datas indisponíveis são listadas em conflicts e as demais são agendadas.
+// @Tags         appointments
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        request  body  CreateSeriesRequest  true  "Dados da série"
+// @Success      201  {object}  models.SeriesResult
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      409  {object}  models.SeriesResult
+// @Failure      500  {object}  ErrorResponse
+// @Router       /appointments/series [post]
+func (h *AppointmentHandler) CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Date.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "appointment date cannot be in the past"})
+		return
+	}
+
+	appointmentUserID, ok := h.bookingUser(c, req.UserID)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.CreateSeries(appointmentUserID, req.Services, req.Date, req.ProfessionalID, req.Recurrence)
+	if errors.Is(err, models.ErrSeriesUnavailable) {
+		c.JSON(http.StatusConflict, result)
+		return
+	}
+	if err != nil {
+		writeAppointmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+// bookingUser returns whom the logged user is booking for, writing the response when they may not book
+func (h *AppointmentHandler) bookingUser(c *gin.Context, requested *uint) (uint, bool) {
 	// Extract user info from JWT claims
 	userID, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
-		return
+		return 0, false
 	}
 
 	role, exists := c.Get("role")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing role in token"})
-		return
+		return 0, false
 	}
 
-	// Determine user ID based on role
+	// Staff who manage appointments may book for a customer
 	appointmentUserID := userID.(uint)
-	if role == models.RoleAdmin {
-		if req.UserID != nil {
-			appointmentUserID = *req.UserID
+	if userRole, _ := role.(models.UserRole); userRole.Can(models.PermManageAppointments) {
+		if requested != nil {
+			appointmentUserID = *requested
+		}
+	} else if h.users != nil {
+		user, err := h.users.FindByID(appointmentUserID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+			return 0, false
+		}
+		if !user.EmailVerified() {
+			c.JSON(http.StatusForbidden, gin.H{"error": models.ErrEmailNotVerified.Error()})
+			return 0, false
 		}
 	}
-
-	ap, suggestion, err := h.svc.CreateAppointment(appointmentUserID, req.Services, req.Date)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, CreationAppointmentResponse{
-		Appointment: ap,
-		Suggestion:  suggestion,
-	})
+	return appointmentUserID, true
 }
 
 // UpdateAppointment godoc
 // @Summary      Atualiza um agendamento
-// @Description  Permite alteração até 2 dias antes
+// @Description  Clientes respeitam a antecedência mínima e o limite de reagendamentos da política de cancelamento. Com scope=following, a alteração vale também para os agendamentos seguintes da série, deslocados pelos mesmos dias, e a resposta é um models.SeriesResult.
 // @Tags         appointments
 // @Security     Bearer
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int                   true  "ID do agendamento"
+// @Param        scope  query   string                false  "this (padrão) ou following"
 // @Param        appointment  body  models.Appointment  true  "Dados do agendamento"
 // @Success      200  {object}  models.Appointment
 // @Failure      400  {object}  ErrorResponse
@@ -218,18 +411,12 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 // @Failure      500  {object}  ErrorResponse
 // @Router       /admin/appointments/{id} [put]
 func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
-	userID, exists := c.Get("userID")
-	if !exists {
+	claims, ok := claimsFromContext(c)
+	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
 		return
 	}
 
-	role, exists := c.Get("role")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing role in token"})
-		return
-	}
-
 	idParam := c.Param("id")
 	var req models.Appointment
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -242,36 +429,51 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 		return
 	}
 
-	// Customers can only update their own appointments, admins can update any
-	if role != models.RoleAdmin && req.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "you can only update your own appointments"})
+	following, ok := seriesScope(c)
+	if !ok {
 		return
 	}
 
 	req.UpdatedAt = time.Now()
-	updated, err := h.svc.UpdateAppointment(uint(id), req, role.(models.UserRole))
-	if err != nil {
-		if errors.Is(err, models.ErrCannotUpdateWithingTwoDays) {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	if following {
+		result, err := h.svc.UpdateFollowing(claims, uint(id), req)
+		if err != nil {
+			writeAppointmentError(c, err)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	updated, err := h.svc.UpdateAppointment(claims, uint(id), req)
+	if err != nil {
+		writeAppointmentError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, updated)
 }
 
-// ChangeStatus godoc
+// CancelAppointment godoc
 // @Summary      Cancela um agendamento
-// @Description  Cancela um agendamento
+// @Description  Clientes só podem cancelar seus próprios agendamentos; cancelamentos tardios são recusados ou geram taxa conforme a política. Com scope=following, cancela também os agendamentos seguintes da série e a resposta é um models.SeriesResult.
 // @Tags         appointments
+// @Security     Bearer
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "ID do agendamento"
-// @Success      200  {object}  nil
+// @Param        id     path   int     true   "ID do agendamento"
+// @Param        scope  query  string  false  "this (padrão) ou following"
+// @Success      200  {object}  models.Appointment
 // @Failure      400  {object}  ErrorResponse
-// @Router       /admin/appointments/{id}/confirm [post]
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /appointments/{id}/cancel [post]
 func (h *AppointmentHandler) CancelAppointment(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
@@ -279,9 +481,23 @@ func (h *AppointmentHandler) CancelAppointment(c *gin.Context) {
 		return
 	}
 
-	ap, err := h.svc.ChangeStatus(uint(id), models.StatusCanceled)
+	following, ok := seriesScope(c)
+	if !ok {
+		return
+	}
+
+	if following {
+		result, err := h.svc.CancelFollowing(claims, uint(id))
+		if err != nil {
+			writeAppointmentError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	ap, err := h.svc.CancelAppointment(claims, uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, ap)
@@ -293,29 +509,48 @@ func (h *AppointmentHandler) CancelAppointment(c *gin.Context) {
 // @Tags         admin
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}  models.Appointment
+// @Param        cursor  query  string  false  "next_cursor da página anterior"
+// @Param        limit   query  int     false  "Tamanho da página (padrão 50, máximo 200)"
+// @Param        total   query  bool    false  "Envia o total de agendamentos no cabeçalho X-Total-Count"
+// @Success      200  {object}  PageResponse[models.Appointment]
+// @Failure      400  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /admin/incoming [get]
 func (h *AppointmentHandler) ListIncoming(c *gin.Context) {
-	list, err := h.svc.ListHistory(time.Now(), time.Now().AddDate(0, 0, 7))
+	page, ok := parsePageRequest(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.svc.ListHistory(time.Now(), time.Now().AddDate(0, 0, 7), page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, list)
+	writePage(c, list.Items, list.PageInfo)
 }
 
 // ChangeStatus godoc
 // @Summary      Atualiza o status de um agendamento
-// @Description  Atualiza o status de um agendamento operacionalmente
+// @Description  Atualiza o status de um agendamento operacionalmente, respeitando as transições permitidas
 // @Tags         admin
+// @Security     Bearer
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "ID do agendamento"
-// @Success      200  {object}  nil
+// @Param        id      path  int                       true  "ID do agendamento"
+// @Param        status  body  models.AppointmentStatus  true  "Novo status"
+// @Success      200  {object}  models.Appointment
 // @Failure      400  {object}  ErrorResponse
-// @Router       /admin/appointments/{id}/confirm [post]
+// @Failure      403  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Router       /admin/appointments/{id}/status [patch]
 func (h *AppointmentHandler) ChangeStatus(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
 	var req models.AppointmentStatus
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -328,14 +563,47 @@ func (h *AppointmentHandler) ChangeStatus(c *gin.Context) {
 		return
 	}
 
-	ap, err := h.svc.ChangeStatus(uint(id), req)
+	ap, err := h.svc.ChangeStatus(claims, uint(id), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, ap)
 }
 
+// GetStatusHistory godoc
+// @Summary      Histórico de status de um agendamento
+// @Description  Lista quem alterou o status do agendamento e quando
+// @Tags         appointments
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "ID do agendamento"
+// @Success      200  {array}   models.AppointmentStatusHistory
+// @Failure      400  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /appointments/{id}/history [get]
+func (h *AppointmentHandler) GetStatusHistory(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid appointment ID"})
+		return
+	}
+
+	history, err := h.svc.GetStatusHistory(claims, uint(id))
+	if err != nil {
+		writeAppointmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 type MergeAppointmentsRequest struct {
 	Services []models.Service `json:"services"`
 }
@@ -352,10 +620,17 @@ type MergeAppointmentsRequest struct {
 // @Success      200  {object}  models.Appointment
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
 // @Failure      404  {object}  ErrorResponse
 // @Failure      500  {object}  ErrorResponse
 // @Router       /appointments/{id}/merge [post]
 func (h *AppointmentHandler) MergeAppointments(c *gin.Context) {
+	claims, ok := claimsFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
@@ -374,39 +649,62 @@ func (h *AppointmentHandler) MergeAppointments(c *gin.Context) {
 		return
 	}
 
-	merged, err := h.svc.MergeAppointments(uint(id), req.Services)
+	merged, err := h.svc.MergeAppointments(claims, uint(id), req.Services)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeAppointmentError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, merged)
 }
 
-// // WeeklyPerformance - endpoint gerencial com desempenho semanal.
-// func (h *AppointmentHandler) WeeklyPerformance(c *gin.Context) {
-// 	weekStartStr := c.Query("weekStart")
-// 	var weekStart time.Time
-// 	var err error
-// 	if weekStartStr == "" {
-// 		// calcula início da semana atual (segunda-feira)
-// 		now := time.Now()
-// 		weekday := int(now.Weekday())
-// 		// Go: Sunday==0, Monday==1
-// 		daysToMonday := (weekday + 6) % 7
-// 		weekStart = time.Date(now.Year(), now.Month(), now.Day()-daysToMonday, 0, 0, 0, 0, now.Location())
-// 	} else {
-// 		weekStart, err = time.Parse("2006-01-02", weekStartStr)
-// 		if err != nil {
-// 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid weekStart format, use YYYY-MM-DD"})
-// 			return
-// 		}
-// 	}
-
-// 	perf, err := h.svc.WeeklyPerformance(c.Request.Context(), weekStart)
-// 	if err != nil {
-// 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-// 		return
-// 	}
-// 	c.JSON(http.StatusOK, perf)
-// }
+// claimsFromContext returns the token claims stored by the JWT middleware
+func claimsFromContext(c *gin.Context) (*models.CustomClaims, bool) {
+	value, exists := c.Get("claims")
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*models.CustomClaims)
+	return claims, ok
+}
+
+// seriesScope reports whether the request applies to the following appointments of the series too,
+// writing the response when the scope is unknown
+func seriesScope(c *gin.Context) (bool, bool) {
+	switch c.Query("scope") {
+	case "", "this":
+		return false, true
+	case "following":
+		return true, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope must be this or following"})
+		return false, false
+	}
+}
+
+// writeAppointmentError maps domain errors from the appointment service to HTTP responses
+func writeAppointmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "appointment not found"})
+	case errors.Is(err, models.ErrNoLinkedProfessional):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrRescheduleTooLate), errors.Is(err, models.ErrRescheduleLimitReached),
+		errors.Is(err, models.ErrCancelTooLate), errors.Is(err, models.ErrAppointmentNotOwned),
+		errors.Is(err, models.ErrAdminOnly):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidStatusTransition), errors.Is(err, models.ErrAppointmentClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrTimeSlotUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrAppointmentNoServices), errors.Is(err, models.ErrUnknownService), errors.Is(err, models.ErrInvalidStatus),
+		errors.Is(err, models.ErrInvalidRecurrence), errors.Is(err, models.ErrNotInSeries),
+		errors.Is(err, models.ErrInvalidSort), errors.Is(err, models.ErrInvalidCursor),
+		errors.Is(err, models.ErrUnknownProfessional), errors.Is(err, models.ErrProfessionalCannotPerform),
+		errors.Is(err, models.ErrNoProfessionalForServices), errors.Is(err, models.ErrSalonClosed),
+		errors.Is(err, models.ErrHolidayClosure), errors.Is(err, models.ErrOutsideBusinessHours):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
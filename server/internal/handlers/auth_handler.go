@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
@@ -12,14 +17,20 @@ import (
 )
 
 type AuthHandler struct {
-	authSvc  service.AuthService
-	userRepo repository.UserRepository
+	authSvc    service.AuthService
+	sessionSvc service.SessionService
+	accountSvc service.AccountService
+	loginGuard service.LoginGuard
+	userRepo   repository.UserRepository
 }
 
-func NewAuthHandler(authSvc service.AuthService, userRepo repository.UserRepository) *AuthHandler {
+func NewAuthHandler(authSvc service.AuthService, sessionSvc service.SessionService, accountSvc service.AccountService, loginGuard service.LoginGuard, userRepo repository.UserRepository) *AuthHandler {
 	return &AuthHandler{
-		authSvc:  authSvc,
-		userRepo: userRepo,
+		authSvc:    authSvc,
+		sessionSvc: sessionSvc,
+		accountSvc: accountSvc,
+		loginGuard: loginGuard,
+		userRepo:   userRepo,
 	}
 }
 
@@ -29,19 +40,24 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string   `json:"token"`
-	User  UserInfo `json:"user"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	User         UserInfo `json:"user"`
 }
 
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
 	Name     string `json:"name" binding:"required"`
+	Phone    string `json:"phone" binding:"omitempty,max=20"`
 }
 
 type RegisterResponse struct {
-	Token string   `json:"token"`
-	User  UserInfo `json:"user"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	User         UserInfo `json:"user"`
 }
 
 type UserInfo struct {
@@ -49,6 +65,23 @@ type UserInfo struct {
 	Email string          `json:"email"`
 	Name  string          `json:"name"`
 	Role  models.UserRole `json:"role"`
+	// Permissions lets the client show only what the role allows
+	Permissions   []models.Permission `json:"permissions"`
+	EmailVerified bool                `json:"email_verified"`
+	// MustChangePassword means the tokens only allow changing the password until it is changed
+	MustChangePassword bool `json:"must_change_password"`
+}
+
+func newUserInfo(user models.User) UserInfo {
+	return UserInfo{
+		ID:                 user.ID,
+		Email:              user.Email,
+		Name:               user.Name,
+		Role:               user.Role,
+		Permissions:        user.Role.Permissions(),
+		EmailVerified:      user.EmailVerified(),
+		MustChangePassword: user.MustChangePassword,
+	}
 }
 
 type ValidateTokenResponse struct {
@@ -56,10 +89,20 @@ type ValidateTokenResponse struct {
 	UserID uint   `json:"user_id,omitempty"`
 	Email  string `json:"email,omitempty"`
 	Role   string `json:"role,omitempty"`
+	// Permissions lets the client show only what the role allows
+	Permissions []models.Permission `json:"permissions,omitempty"`
+	// MustChangePassword means the token only allows changing the password
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type RefreshTokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -69,36 +112,46 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	ip := c.ClientIP()
+	if err := h.loginGuard.Check(req.Email, ip); err != nil {
+		writeLoginThrottledError(c, err)
+		return
+	}
+
 	user, err := h.userRepo.FindByEmail(req.Email)
 	if err != nil {
+		h.loginFailed(req.Email, ip, nil, service.ReasonUnknownEmail)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
 		return
 	}
 
 	if !user.IsActive {
+		h.loginFailed(req.Email, ip, &user.ID, service.ReasonInactiveAccount)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "user account is inactive"})
 		return
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		h.loginFailed(req.Email, ip, &user.ID, service.ReasonWrongPassword)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
 		return
 	}
 
-	token, err := h.authSvc.GenerateToken(user)
+	if err := h.loginGuard.Succeeded(req.Email); err != nil {
+		log.Printf("Error clearing failed logins of %s: %v", req.Email, err)
+	}
+
+	tokens, err := h.sessionSvc.StartSession(user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
 		return
 	}
 
 	c.JSON(http.StatusOK, LoginResponse{
-		Token: token,
-		User: UserInfo{
-			ID:    user.ID,
-			Email: user.Email,
-			Name:  user.Name,
-			Role:  user.Role,
-		},
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		User:         newUserInfo(user),
 	})
 }
 
@@ -125,6 +178,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Email:    req.Email,
 		Password: string(hashedPassword),
 		Name:     req.Name,
+		Phone:    req.Phone,
 		Role:     models.RoleCustomer,
 		IsActive: true,
 	}
@@ -135,20 +189,22 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authSvc.GenerateToken(created)
+	tokens, err := h.sessionSvc.StartSession(created)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
 		return
 	}
 
+	// The account works without it, the user can ask for a new link later
+	if err := h.accountSvc.SendVerification(created.ID); err != nil {
+		log.Printf("Error sending verification email to user %d: %v", created.ID, err)
+	}
+
 	c.JSON(http.StatusCreated, RegisterResponse{
-		Token: token,
-		User: UserInfo{
-			ID:    created.ID,
-			Email: created.Email,
-			Name:  created.Name,
-			Role:  created.Role,
-		},
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		User:         newUserInfo(created),
 	})
 }
 
@@ -174,33 +230,221 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, ValidateTokenResponse{
-		Valid:  true,
-		UserID: claims.UserID,
-		Email:  claims.Email,
-		Role:   string(claims.Role),
+		Valid:              true,
+		UserID:             claims.UserID,
+		Email:              claims.Email,
+		Role:               string(claims.Role),
+		Permissions:        claims.Role.Permissions(),
+		MustChangePassword: claims.PasswordChangeRequired,
 	})
 }
 
+// RefreshToken trades a refresh token for a new access token and a new refresh token.
+// The refresh token sent cannot be used again.
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
+	var req RefreshTokenRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+	tokens, err := h.sessionSvc.Refresh(req.RefreshToken)
+	if err != nil {
+		writeSessionError(c, err)
 		return
 	}
 
-	newToken, err := h.authSvc.RefreshToken(parts[1])
+	c.JSON(http.StatusOK, RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+// Logout revokes the session the refresh token belongs to
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.sessionSvc.Logout(req.RefreshToken); err != nil {
+		writeSessionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll revokes every session of the logged user
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
+	if err := h.sessionSvc.LogoutAll(userID.(uint)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ForgotPassword godoc
+// @Summary      Request a password reset
+// @Description  Queues an email with a single-use reset link. The answer is the same whether the email is registered or not.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  ForgotPasswordRequest  true  "Account email"
+// @Success      202  {object}  map[string]string
+// @Failure      400  {object}  ErrorResponse
+// @Failure      429  {object}  ErrorResponse
+// @Router       /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.accountSvc.RequestPasswordReset(req.Email, c.ClientIP())
+	var throttled *models.RequestThrottledError
+	if errors.As(err, &throttled) {
+		seconds := int(math.Ceil(throttled.RetryAfter(time.Now()).Seconds()))
+		c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error()})
+		return
+	}
+	// Failing only for registered emails would tell them apart, so the failure is only logged
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		log.Printf("Error requesting the password reset of %s: %v", req.Email, err)
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered, a reset link was sent to it"})
+}
+
+// ResetPassword godoc
+// @Summary      Reset the password
+// @Description  Sets a new password with the token received by email and ends every session of the user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  ResetPasswordRequest  true  "Reset token and new password"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, RefreshTokenResponse{
-		Token: newToken,
-	})
+	if err := h.accountSvc.ResetPassword(req.Token, req.Password); err != nil {
+		writeAccountError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyEmail godoc
+// @Summary      Verify the email
+// @Description  Confirms the email of the account with the token received by email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body  VerifyEmailRequest  true  "Verification token"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountSvc.VerifyEmail(req.Token); err != nil {
+		writeAccountError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification godoc
+// @Summary      Resend the verification email
+// @Description  Emails a new verification link to the logged user, the previous links stop working
+// @Tags         auth
+// @Security     Bearer
+// @Produce      json
+// @Success      202  {object}  map[string]string
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+		return
+	}
+
+	if err := h.accountSvc.SendVerification(userID.(uint)); err != nil {
+		writeAccountError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "verification email sent"})
+}
+
+func writeAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidAccountToken):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrEmailAlreadyVerified):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// loginFailed counts the failure, the client still receives the usual invalid credentials answer
+func (h *AuthHandler) loginFailed(email, ip string, userID *uint, reason string) {
+	if err := h.loginGuard.Failed(email, ip, userID, reason); err != nil {
+		log.Printf("Error recording failed login of %s: %v", email, err)
+	}
+}
+
+func writeLoginThrottledError(c *gin.Context, err error) {
+	var throttled *models.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	seconds := int(math.Ceil(throttled.RetryAfter(time.Now()).Seconds()))
+	c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error()})
+}
+
+func writeSessionError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrInvalidRefreshToken) || errors.Is(err, models.ErrRefreshTokenReused) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
@@ -3,15 +3,19 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -20,13 +24,45 @@ func hashPassword(password string) string {
 	return string(hash)
 }
 
+// newTestAuthService signs tokens with a new key named "test"
+func newTestAuthService(t *testing.T) service.AuthService {
+	key, err := keys.GenerateEd25519("test")
+	require.NoError(t, err)
+	set, err := keys.NewSet("", key)
+	require.NoError(t, err)
+	return service.NewAuthService(set)
+}
+
+// newTestSessionService issues real tokens, storing the refresh tokens nowhere
+func newTestSessionService(ctrl *gomock.Controller, authSvc service.AuthService, userRepo *mocks.MockUserRepository) service.SessionService {
+	tokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.RefreshToken) (models.RefreshToken, error) {
+		return token, nil
+	}).AnyTimes()
+	return service.NewSessionService(authSvc, tokenRepo, userRepo)
+}
+
+func newTestAccountService(ctrl *gomock.Controller) service.AccountService {
+	accountSvc := mocks.NewMockAccountService(ctrl)
+	accountSvc.EXPECT().SendVerification(gomock.Any()).Return(nil).AnyTimes()
+	return accountSvc
+}
+
+func newTestLoginGuard(ctrl *gomock.Controller) service.LoginGuard {
+	loginGuard := mocks.NewMockLoginGuard(ctrl)
+	loginGuard.EXPECT().Check(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	loginGuard.EXPECT().Failed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	loginGuard.EXPECT().Succeeded(gomock.Any()).Return(nil).AnyTimes()
+	return loginGuard
+}
+
 func TestLogin_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	password := "password123"
 	hashedPassword := hashPassword(password)
@@ -65,6 +101,8 @@ func TestLogin_Success(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, response.Token)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Equal(t, int64(service.AccessTokenTTL.Seconds()), response.ExpiresIn)
 	assert.Equal(t, "test@example.com", response.User.Email)
 	assert.Equal(t, models.RoleCustomer, response.User.Role)
 }
@@ -74,8 +112,8 @@ func TestLogin_InvalidEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/login", handler.Login)
@@ -100,8 +138,8 @@ func TestLogin_MissingEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/login", handler.Login)
@@ -126,8 +164,8 @@ func TestLogin_ShortPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/login", handler.Login)
@@ -152,8 +190,8 @@ func TestLogin_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	mockUserRepo.EXPECT().
 		FindByEmail("nonexistent@example.com").
@@ -186,8 +224,12 @@ func TestLogin_InactiveUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	// Guesses against a deactivated account are throttled like any other
+	loginGuard := mocks.NewMockLoginGuard(ctrl)
+	loginGuard.EXPECT().Check("inactive@example.com", gomock.Any()).Return(nil)
+	loginGuard.EXPECT().Failed("inactive@example.com", gomock.Any(), gomock.Any(), service.ReasonInactiveAccount).Return(nil)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), loginGuard, mockUserRepo)
 
 	user := models.User{
 		ID:       1,
@@ -225,8 +267,8 @@ func TestLogin_WrongPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	user := models.User{
 		ID:       1,
@@ -264,8 +306,8 @@ func TestLogin_AdminUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	password := "adminPass123"
 	hashedPassword := hashPassword(password)
@@ -304,6 +346,7 @@ func TestLogin_AdminUser(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, models.RoleAdmin, response.User.Role)
+	assert.Contains(t, response.User.Permissions, models.PermManageUsers)
 }
 
 func TestRegister_Success(t *testing.T) {
@@ -311,8 +354,8 @@ func TestRegister_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	// First call: FindByEmail returns error (user doesn't exist)
 	mockUserRepo.EXPECT().
@@ -332,7 +375,10 @@ func TestRegister_Success(t *testing.T) {
 
 	mockUserRepo.EXPECT().
 		Create(gomock.Any()).
-		Return(createdUser, nil)
+		DoAndReturn(func(user models.User) (models.User, error) {
+			assert.Equal(t, "123456789", user.Phone)
+			return createdUser, nil
+		})
 
 	router := setupTestRouter(t)
 	router.POST("/register", handler.Register)
@@ -341,6 +387,7 @@ func TestRegister_Success(t *testing.T) {
 		Email:    "newcustomer@example.com",
 		Password: "password123",
 		Name:     "New Customer",
+		Phone:    "123456789",
 	}
 
 	body, _ := json.Marshal(req)
@@ -365,8 +412,8 @@ func TestRegister_EmailAlreadyExists(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	existingUser := models.User{
 		ID:    1,
@@ -402,8 +449,8 @@ func TestRegister_InvalidEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/register", handler.Register)
@@ -429,8 +476,8 @@ func TestRegister_MissingFields(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/register", handler.Register)
@@ -455,8 +502,8 @@ func TestRegister_ShortPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	router := setupTestRouter(t)
 	router.POST("/register", handler.Register)
@@ -482,8 +529,8 @@ func TestRegister_AlwaysCreatesCustomer(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
-	authSvc := service.NewAuthService("test-secret")
-	handler := NewAuthHandler(authSvc, mockUserRepo)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, newTestSessionService(ctrl, authSvc, mockUserRepo), newTestAccountService(ctrl), newTestLoginGuard(ctrl), mockUserRepo)
 
 	mockUserRepo.EXPECT().
 		FindByEmail("customer@example.com").
@@ -523,3 +570,210 @@ func TestRegister_AlwaysCreatesCustomer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, models.RoleCustomer, response.User.Role)
 }
+
+func TestRefreshToken_Rotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionSvc := mocks.NewMockSessionService(ctrl)
+	handler := NewAuthHandler(newTestAuthService(t), mockSessionSvc, nil, nil, nil)
+
+	mockSessionSvc.EXPECT().Refresh("old-refresh").Return(models.TokenPair{AccessToken: "access", RefreshToken: "new-refresh", ExpiresIn: 900}, nil)
+	mockSessionSvc.EXPECT().Refresh("stolen-refresh").Return(models.TokenPair{}, models.ErrRefreshTokenReused)
+
+	router := setupTestRouter(t)
+	router.POST("/refresh", handler.RefreshToken)
+
+	body, _ := json.Marshal(RefreshTokenRequest{RefreshToken: "old-refresh"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/refresh", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response RefreshTokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, RefreshTokenResponse{Token: "access", RefreshToken: "new-refresh", ExpiresIn: 900}, response)
+
+	body, _ = json.Marshal(RefreshTokenRequest{RefreshToken: "stolen-refresh"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/refresh", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/refresh", bytes.NewBufferString("{}")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionSvc := mocks.NewMockSessionService(ctrl)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, mockSessionSvc, nil, nil, nil)
+
+	mockSessionSvc.EXPECT().Logout("refresh").Return(nil)
+	mockSessionSvc.EXPECT().LogoutAll(uint(1)).Return(nil)
+
+	router := setupTestRouter(t)
+	router.POST("/logout", handler.Logout)
+	router.POST("/logout-all", JWTAuthMiddleware(authSvc), handler.LogoutAll)
+
+	body, _ := json.Marshal(RefreshTokenRequest{RefreshToken: "refresh"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/logout", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	token, err := authSvc.GenerateToken(models.User{ID: 1, Role: models.RoleCustomer})
+	assert.NoError(t, err)
+	req := httptest.NewRequest("POST", "/logout-all", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountSvc := mocks.NewMockAccountService(ctrl)
+	handler := NewAuthHandler(newTestAuthService(t), nil, mockAccountSvc, nil, nil)
+
+	mockAccountSvc.EXPECT().RequestPasswordReset("unknown@example.com", gomock.Any()).Return(nil)
+	mockAccountSvc.EXPECT().RequestPasswordReset("down@example.com", gomock.Any()).Return(errors.New("queue full"))
+	mockAccountSvc.EXPECT().RequestPasswordReset("spam@example.com", gomock.Any()).Return(&models.RequestThrottledError{Until: time.Now().Add(15 * time.Minute)})
+
+	router := setupTestRouter(t)
+	router.POST("/forgot-password", handler.ForgotPassword)
+
+	body, _ := json.Marshal(ForgotPasswordRequest{Email: "unknown@example.com"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/forgot-password", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	// A failure to queue the email answers like any other request, so registered emails cannot be told apart
+	body, _ = json.Marshal(ForgotPasswordRequest{Email: "down@example.com"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/forgot-password", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	body, _ = json.Marshal(ForgotPasswordRequest{Email: "spam@example.com"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/forgot-password", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "900", w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/forgot-password", bytes.NewBufferString(`{"email":"not-an-email"}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountSvc := mocks.NewMockAccountService(ctrl)
+	handler := NewAuthHandler(newTestAuthService(t), nil, mockAccountSvc, nil, nil)
+
+	mockAccountSvc.EXPECT().ResetPassword("valid", "newpassword").Return(nil)
+	mockAccountSvc.EXPECT().ResetPassword("used", "newpassword").Return(models.ErrInvalidAccountToken)
+
+	router := setupTestRouter(t)
+	router.POST("/reset-password", handler.ResetPassword)
+
+	tests := []struct {
+		name string
+		req  ResetPasswordRequest
+		code int
+	}{
+		{"valid token", ResetPasswordRequest{Token: "valid", Password: "newpassword"}, http.StatusNoContent},
+		{"used token", ResetPasswordRequest{Token: "used", Password: "newpassword"}, http.StatusBadRequest},
+		{"short password", ResetPasswordRequest{Token: "valid", Password: "123"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.req)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/reset-password", bytes.NewBuffer(body)))
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountSvc := mocks.NewMockAccountService(ctrl)
+	authSvc := newTestAuthService(t)
+	handler := NewAuthHandler(authSvc, nil, mockAccountSvc, nil, nil)
+
+	gomock.InOrder(
+		mockAccountSvc.EXPECT().SendVerification(uint(1)).Return(nil),
+		mockAccountSvc.EXPECT().SendVerification(uint(1)).Return(models.ErrEmailAlreadyVerified),
+	)
+
+	router := setupTestRouter(t)
+	router.POST("/resend-verification", JWTAuthMiddleware(authSvc), handler.ResendVerification)
+
+	token, err := authSvc.GenerateToken(models.User{ID: 1, Role: models.RoleCustomer})
+	assert.NoError(t, err)
+	for _, code := range []int{http.StatusAccepted, http.StatusConflict} {
+		req := httptest.NewRequest("POST", "/resend-verification", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code)
+	}
+}
+
+func TestLogin_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoginGuard := mocks.NewMockLoginGuard(ctrl)
+	handler := NewAuthHandler(newTestAuthService(t), nil, nil, mockLoginGuard, nil)
+
+	until := time.Now().Add(90 * time.Second)
+	mockLoginGuard.EXPECT().Check("test@example.com", gomock.Any()).Return(&models.LoginThrottledError{Until: until, Locked: true})
+
+	router := setupTestRouter(t)
+	router.POST("/login", handler.Login)
+
+	body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "password123"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/login", bytes.NewBuffer(body)))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "90", w.Header().Get("Retry-After"))
+}
+
+func TestLogin_RecordsFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockLoginGuard := mocks.NewMockLoginGuard(ctrl)
+	handler := NewAuthHandler(newTestAuthService(t), nil, nil, mockLoginGuard, mockUserRepo)
+
+	mockLoginGuard.EXPECT().Check(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockUserRepo.EXPECT().FindByEmail("test@example.com").Return(models.User{ID: 1, Password: hashPassword("password123"), IsActive: true}, nil)
+	mockUserRepo.EXPECT().FindByEmail("ghost@example.com").Return(models.User{}, assert.AnError)
+	userID := uint(1)
+	mockLoginGuard.EXPECT().Failed("test@example.com", "10.0.0.1", &userID, service.ReasonWrongPassword).Return(nil)
+	mockLoginGuard.EXPECT().Failed("ghost@example.com", "10.0.0.1", nil, service.ReasonUnknownEmail).Return(nil)
+
+	router := setupTestRouter(t)
+	router.POST("/login", handler.Login)
+
+	for _, req := range []LoginRequest{
+		{Email: "test@example.com", Password: "wrongpassword"},
+		{Email: "ghost@example.com", Password: "wrongpassword"},
+	} {
+		body, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest("POST", "/login", bytes.NewBuffer(body))
+		httpReq.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httpReq)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+}
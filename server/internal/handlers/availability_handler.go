@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityResponse struct {
+	Date  string      `json:"date"`
+	Slots []time.Time `json:"slots"`
+}
+
+// GetAvailability godoc
+// @Summary      Lista horários livres
+// @Description  Retorna os horários de início disponíveis em um dia para os serviços informados
+// @Tags         appointments
+// @Produce      json
+// @Param        date      query  string  true  "Dia desejado (YYYY-MM-DD)"
+// @Param        services  query  string  true  "IDs dos serviços separados por vírgula"
+// @Param        professional  query  int  false  "ID do profissional, qualquer um disponível quando omitido"
+// @Success      200  {object}  AvailabilityResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /availability [get]
+func GetAvailability(svc service.AvailabilityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		day, err := time.ParseInLocation("2006-01-02", c.Query("date"), time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format, use YYYY-MM-DD"})
+			return
+		}
+
+		ids, err := parseIDList(c.Query("services"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid services, use a comma separated list of IDs"})
+			return
+		}
+
+		var professionalID *uint
+		if raw := c.Query("professional"); raw != "" {
+			id, err := strconv.ParseUint(raw, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid professional ID"})
+				return
+			}
+			pid := uint(id)
+			professionalID = &pid
+		}
+
+		slots, err := svc.GetAvailableSlots(day, ids, professionalID)
+		if err != nil {
+			if errors.Is(err, models.ErrAppointmentNoServices) || errors.Is(err, models.ErrUnknownService) ||
+				errors.Is(err, models.ErrUnknownProfessional) || errors.Is(err, models.ErrProfessionalCannotPerform) ||
+				errors.Is(err, models.ErrNoProfessionalForServices) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, AvailabilityResponse{
+			Date:  day.Format("2006-01-02"),
+			Slots: slots,
+		})
+	}
+}
+
+// parseIDList parses a comma separated list of IDs such as "1,2,3"
+func parseIDList(raw string) ([]uint, error) {
+	var ids []uint
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCancellationPolicy godoc
+// @Summary      Get cancellation policy
+// @Description  Retrieve the notice, fees and reschedule limit customers must follow
+// @Tags         cancellation-policy
+// @Produce      json
+// @Success      200  {object}  models.CancellationPolicy
+// @Failure      500  {object}  map[string]string
+// @Router       /cancellation-policy [get]
+func GetCancellationPolicy(svc service.CancellationPolicyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, err := svc.GetPolicy()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, policy)
+	}
+}
+
+// UpdateCancellationPolicy godoc
+// @Summary      Update cancellation policy (admin only)
+// @Description  Configure the notice for rescheduling and cancelling, late-cancel and no-show fees and the reschedule limit
+// @Tags         cancellation-policy
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        policy  body      models.CancellationPolicy  true  "Cancellation policy"
+// @Success      200     {object}  models.CancellationPolicy
+// @Failure      400     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Router       /admin/cancellation-policy [put]
+func UpdateCancellationPolicy(svc service.CancellationPolicyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.CancellationPolicy
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		policy, err := svc.UpdatePolicy(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, policy)
+	}
+}
+
+// ListMyFees godoc
+// @Summary      List my fees
+// @Description  Retrieve the late-cancellation and no-show fees charged to the logged user
+// @Tags         cancellation-policy
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   models.CustomerFee
+// @Failure      401  {object}  map[string]string
+// @Router       /fees [get]
+func ListMyFees(svc service.CancellationPolicyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		fees, err := svc.ListUserFees(userID.(uint))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, fees)
+	}
+}
+
+// ListUserFees godoc
+// @Summary      List fees of a user (admin only)
+// @Description  Retrieve the late-cancellation and no-show fees charged to a customer
+// @Tags         cancellation-policy
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {array}   models.CustomerFee
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Router       /admin/users/{id}/fees [get]
+func ListUserFees(svc service.CancellationPolicyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+			return
+		}
+
+		fees, err := svc.ListUserFees(uint(id))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, fees)
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// loginStateCookie binds the login started at the provider to the browser that started it
+const loginStateCookie = "oidc_state"
+
+type IdentityProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+type ExchangeLoginCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ListIdentityProviders godoc
+// @Summary      List login providers
+// @Description  Names of the OpenID Connect providers users can log in with
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  IdentityProvidersResponse
+// @Router       /auth/oidc/providers [get]
+func ListIdentityProviders(svc service.IdentityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, IdentityProvidersResponse{Providers: svc.Providers()})
+	}
+}
+
+// BeginIdentityLogin godoc
+// @Summary      Log in with a provider
+// @Description  Redirects to the login page of the OpenID Connect provider
+// @Tags         auth
+// @Param        provider  path  string  true  "Provider name"
+// @Success      302
+// @Failure      404  {object}  map[string]string
+// @Failure      502  {object}  map[string]string
+// @Router       /auth/oidc/{provider}/login [get]
+func BeginIdentityLogin(svc service.IdentityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authURL, state, err := svc.Begin(c.Request.Context(), c.Param("provider"))
+		if errors.Is(err, models.ErrUnknownIdentityProvider) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Error starting login with %s: %v", c.Param("provider"), err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "identity provider unavailable"})
+			return
+		}
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(loginStateCookie, state, int(service.LoginStateTTL.Seconds()), "/api/auth/oidc", "", isHTTPS(c), true)
+		c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+// IdentityLoginCallback godoc
+// @Summary      Provider login callback
+// @Description  The provider sends the user back here. Redirects to the web app with a single-use code to trade for the tokens, or with an error.
+// @Tags         auth
+// @Param        provider  path   string  true   "Provider name"
+// @Param        code      query  string  false  "Authorization code"
+// @Param        state     query  string  false  "State sent to the provider"
+// @Success      302
+// @Router       /auth/oidc/{provider}/callback [get]
+func IdentityLoginCallback(svc service.IdentityService, appURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, _ := c.Cookie(loginStateCookie)
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(loginStateCookie, "", -1, "/api/auth/oidc", "", isHTTPS(c), true)
+
+		redirect := func(param, value string) {
+			c.Redirect(http.StatusFound, strings.TrimSuffix(appURL, "/")+"/login/callback?"+url.Values{param: {value}}.Encode())
+		}
+		if c.Query("error") != "" {
+			redirect("error", "provider_error")
+			return
+		}
+		if state == "" || c.Query("state") != state {
+			redirect("error", "invalid_state")
+			return
+		}
+
+		code, err := svc.Complete(c.Request.Context(), c.Param("provider"), state, c.Query("code"))
+		switch {
+		case err == nil:
+			redirect("code", code)
+		case errors.Is(err, models.ErrUnknownIdentityProvider):
+			redirect("error", "unknown_provider")
+		case errors.Is(err, models.ErrInvalidLoginState):
+			redirect("error", "invalid_state")
+		case errors.Is(err, models.ErrIdentityEmailNotVerified):
+			redirect("error", "email_not_verified")
+		case errors.Is(err, models.ErrUserInactive):
+			redirect("error", "account_inactive")
+		default:
+			log.Printf("Error completing login with %s: %v", c.Param("provider"), err)
+			redirect("error", "login_failed")
+		}
+	}
+}
+
+// ExchangeLoginCode godoc
+// @Summary      Finish a provider login
+// @Description  Trades the single-use code received after a provider login for the session tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ExchangeLoginCodeRequest  true  "Login code"
+// @Success      200      {object}  LoginResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /auth/oidc/exchange [post]
+func ExchangeLoginCode(svc service.IdentityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ExchangeLoginCodeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, tokens, err := svc.Exchange(req.Code)
+		if errors.Is(err, models.ErrInvalidAccountToken) || errors.Is(err, models.ErrUserInactive) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate token"})
+			return
+		}
+
+		c.JSON(http.StatusOK, LoginResponse{
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+			User:         newUserInfo(user),
+		})
+	}
+}
+
+// isHTTPS tells whether the client reached the API over HTTPS, directly or through a proxy
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
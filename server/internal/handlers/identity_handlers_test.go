@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityLogin_RedirectsWithState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := mocks.NewMockIdentityService(ctrl)
+	svc.EXPECT().Begin(gomock.Any(), "google").Return("https://accounts.example.com/auth?state=abc", "abc", nil)
+
+	router := setupTestRouter(t)
+	router.GET("/api/auth/oidc/:provider/login", BeginIdentityLogin(svc))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/auth/oidc/google/login", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://accounts.example.com/auth?state=abc", w.Header().Get("Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "abc", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+}
+
+func TestIdentityLoginCallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		cookie       string
+		query        string
+		setup        func(svc *mocks.MockIdentityService)
+		wantLocation string
+	}{
+		{
+			name:   "success",
+			cookie: "abc",
+			query:  "?state=abc&code=xyz",
+			setup: func(svc *mocks.MockIdentityService) {
+				svc.EXPECT().Complete(gomock.Any(), "google", "abc", "xyz").Return("login-code", nil)
+			},
+			wantLocation: "http://app.example.com/login/callback?code=login-code",
+		},
+		{
+			name:         "state from another browser",
+			cookie:       "other",
+			query:        "?state=abc&code=xyz",
+			wantLocation: "http://app.example.com/login/callback?error=invalid_state",
+		},
+		{
+			name:         "denied at the provider",
+			cookie:       "abc",
+			query:        "?state=abc&error=access_denied",
+			wantLocation: "http://app.example.com/login/callback?error=provider_error",
+		},
+		{
+			name:   "unverified email",
+			cookie: "abc",
+			query:  "?state=abc&code=xyz",
+			setup: func(svc *mocks.MockIdentityService) {
+				svc.EXPECT().Complete(gomock.Any(), "google", "abc", "xyz").Return("", models.ErrIdentityEmailNotVerified)
+			},
+			wantLocation: "http://app.example.com/login/callback?error=email_not_verified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := mocks.NewMockIdentityService(ctrl)
+			if tt.setup != nil {
+				tt.setup(svc)
+			}
+
+			router := setupTestRouter(t)
+			router.GET("/api/auth/oidc/:provider/callback", IdentityLoginCallback(svc, "http://app.example.com/"))
+			req := httptest.NewRequest("GET", "/api/auth/oidc/google/callback"+tt.query, nil)
+			req.AddCookie(&http.Cookie{Name: loginStateCookie, Value: tt.cookie})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestExchangeLoginCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := mocks.NewMockIdentityService(ctrl)
+	user := models.User{ID: 7, Email: "maria@example.com", Role: models.RoleCustomer, IsActive: true}
+	svc.EXPECT().Exchange("login-code").Return(user, models.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}, nil)
+	svc.EXPECT().Exchange("used").Return(models.User{}, models.TokenPair{}, models.ErrInvalidAccountToken)
+
+	router := setupTestRouter(t)
+	router.POST("/exchange", ExchangeLoginCode(svc))
+
+	body, _ := json.Marshal(ExchangeLoginCodeRequest{Code: "login-code"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/exchange", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response LoginResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "access", response.Token)
+	assert.Equal(t, uint(7), response.User.ID)
+
+	body, _ = json.Marshal(ExchangeLoginCodeRequest{Code: "used"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/exchange", bytes.NewBuffer(body)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS godoc
+// @Summary      Public signing keys
+// @Description  Public keys, identified by kid, that verify the access tokens. Keys being rotated out are listed until retired.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  keys.JWKS
+// @Router       /.well-known/jwks.json [get]
+func JWKS(keySet *keys.Set) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Short enough for verifiers to pick up a new key before it signs many tokens
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, keySet.JWKS())
+	}
+}
@@ -42,35 +42,39 @@ func JWTAuthMiddleware(authSvc service.AuthService) gin.HandlerFunc {
 	}
 }
 
-// RequireRole returns a middleware that checks if user has one of the required roles
-func RequireRole(authSvc service.AuthService, allowedRoles ...models.UserRole) gin.HandlerFunc {
+// RequirePermission rejects users whose role does not grant the permission. It must run after JWTAuthMiddleware.
+func RequirePermission(permission models.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
+		role, exists := c.Get("role")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing role in token"})
 			c.Abort()
 			return
 		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+		if userRole, ok := role.(models.UserRole); !ok || !userRole.Can(permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "missing permission " + string(permission)})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		claims, err := authSvc.ValidateTokenWithRole(parts[1], allowedRoles...)
-		if err != nil {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			c.Abort()
+// RequirePasswordChange blocks tokens of users who must change their password, except on the allowed routes
+func RequirePasswordChange(allowedPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := claimsFromContext(c)
+		if !ok || !claims.PasswordChangeRequired {
+			c.Next()
 			return
 		}
-
-		// Store claims in context for use in handlers
-		c.Set("userID", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("role", claims.Role)
-		c.Set("claims", claims)
-		c.Next()
+		for _, path := range allowedPaths {
+			if c.FullPath() == path {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "password change required"})
+		c.Abort()
 	}
 }
@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
-	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,7 +19,7 @@ func setupTestRouter(t *testing.T) *gin.Engine {
 }
 
 func TestJWTAuthMiddleware_ValidToken(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 	user := models.User{
 		ID:    1,
@@ -55,7 +56,7 @@ func TestJWTAuthMiddleware_ValidToken(t *testing.T) {
 }
 
 func TestJWTAuthMiddleware_MissingHeader(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
 	router.GET("/protected", JWTAuthMiddleware(authSvc), func(c *gin.Context) {
@@ -71,7 +72,7 @@ func TestJWTAuthMiddleware_MissingHeader(t *testing.T) {
 }
 
 func TestJWTAuthMiddleware_InvalidFormat(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
 	router.GET("/protected", JWTAuthMiddleware(authSvc), func(c *gin.Context) {
@@ -87,7 +88,7 @@ func TestJWTAuthMiddleware_InvalidFormat(t *testing.T) {
 }
 
 func TestJWTAuthMiddleware_InvalidToken(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
 	router.GET("/protected", JWTAuthMiddleware(authSvc), func(c *gin.Context) {
@@ -102,9 +103,9 @@ func TestJWTAuthMiddleware_InvalidToken(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
-func TestJWTAuthMiddleware_WrongSecret(t *testing.T) {
-	authSvc1 := service.NewAuthService("secret-1")
-	authSvc2 := service.NewAuthService("secret-2")
+func TestJWTAuthMiddleware_WrongKey(t *testing.T) {
+	authSvc1 := newTestAuthService(t)
+	authSvc2 := newTestAuthService(t)
 	router := setupTestRouter(t)
 
 	user := models.User{
@@ -128,8 +129,8 @@ func TestJWTAuthMiddleware_WrongSecret(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
-func TestRequireRole_AllowedRole(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+func TestRequirePermission_Granted(t *testing.T) {
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
 	user := models.User{
@@ -141,7 +142,7 @@ func TestRequireRole_AllowedRole(t *testing.T) {
 	token, err := authSvc.GenerateToken(user)
 	require.NoError(t, err)
 
-	router.GET("/admin", RequireRole(authSvc, models.RoleAdmin), func(c *gin.Context) {
+	router.GET("/admin", JWTAuthMiddleware(authSvc), RequirePermission(models.PermManageUsers), func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"success": true})
 	})
 
@@ -153,61 +154,53 @@ func TestRequireRole_AllowedRole(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestRequireRole_UnauthorizedRole(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+func TestRequirePermission_Denied(t *testing.T) {
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
-	user := models.User{
-		ID:    1,
-		Email: "customer@example.com",
-		Role:  models.RoleCustomer,
-	}
-
-	token, err := authSvc.GenerateToken(user)
-	require.NoError(t, err)
-
-	router.GET("/admin", RequireRole(authSvc, models.RoleAdmin), func(c *gin.Context) {
+	router.GET("/admin", JWTAuthMiddleware(authSvc), RequirePermission(models.PermManageUsers), func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"success": true})
 	})
 
-	req := httptest.NewRequest("GET", "/admin", nil)
-	req.Header.Set("Authorization", "Bearer "+token)
-	w := httptest.NewRecorder()
-
-	router.ServeHTTP(w, req)
-	assert.Equal(t, http.StatusForbidden, w.Code)
-}
+	for _, role := range []models.UserRole{models.RoleCustomer, models.RoleReceptionist, models.RoleProfessional, models.RoleManager} {
+		token, err := authSvc.GenerateToken(models.User{ID: 1, Role: role})
+		require.NoError(t, err)
 
-func TestRequireRole_MultipleRoles(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
-	router := setupTestRouter(t)
+		req := httptest.NewRequest("GET", "/admin", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
 
-	user := models.User{
-		ID:    1,
-		Email: "customer@example.com",
-		Role:  models.RoleCustomer,
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code, role)
 	}
+}
 
-	token, err := authSvc.GenerateToken(user)
-	require.NoError(t, err)
+func TestRequirePermission_SharedByRoles(t *testing.T) {
+	authSvc := newTestAuthService(t)
+	router := setupTestRouter(t)
 
-	router.GET("/protected", RequireRole(authSvc, models.RoleAdmin, models.RoleCustomer), func(c *gin.Context) {
+	router.GET("/incoming", JWTAuthMiddleware(authSvc), RequirePermission(models.PermViewAppointments), func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"success": true})
 	})
 
-	req := httptest.NewRequest("GET", "/protected", nil)
-	req.Header.Set("Authorization", "Bearer "+token)
-	w := httptest.NewRecorder()
+	for _, role := range []models.UserRole{models.RoleAdmin, models.RoleManager, models.RoleReceptionist} {
+		token, err := authSvc.GenerateToken(models.User{ID: 1, Role: role})
+		require.NoError(t, err)
 
-	router.ServeHTTP(w, req)
-	assert.Equal(t, http.StatusOK, w.Code)
+		req := httptest.NewRequest("GET", "/incoming", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, role)
+	}
 }
 
-func TestRequireRole_MissingHeader(t *testing.T) {
-	authSvc := service.NewAuthService("test-secret")
+func TestRequirePermission_MissingHeader(t *testing.T) {
+	authSvc := newTestAuthService(t)
 	router := setupTestRouter(t)
 
-	router.GET("/admin", RequireRole(authSvc, models.RoleAdmin), func(c *gin.Context) {
+	router.GET("/admin", JWTAuthMiddleware(authSvc), RequirePermission(models.PermManageUsers), func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"success": true})
 	})
 
@@ -218,3 +211,60 @@ func TestRequireRole_MissingHeader(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestRequirePasswordChange(t *testing.T) {
+	authSvc := newTestAuthService(t)
+	router := setupTestRouter(t)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	guarded := router.Group("/", JWTAuthMiddleware(authSvc), RequirePasswordChange("/me/password"))
+	guarded.POST("/me/password", ok)
+	guarded.GET("/appointments", ok)
+
+	seeded, err := authSvc.GenerateToken(models.User{ID: 1, Role: models.RoleAdmin, MustChangePassword: true})
+	require.NoError(t, err)
+	regular, err := authSvc.GenerateToken(models.User{ID: 2, Role: models.RoleCustomer})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		code   int
+	}{
+		{"seeded account changes password", seeded, "POST", "/me/password", http.StatusOK},
+		{"seeded account blocked elsewhere", seeded, "GET", "/appointments", http.StatusForbidden},
+		{"regular account", regular, "GET", "/appointments", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	key, err := keys.GenerateEd25519("2026-01-01")
+	require.NoError(t, err)
+	set, err := keys.NewSet("", key)
+	require.NoError(t, err)
+
+	router := setupTestRouter(t)
+	router.GET("/.well-known/jwks.json", JWKS(set))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var jwks keys.JWKS
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "2026-01-01", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.NotContains(t, w.Body.String(), `"d"`)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// TotalCountHeader carries the size of the whole list when the client asks for it with total=true
+const TotalCountHeader = "X-Total-Count"
+
+// PageResponse is the envelope of the paginated lists. NextCursor is sent back as the cursor query parameter
+// to get the next page, it is empty on the last one.
+type PageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// parsePageRequest reads the cursor, limit and total query parameters, writing the response when one is malformed
+func parsePageRequest(c *gin.Context) (models.PageRequest, bool) {
+	page := models.PageRequest{Cursor: c.Query("cursor")}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return models.PageRequest{}, false
+		}
+		page.Limit = limit
+	}
+	if value := c.Query("total"); value != "" {
+		total, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid total, use true or false"})
+			return models.PageRequest{}, false
+		}
+		page.WithTotal = total
+	}
+	return page, true
+}
+
+// writePage writes the items in the page envelope, with the total count header when it was counted
+func writePage[T any](c *gin.Context, items []T, info models.PageInfo) {
+	if info.Total != nil {
+		c.Header(TotalCountHeader, strconv.FormatInt(*info.Total, 10))
+	}
+	c.JSON(http.StatusOK, PageResponse[T]{Items: items, NextCursor: info.NextCursor})
+}
+
+// writeListError answers a request for a page that could not be read
+func writeListError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllUsers_Page(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	router := setupTestRouter(t)
+	router.GET("/admin/users", GetAllUsers(userRepo))
+
+	total := int64(3)
+	userRepo.EXPECT().FindAll(models.PageRequest{Cursor: "abc", Limit: 1, WithTotal: true}).Return(models.UserPage{
+		Items:    []models.User{{ID: 2, Email: "maria@example.com"}},
+		PageInfo: models.PageInfo{NextCursor: "def", Total: &total},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/users?cursor=abc&limit=1&total=true", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get(TotalCountHeader))
+
+	var body PageResponse[UserResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Items, 1)
+	assert.Equal(t, "maria@example.com", body.Items[0].Email)
+	assert.Equal(t, "def", body.NextCursor)
+}
+
+func TestGetAllUsers_InvalidPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	router := setupTestRouter(t)
+	router.GET("/admin/users", GetAllUsers(userRepo))
+
+	for _, query := range []string{"limit=0", "limit=abc", "total=maybe"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/users?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	userRepo.EXPECT().FindAll(models.PageRequest{Cursor: "stale"}).Return(models.UserPage{}, models.ErrInvalidCursor)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/users?cursor=stale", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get(TotalCountHeader))
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type ProfessionalRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Phone      string `json:"phone"`
+	IsActive   *bool  `json:"is_active"`
+	ServiceIDs []uint `json:"service_ids"`
+	// UserID is the account of the professional, with the professional role, used to see their agenda
+	UserID *uint `json:"user_id"`
+}
+
+type ProfessionalResponse struct {
+	ID       uint              `json:"id"`
+	Name     string            `json:"name"`
+	Phone    string            `json:"phone"`
+	IsActive bool              `json:"is_active"`
+	Services []ServiceResponse `json:"services"`
+	UserID   *uint             `json:"user_id,omitempty"`
+}
+
+func (r ProfessionalRequest) toModel(id uint) models.Professional {
+	professional := models.Professional{
+		ID:       id,
+		Name:     r.Name,
+		Phone:    r.Phone,
+		IsActive: true,
+		Services: make([]models.Service, len(r.ServiceIDs)),
+		UserID:   r.UserID,
+	}
+	if r.IsActive != nil {
+		professional.IsActive = *r.IsActive
+	}
+	for i, serviceID := range r.ServiceIDs {
+		professional.Services[i] = models.Service{ID: serviceID}
+	}
+	return professional
+}
+
+func newProfessionalResponse(p models.Professional) ProfessionalResponse {
+	response := ProfessionalResponse{
+		ID:       p.ID,
+		Name:     p.Name,
+		Phone:    p.Phone,
+		IsActive: p.IsActive,
+		Services: make([]ServiceResponse, len(p.Services)),
+		UserID:   p.UserID,
+	}
+	for i, s := range p.Services {
+		response.Services[i] = ServiceResponse{
+			ID:              s.ID,
+			Name:            s.Name,
+			Price:           s.Price,
+			DurationMinutes: s.DurationMinutes,
+		}
+	}
+	return response
+}
+
+func writeProfessionalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrUnknownProfessional):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrUnknownService):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// ListActiveProfessionals godoc
+// @Summary      List active professionals
+// @Description  Retrieve the professionals customers can book with
+// @Tags         professionals
+// @Produce      json
+// @Success      200  {array}   ProfessionalResponse
+// @Failure      500  {object}  map[string]string
+// @Router       /professionals [get]
+func ListActiveProfessionals(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		professionals, err := svc.ListActiveProfessionals()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := make([]ProfessionalResponse, len(professionals))
+		for i, p := range professionals {
+			response[i] = newProfessionalResponse(p)
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ListProfessionals godoc
+// @Summary      List all professionals (admin only)
+// @Description  Retrieve all professionals, including inactive ones
+// @Tags         professionals
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   ProfessionalResponse
+// @Failure      403  {object}  map[string]string
+// @Router       /admin/professionals [get]
+func ListProfessionals(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		professionals, err := svc.ListProfessionals()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := make([]ProfessionalResponse, len(professionals))
+		for i, p := range professionals {
+			response[i] = newProfessionalResponse(p)
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// GetProfessional godoc
+// @Summary      Get professional by ID (admin only)
+// @Description  Retrieve a specific professional by ID
+// @Tags         professionals
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "Professional ID"
+// @Success      200  {object}  ProfessionalResponse
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/professionals/{id} [get]
+func GetProfessional(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid professional ID"})
+			return
+		}
+
+		professional, err := svc.GetProfessional(uint(id))
+		if err != nil {
+			writeProfessionalError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, newProfessionalResponse(professional))
+	}
+}
+
+// CreateProfessional godoc
+// @Summary      Create a new professional (admin only)
+// @Description  Create a professional and link the services they perform
+// @Tags         professionals
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        professional  body      ProfessionalRequest  true  "Professional data"
+// @Success      201           {object}  ProfessionalResponse
+// @Failure      400           {object}  map[string]string
+// @Failure      403           {object}  map[string]string
+// @Router       /admin/professionals [post]
+func CreateProfessional(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ProfessionalRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		created, err := svc.CreateProfessional(req.toModel(0))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, newProfessionalResponse(created))
+	}
+}
+
+// UpdateProfessional godoc
+// @Summary      Update professional (admin only)
+// @Description  Update a professional and replace the services they perform
+// @Tags         professionals
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                  true  "Professional ID"
+// @Param        professional  body      ProfessionalRequest  true  "Updated professional data"
+// @Success      200           {object}  ProfessionalResponse
+// @Failure      400           {object}  map[string]string
+// @Failure      403           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Router       /admin/professionals/{id} [put]
+func UpdateProfessional(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid professional ID"})
+			return
+		}
+
+		var req ProfessionalRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		updated, err := svc.UpdateProfessional(req.toModel(uint(id)))
+		if err != nil {
+			writeProfessionalError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, newProfessionalResponse(updated))
+	}
+}
+
+// DeleteProfessional godoc
+// @Summary      Delete professional (admin only)
+// @Description  Delete a professional, keeping their past appointments unassigned
+// @Tags         professionals
+// @Security     Bearer
+// @Param        id  path  int  true  "Professional ID"
+// @Success      204
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/professionals/{id} [delete]
+func DeleteProfessional(svc service.ProfessionalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid professional ID"})
+			return
+		}
+
+		if err := svc.DeleteProfessional(uint(id)); err != nil {
+			writeProfessionalError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
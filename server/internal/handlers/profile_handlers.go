@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ProfileResponse struct {
+	ID            uint            `json:"id"`
+	Email         string          `json:"email"`
+	Name          string          `json:"name"`
+	Phone         string          `json:"phone"`
+	Role          models.UserRole `json:"role"`
+	EmailVerified bool            `json:"email_verified"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type UpdateProfileRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Phone string `json:"phone" binding:"omitempty,max=20"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+type CloseAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+func newProfileResponse(user models.User) ProfileResponse {
+	return ProfileResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		Phone:         user.Phone,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified(),
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+// GetMe godoc
+// @Summary      Get my profile
+// @Description  Retrieve the profile of the logged user
+// @Tags         me
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  ProfileResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /me [get]
+func GetMe(svc service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		user, err := svc.GetProfile(userID.(uint))
+		if err != nil {
+			writeProfileError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, newProfileResponse(user))
+	}
+}
+
+// UpdateMe godoc
+// @Summary      Update my profile
+// @Description  Change the name and phone of the logged user
+// @Tags         me
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        profile  body      UpdateProfileRequest  true  "Profile data"
+// @Success      200      {object}  ProfileResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Router       /me [put]
+func UpdateMe(svc service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		var req UpdateProfileRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := svc.UpdateProfile(userID.(uint), models.ProfileUpdate{Name: req.Name, Phone: req.Phone})
+		if err != nil {
+			writeProfileError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, newProfileResponse(user))
+	}
+}
+
+// ChangeMyPassword godoc
+// @Summary      Change my password
+// @Description  Requires the current password. Every other session is ended and new tokens are returned for this one.
+// @Tags         me
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ChangePasswordRequest  true  "Current and new password"
+// @Success      200      {object}  RefreshTokenResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Router       /me/password [post]
+func ChangeMyPassword(svc service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		var req ChangePasswordRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tokens, err := svc.ChangePassword(userID.(uint), req.CurrentPassword, req.NewPassword)
+		if err != nil {
+			writeProfileError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, RefreshTokenResponse{
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+		})
+	}
+}
+
+// DeleteMe godoc
+// @Summary      Close my account
+// @Description  Requires the password. Upcoming appointments are canceled and personal data is erased, past appointments stay in the salon history.
+// @Tags         me
+// @Security     Bearer
+// @Accept       json
+// @Param        request  body  CloseAccountRequest  true  "Current password"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Router       /me [delete]
+func DeleteMe(svc service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		var req CloseAccountRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.CloseAccount(userID.(uint), req.Password); err != nil {
+			writeProfileError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func writeProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, models.ErrWrongPassword):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
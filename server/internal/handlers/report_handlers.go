@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// parseReportRange reads the start and end query parameters (YYYY-MM-DD, both inclusive).
+// Without them the report covers the current week, from Monday to Sunday.
+func parseReportRange(c *gin.Context) (time.Time, time.Time, bool) {
+	start := models.BucketWeek.Truncate(time.Now())
+	end := start.AddDate(0, 0, 7)
+
+	if value := c.Query("start"); value != "" {
+		parsed, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start, use YYYY-MM-DD"})
+			return time.Time{}, time.Time{}, false
+		}
+		start = parsed
+	}
+	if value := c.Query("end"); value != "" {
+		parsed, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end, use YYYY-MM-DD"})
+			return time.Time{}, time.Time{}, false
+		}
+		end = parsed.AddDate(0, 0, 1)
+	}
+	return start, end, true
+}
+
+// GetPerformanceReport godoc
+// @Summary      Performance report (admin only)
+// @Description  Appointment counts per status, completion and cancellation rates and revenue, split into day, week or month periods
+// @Tags         reports
+// @Security     Bearer
+// @Produce      json
+// @Param        start   query     string  false  "First day (YYYY-MM-DD), defaults to the current week"
+// @Param        end     query     string  false  "Last day (YYYY-MM-DD)"
+// @Param        bucket  query     string  false  "day, week or month (default week)"
+// @Success      200     {object}  models.PerformanceReport
+// @Failure      400     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Router       /admin/reports/performance [get]
+func GetPerformanceReport(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start, end, ok := parseReportRange(c)
+		if !ok {
+			return
+		}
+		bucket := models.ReportBucket(c.DefaultQuery("bucket", string(models.BucketWeek)))
+
+		report, err := svc.GetPerformance(start, end, bucket)
+		if err != nil {
+			writeReportError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// GetTopServicesReport godoc
+// @Summary      Top services report (admin only)
+// @Description  Services ranked by number of bookings, with the revenue of the completed ones
+// @Tags         reports
+// @Security     Bearer
+// @Produce      json
+// @Param        start  query     string  false  "First day (YYYY-MM-DD), defaults to the current week"
+// @Param        end    query     string  false  "Last day (YYYY-MM-DD)"
+// @Param        limit  query     int     false  "Number of services (default 5)"
+// @Success      200    {array}   models.ServiceRanking
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /admin/reports/top-services [get]
+func GetTopServicesReport(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start, end, ok := parseReportRange(c)
+		if !ok {
+			return
+		}
+		limit := 0
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+				return
+			}
+			limit = parsed
+		}
+
+		ranking, err := svc.GetTopServices(start, end, limit)
+		if err != nil {
+			writeReportError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ranking)
+	}
+}
+
+// GetBusiestHoursReport godoc
+// @Summary      Busiest hours report (admin only)
+// @Description  Number of appointments starting at each hour of the day, busiest first
+// @Tags         reports
+// @Security     Bearer
+// @Produce      json
+// @Param        start  query     string  false  "First day (YYYY-MM-DD), defaults to the current week"
+// @Param        end    query     string  false  "Last day (YYYY-MM-DD)"
+// @Success      200    {array}   models.HourLoad
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /admin/reports/busiest-hours [get]
+func GetBusiestHoursReport(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start, end, ok := parseReportRange(c)
+		if !ok {
+			return
+		}
+
+		hours, err := svc.GetBusiestHours(start, end)
+		if err != nil {
+			writeReportError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, hours)
+	}
+}
+
+func writeReportError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrInvalidReportBucket) || errors.Is(err, models.ErrInvalidReportRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
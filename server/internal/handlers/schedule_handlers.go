@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateHolidayRequest struct {
+	Date        string `json:"date" binding:"required"`
+	Description string `json:"description"`
+}
+
+// GetBusinessHours godoc
+// @Summary      Get business hours
+// @Description  Retrieve the opening schedule of every weekday
+// @Tags         schedule
+// @Produce      json
+// @Success      200  {array}   models.BusinessHours
+// @Failure      500  {object}  map[string]string
+// @Router       /business-hours [get]
+func GetBusinessHours(svc service.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hours, err := svc.GetBusinessHours()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, hours)
+	}
+}
+
+// UpdateBusinessHours godoc
+// @Summary      Update business hours (admin only)
+// @Description  Replace the opening schedule of the informed weekdays
+// @Tags         schedule
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        hours  body      []models.BusinessHours  true  "Weekday schedules"
+// @Success      200    {array}   models.BusinessHours
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /admin/business-hours [put]
+func UpdateBusinessHours(svc service.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req []models.BusinessHours
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		hours, err := svc.UpdateBusinessHours(req)
+		if err != nil {
+			writeScheduleError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, hours)
+	}
+}
+
+// ListHolidays godoc
+// @Summary      List holidays (admin only)
+// @Description  Retrieve the days on which the salon is closed
+// @Tags         schedule
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   models.Holiday
+// @Failure      403  {object}  map[string]string
+// @Router       /admin/holidays [get]
+func ListHolidays(svc service.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		holidays, err := svc.ListHolidays()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, holidays)
+	}
+}
+
+// CreateHoliday godoc
+// @Summary      Create a holiday (admin only)
+// @Description  Close the salon on a calendar day
+// @Tags         schedule
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        holiday  body      CreateHolidayRequest  true  "Holiday data"
+// @Success      201      {object}  models.Holiday
+// @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /admin/holidays [post]
+func CreateHoliday(svc service.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CreateHolidayRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		created, err := svc.CreateHoliday(models.Holiday{Date: req.Date, Description: req.Description})
+		if err != nil {
+			writeScheduleError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, created)
+	}
+}
+
+// DeleteHoliday godoc
+// @Summary      Delete a holiday (admin only)
+// @Description  Reopen the salon on a previously closed day
+// @Tags         schedule
+// @Security     Bearer
+// @Param        id  path  int  true  "Holiday ID"
+// @Success      204
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/holidays/{id} [delete]
+func DeleteHoliday(svc service.ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid holiday ID"})
+			return
+		}
+
+		if err := svc.DeleteHoliday(uint(id)); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// writeScheduleError maps the errors of the schedule service to the matching HTTP status
+func writeScheduleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidBusinessHours), errors.Is(err, models.ErrDuplicateWeekday),
+		errors.Is(err, models.ErrInvalidHolidayDate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
     "net/http"
     "strconv"
+    "time"
 
     "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
     "github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
@@ -26,25 +27,54 @@ type ServiceResponse struct {
     Name            string  `json:"name"`
     Price           float64 `json:"price"`
     DurationMinutes int     `json:"duration_minutes"`
+    // DeletedAt is only set for services that are no longer offered
+    DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+func newServiceResponse(s models.Service) ServiceResponse {
+    response := ServiceResponse{
+        ID:              s.ID,
+        Name:            s.Name,
+        Price:           s.Price,
+        DurationMinutes: s.DurationMinutes,
+    }
+    if s.DeletedAt.Valid {
+        response.DeletedAt = &s.DeletedAt.Time
+    }
+    return response
+}
+
+// includeInactive reports whether the request asked for deleted records too
+func includeInactive(c *gin.Context) bool {
+    include, _ := strconv.ParseBool(c.Query("include_inactive"))
+    return include
 }
 
 // ListServices godoc
 // @Summary      List all services
-// @Description  Retrieve all available services
+// @Description  Retrieve a page of the available services
 // @Tags         services
 // @Produce      json
-// @Success      200  {array}   ServiceResponse
-// @Failure      500  {object}  map[string]string
+// @Param        cursor  query     string  false  "next_cursor of the previous page"
+// @Param        limit   query     int     false  "Page size (default 50, at most 200)"
+// @Param        total   query     bool    false  "Send the number of services in the X-Total-Count header"
+// @Success      200     {object}  PageResponse[ServiceResponse]
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
 // @Router       /services [get]
 func ListServices(svc service.ServiceService) gin.HandlerFunc {
     return func(c *gin.Context) {
-        services, err := svc.ListServices()
+        page, ok := parsePageRequest(c)
+        if !ok {
+            return
+        }
+        services, err := svc.ListServices(page)
         if err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+            writeListError(c, err)
             return
         }
-        response := make([]ServiceResponse, len(services))
-        for i, s := range services {
+        response := make([]ServiceResponse, len(services.Items))
+        for i, s := range services.Items {
             response[i] = ServiceResponse{
                 ID:              s.ID,
                 Name:            s.Name,
@@ -52,7 +82,7 @@ func ListServices(svc service.ServiceService) gin.HandlerFunc {
                 DurationMinutes: s.DurationMinutes,
             }
         }
-        c.JSON(http.StatusOK, response)
+        writePage(c, response, services.PageInfo)
     }
 }
 
@@ -105,12 +135,6 @@ func GetService(svc service.ServiceService) gin.HandlerFunc {
 // @Router       /admin/services [post]
 func CreateService(svc service.ServiceService) gin.HandlerFunc {
     return func(c *gin.Context) {
-        role, exists := c.Get("role")
-        if !exists || role != models.RoleAdmin {
-            c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-            return
-        }
-
         var req CreateServiceRequest
         if err := c.BindJSON(&req); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -155,12 +179,6 @@ func CreateService(svc service.ServiceService) gin.HandlerFunc {
 // @Router       /admin/services/{id} [put]
 func UpdateService(svc service.ServiceService) gin.HandlerFunc {
     return func(c *gin.Context) {
-        role, exists := c.Get("role")
-        if !exists || role != models.RoleAdmin {
-            c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-            return
-        }
-
         idStr := c.Param("id")
         id, err := strconv.ParseUint(idStr, 10, 32)
         if err != nil {
@@ -199,7 +217,7 @@ func UpdateService(svc service.ServiceService) gin.HandlerFunc {
 
 // DeleteService godoc
 // @Summary      Delete service (admin only)
-// @Description  Delete a specific service
+// @Description  Stop offering a specific service, keeping it in past appointments
 // @Tags         services
 // @Security     Bearer
 // @Param        id  path  int  true  "Service ID"
@@ -209,12 +227,6 @@ func UpdateService(svc service.ServiceService) gin.HandlerFunc {
 // @Router       /admin/services/{id} [delete]
 func DeleteService(svc service.ServiceService) gin.HandlerFunc {
     return func(c *gin.Context) {
-        role, exists := c.Get("role")
-        if !exists || role != models.RoleAdmin {
-            c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-            return
-        }
-
         idStr := c.Param("id")
         id, err := strconv.ParseUint(idStr, 10, 32)
         if err != nil {
@@ -229,4 +241,72 @@ func DeleteService(svc service.ServiceService) gin.HandlerFunc {
 
         c.Status(http.StatusNoContent)
     }
-}
\ No newline at end of file
+}
+
+// ListAllServices godoc
+// @Summary      List services (admin only)
+// @Description  Retrieve a page of the services, including the deleted ones when include_inactive is true
+// @Tags         services
+// @Security     Bearer
+// @Produce      json
+// @Param        include_inactive  query     bool    false  "Include deleted services"
+// @Param        cursor            query     string  false  "next_cursor of the previous page"
+// @Param        limit             query     int     false  "Page size (default 50, at most 200)"
+// @Param        total             query     bool    false  "Send the number of services in the X-Total-Count header"
+// @Success      200               {object}  PageResponse[ServiceResponse]
+// @Failure      400               {object}  map[string]string
+// @Failure      403               {object}  map[string]string
+// @Failure      500               {object}  map[string]string
+// @Router       /admin/services [get]
+func ListAllServices(svc service.ServiceService) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        page, ok := parsePageRequest(c)
+        if !ok {
+            return
+        }
+        list := svc.ListServices
+        if includeInactive(c) {
+            list = svc.ListServicesIncludingInactive
+        }
+        services, err := list(page)
+        if err != nil {
+            writeListError(c, err)
+            return
+        }
+        response := make([]ServiceResponse, len(services.Items))
+        for i, s := range services.Items {
+            response[i] = newServiceResponse(s)
+        }
+        writePage(c, response, services.PageInfo)
+    }
+}
+
+// RestoreService godoc
+// @Summary      Restore service (admin only)
+// @Description  Offer a deleted service again
+// @Tags         services
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "Service ID"
+// @Success      200  {object}  ServiceResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/services/{id}/restore [post]
+func RestoreService(svc service.ServiceService) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        idStr := c.Param("id")
+        id, err := strconv.ParseUint(idStr, 10, 32)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service ID"})
+            return
+        }
+
+        restored, err := svc.RestoreService(uint(id))
+        if err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusOK, newServiceResponse(restored))
+    }
+}
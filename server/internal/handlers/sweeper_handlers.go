@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSweeperRules godoc
+// @Summary      Get automatic closing rules (admin only)
+// @Description  Retrieve how appointments left open after their date are expired or completed automatically
+// @Tags         sweeper
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  models.SweeperRules
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/sweeper-rules [get]
+func GetSweeperRules(svc service.SweeperService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules, err := svc.GetRules()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, rules)
+	}
+}
+
+// UpdateSweeperRules godoc
+// @Summary      Update automatic closing rules (admin only)
+// @Description  Configure whether pending appointments expire and confirmed ones are completed once their date passes, and after how long
+// @Tags         sweeper
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        rules  body      models.SweeperRules  true  "Automatic closing rules"
+// @Success      200    {object}  models.SweeperRules
+// @Failure      400    {object}  map[string]string
+// @Failure      403    {object}  map[string]string
+// @Router       /admin/sweeper-rules [put]
+func UpdateSweeperRules(svc service.SweeperService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.SweeperRules
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rules, err := svc.UpdateRules(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, rules)
+	}
+}
+
+// RunSweeper godoc
+// @Summary      Close overdue appointments now (admin only)
+// @Description  Apply the automatic closing rules right away instead of waiting for the next periodic run
+// @Tags         sweeper
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {object}  models.SweepResult
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/sweeper/run [post]
+func RunSweeper(svc service.SweeperService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := svc.Sweep(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -15,54 +17,68 @@ type CreateUserRequest struct {
 	Password string          `json:"password" binding:"required,min=6"`
 	Name     string          `json:"name" binding:"required"`
 	Phone    string          `json:"phone"`
-	Role     models.UserRole `json:"role" binding:"required,oneof=admin customer"`
+	Role     models.UserRole `json:"role" binding:"required"`
 }
 
 type UserResponse struct {
-	ID       uint            `json:"id"`
-	Email    string          `json:"email"`
-	Name     string          `json:"name"`
-	Phone    string          `json:"phone"`
-	Role     models.UserRole `json:"role"`
-	IsActive bool            `json:"is_active"`
+	ID            uint            `json:"id"`
+	Email         string          `json:"email"`
+	Name          string          `json:"name"`
+	Phone         string          `json:"phone"`
+	Role          models.UserRole `json:"role"`
+	IsActive      bool            `json:"is_active"`
+	EmailVerified bool            `json:"email_verified"`
+	// DeletedAt is only set for deleted users
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // GetAllUsers godoc
 // @Summary      List all users (admin only)
-// @Description  Retrieve all users from the system
+// @Description  Retrieve a page of the users, including the deleted ones when include_inactive is true
 // @Tags         admin
 // @Security     Bearer
 // @Produce      json
-// @Success      200  {array}   UserResponse
-// @Failure      403  {object}  map[string]string
+// @Param        include_inactive  query     bool    false  "Include deleted users"
+// @Param        cursor            query     string  false  "next_cursor of the previous page"
+// @Param        limit             query     int     false  "Page size (default 50, at most 200)"
+// @Param        total             query     bool    false  "Send the number of users in the X-Total-Count header"
+// @Success      200               {object}  PageResponse[UserResponse]
+// @Failure      400               {object}  map[string]string
+// @Failure      403               {object}  map[string]string
 // @Router       /admin/users [get]
 func GetAllUsers(userRepo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != models.RoleAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		page, ok := parsePageRequest(c)
+		if !ok {
 			return
 		}
-
-		users, err := userRepo.FindAll()
+		findUsers := userRepo.FindAll
+		if includeInactive(c) {
+			findUsers = userRepo.FindAllIncludingInactive
+		}
+		users, err := findUsers(page)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeListError(c, err)
 			return
 		}
 
-		response := make([]UserResponse, len(users))
-		for i, user := range users {
+		response := make([]UserResponse, len(users.Items))
+		for i, user := range users.Items {
 			response[i] = UserResponse{
-				ID:       user.ID,
-				Email:    user.Email,
-				Name:     user.Name,
-				Phone:    user.Phone,
-				Role:     user.Role,
-				IsActive: user.IsActive,
+				ID:            user.ID,
+				Email:         user.Email,
+				Name:          user.Name,
+				Phone:         user.Phone,
+				Role:          user.Role,
+				IsActive:      user.IsActive,
+				EmailVerified: user.EmailVerified(),
+			}
+			if user.DeletedAt.Valid {
+				response[i].DeletedAt = &user.DeletedAt.Time
 			}
 		}
 
-		c.JSON(http.StatusOK, response)
+		writePage(c, response, users.PageInfo)
 	}
 }
 
@@ -80,17 +96,15 @@ func GetAllUsers(userRepo repository.UserRepository) gin.HandlerFunc {
 // @Router       /admin/users [post]
 func CreateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != models.RoleAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-			return
-		}
-
 		var req CreateUserRequest
 		if err := c.BindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if !req.Role.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": models.ErrInvalidRole.Error()})
+			return
+		}
 
 		// Hash the password
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -115,12 +129,13 @@ func CreateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 		}
 
 		response := UserResponse{
-			ID:       created.ID,
-			Email:    created.Email,
-			Name:     created.Name,
-			Phone:    created.Phone,
-			Role:     created.Role,
-			IsActive: created.IsActive,
+			ID:            created.ID,
+			Email:         created.Email,
+			Name:          created.Name,
+			Phone:         created.Phone,
+			Role:          created.Role,
+			IsActive:      created.IsActive,
+			EmailVerified: created.EmailVerified(),
 		}
 
 		c.JSON(http.StatusCreated, response)
@@ -140,12 +155,6 @@ func CreateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 // @Router       /admin/users/{id} [get]
 func GetUser(userRepo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != models.RoleAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-			return
-		}
-
 		idStr := c.Param("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
@@ -160,12 +169,13 @@ func GetUser(userRepo repository.UserRepository) gin.HandlerFunc {
 		}
 
 		response := UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Name:     user.Name,
-			Phone:    user.Phone,
-			Role:     user.Role,
-			IsActive: user.IsActive,
+			ID:            user.ID,
+			Email:         user.Email,
+			Name:          user.Name,
+			Phone:         user.Phone,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			EmailVerified: user.EmailVerified(),
 		}
 
 		c.JSON(http.StatusOK, response)
@@ -181,7 +191,7 @@ type UpdateUserRequest struct {
 
 // UpdateUser godoc
 // @Summary      Update user (admin only)
-// @Description  Update a specific user's details
+// @Description  Update a specific user's details. Deactivating a user or changing their role ends all of their sessions.
 // @Tags         admin
 // @Security     Bearer
 // @Accept       json
@@ -189,17 +199,12 @@ type UpdateUserRequest struct {
 // @Param        id    path      int                  true  "User ID"
 // @Param        user  body      UpdateUserRequest    true  "Updated user data"
 // @Success      200   {object}  UserResponse
+// @Failure      400   {object}  map[string]string
 // @Failure      403   {object}  map[string]string
 // @Failure      404   {object}  map[string]string
 // @Router       /admin/users/{id} [put]
-func UpdateUser(userRepo repository.UserRepository) gin.HandlerFunc {
+func UpdateUser(userRepo repository.UserRepository, sessionSvc service.SessionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != models.RoleAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-			return
-		}
-
 		idStr := c.Param("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
@@ -225,7 +230,13 @@ func UpdateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 		if req.Phone != nil {
 			user.Phone = *req.Phone
 		}
+		// The role is in the access tokens, so its sessions must end for a new role to apply
+		roleChanged := req.Role != nil && *req.Role != user.Role
 		if req.Role != nil {
+			if !req.Role.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": models.ErrInvalidRole.Error()})
+				return
+			}
 			user.Role = *req.Role
 		}
 		if req.IsActive != nil {
@@ -236,14 +247,21 @@ func UpdateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		if !user.IsActive || roleChanged {
+			if err := sessionSvc.LogoutAll(user.ID); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
 
 		response := UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Name:     user.Name,
-			Phone:    user.Phone,
-			Role:     user.Role,
-			IsActive: user.IsActive,
+			ID:            user.ID,
+			Email:         user.Email,
+			Name:          user.Name,
+			Phone:         user.Phone,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			EmailVerified: user.EmailVerified(),
 		}
 
 		c.JSON(http.StatusOK, response)
@@ -252,7 +270,7 @@ func UpdateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 
 // DeleteUser godoc
 // @Summary      Delete user (admin only)
-// @Description  Delete a specific user
+// @Description  Archive a specific user, keeping their appointment history
 // @Tags         admin
 // @Security     Bearer
 // @Param        id  path  int  true  "User ID"
@@ -260,14 +278,8 @@ func UpdateUser(userRepo repository.UserRepository) gin.HandlerFunc {
 // @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Router       /admin/users/{id} [delete]
-func DeleteUser(userRepo repository.UserRepository) gin.HandlerFunc {
+func DeleteUser(userRepo repository.UserRepository, sessionSvc service.SessionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != models.RoleAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
-			return
-		}
-
 		idStr := c.Param("id")
 		id, err := strconv.ParseUint(idStr, 10, 32)
 		if err != nil {
@@ -286,7 +298,112 @@ func DeleteUser(userRepo repository.UserRepository) gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		if err := sessionSvc.LogoutAll(uint(id)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// RestoreUser godoc
+// @Summary      Restore user (admin only)
+// @Description  Reactivate a deleted user
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  UserResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/users/{id}/restore [post]
+func RestoreUser(userRepo repository.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idStr := c.Param("id")
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+			return
+		}
 
+		user, err := userRepo.Restore(uint(id))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		response := UserResponse{
+			ID:            user.ID,
+			Email:         user.Email,
+			Name:          user.Name,
+			Phone:         user.Phone,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			EmailVerified: user.EmailVerified(),
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UnlockUser godoc
+// @Summary      Unlock user login (admin only)
+// @Description  Clear the failed login attempts of a user, ending the lockout of the account
+// @Tags         admin
+// @Security     Bearer
+// @Param        id  path  int  true  "User ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /admin/users/{id}/unlock [post]
+func UnlockUser(userRepo repository.UserRepository, loginGuard service.LoginGuard) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idStr := c.Param("id")
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+			return
+		}
+
+		user, err := userRepo.FindByID(uint(id))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		adminID, _ := c.Get("userID")
+		if err := loginGuard.Unlock(user.Email, adminID.(uint)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 		c.Status(http.StatusNoContent)
 	}
 }
+
+type RoleResponse struct {
+	Role        models.UserRole     `json:"role"`
+	Permissions []models.Permission `json:"permissions"`
+}
+
+// ListRoles godoc
+// @Summary      List roles
+// @Description  List the roles that can be assigned to users, with the permissions each one grants
+// @Tags         admin
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   RoleResponse
+// @Failure      403  {object}  map[string]string
+// @Router       /admin/roles [get]
+func ListRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles := models.Roles()
+		response := make([]RoleResponse, len(roles))
+		for i, role := range roles {
+			response[i] = RoleResponse{Role: role, Permissions: role.Permissions()}
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
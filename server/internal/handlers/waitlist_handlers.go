@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type JoinWaitlistRequest struct {
+	ServiceIDs []uint `json:"service_ids" binding:"required,min=1"`
+	// StartDate and EndDate are the first and last days the customer can come (YYYY-MM-DD)
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	// WindowStart and WindowEnd bound the preferred hours of the day (HH:MM)
+	WindowStart string `json:"window_start" binding:"required"`
+	WindowEnd   string `json:"window_end" binding:"required"`
+}
+
+// JoinWaitlist godoc
+// @Summary      Join the waitlist
+// @Description  Wait for a slot with the services within the given days and hours. When an appointment that suits it is canceled, the slot is held for the customer for a while.
+// @Tags         waitlist
+// @Security     Bearer
+// @Accept       json
+// @Produce      json
+// @Param        request  body      JoinWaitlistRequest  true  "Desired services, days and hours"
+// @Success      201      {object}  models.WaitlistEntry
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /waitlist [post]
+func JoinWaitlist(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		var req JoinWaitlistRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		entry := models.WaitlistEntry{
+			StartDate:   req.StartDate,
+			EndDate:     req.EndDate,
+			WindowStart: req.WindowStart,
+			WindowEnd:   req.WindowEnd,
+		}
+		for _, id := range req.ServiceIDs {
+			entry.Services = append(entry.Services, models.Service{ID: id})
+		}
+
+		created, err := svc.Join(userID.(uint), entry)
+		if err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, created)
+	}
+}
+
+// ListMyWaitlist godoc
+// @Summary      List my waitlist entries
+// @Description  Retrieve the waitlist entries of the logged user, newest first
+// @Tags         waitlist
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   models.WaitlistEntry
+// @Failure      401  {object}  map[string]string
+// @Router       /waitlist [get]
+func ListMyWaitlist(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		entries, err := svc.ListEntries(userID.(uint))
+		if err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// LeaveWaitlist godoc
+// @Summary      Leave the waitlist
+// @Description  Stop waiting for a slot. A slot held for the entry is passed to the next customer.
+// @Tags         waitlist
+// @Security     Bearer
+// @Param        id   path      int  true  "Waitlist entry ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /waitlist/{id} [delete]
+func LeaveWaitlist(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid waitlist entry ID"})
+			return
+		}
+
+		if err := svc.Leave(userID.(uint), uint(id)); err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ListMyWaitlistOffers godoc
+// @Summary      List my slot offers
+// @Description  Retrieve the slots currently held for the logged user, which they may accept until they expire
+// @Tags         waitlist
+// @Security     Bearer
+// @Produce      json
+// @Success      200  {array}   models.WaitlistOffer
+// @Failure      401  {object}  map[string]string
+// @Router       /waitlist/offers [get]
+func ListMyWaitlistOffers(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+
+		offers, err := svc.ListOffers(userID.(uint))
+		if err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, offers)
+	}
+}
+
+// AcceptWaitlistOffer godoc
+// @Summary      Accept a slot offer
+// @Description  Book the slot held for the logged user
+// @Tags         waitlist
+// @Security     Bearer
+// @Produce      json
+// @Param        id   path      int  true  "Offer ID"
+// @Success      201  {object}  models.Appointment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /waitlist/offers/{id}/accept [post]
+func AcceptWaitlistOffer(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offer ID"})
+			return
+		}
+
+		ap, err := svc.AcceptOffer(userID.(uint), uint(id))
+		if err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, ap)
+	}
+}
+
+// DeclineWaitlistOffer godoc
+// @Summary      Decline a slot offer
+// @Description  Give up the slot held for the logged user, who stays on the waitlist. The slot is passed to the next customer.
+// @Tags         waitlist
+// @Security     Bearer
+// @Param        id   path      int  true  "Offer ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /waitlist/offers/{id}/decline [post]
+func DeclineWaitlistOffer(svc service.WaitlistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user info in token"})
+			return
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offer ID"})
+			return
+		}
+
+		if err := svc.DeclineOffer(userID.(uint), uint(id)); err != nil {
+			writeWaitlistError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// writeWaitlistError maps domain errors from the waitlist service to HTTP responses
+func writeWaitlistError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "waitlist entry or offer not found"})
+	case errors.Is(err, models.ErrWaitlistEntryClosed), errors.Is(err, models.ErrWaitlistOfferClosed),
+		errors.Is(err, models.ErrTimeSlotUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidWaitlistEntry), errors.Is(err, models.ErrAppointmentNoServices),
+		errors.Is(err, models.ErrUnknownService), errors.Is(err, models.ErrUnknownProfessional),
+		errors.Is(err, models.ErrProfessionalCannotPerform), errors.Is(err, models.ErrNoProfessionalForServices),
+		errors.Is(err, models.ErrSalonClosed), errors.Is(err, models.ErrHolidayClosure),
+		errors.Is(err, models.ErrOutsideBusinessHours):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
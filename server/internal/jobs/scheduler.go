@@ -0,0 +1,169 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+// Handler performs a job. Returning an error makes the job run again later, until it runs out of attempts.
+type Handler func(ctx context.Context, job models.Job) error
+
+const (
+	DefaultInterval    = time.Minute
+	DefaultMaxAttempts = 5
+	batchSize          = 50
+)
+
+// Scheduler runs the stored jobs once their time comes. It runs in the same process as the API and
+// handles one job at a time, so a job is never picked twice.
+type Scheduler struct {
+	repo        repository.JobRepository
+	handlers    map[string]Handler
+	interval    time.Duration
+	maxAttempts int
+	now         func() time.Time
+
+	periodic []periodicTask
+
+	wg   sync.WaitGroup
+	stop context.CancelFunc
+}
+
+// periodicTask is work repeated at a fixed interval rather than planned for a given time
+type periodicTask struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func NewScheduler(repo repository.JobRepository, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Scheduler{
+		repo:        repo,
+		handlers:    make(map[string]Handler),
+		interval:    interval,
+		maxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// Register sets the handler of the jobs of the given kind. It must be called before Start.
+func (s *Scheduler) Register(kind string, handler Handler) {
+	s.handlers[kind] = handler
+}
+
+// Every runs the task at the given interval, starting right away. It must be called before Start.
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.periodic = append(s.periodic, periodicTask{name: name, interval: interval, run: run})
+}
+
+// Start polls for due jobs and runs the periodic tasks in the background until ctx is done or Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.stop = context.WithCancel(ctx)
+	for _, task := range s.periodic {
+		s.wg.Add(1)
+		go func(task periodicTask) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(task.interval)
+			defer ticker.Stop()
+			for {
+				if err := task.run(ctx); err != nil {
+					log.Printf("jobs: %s: %v", task.name, err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(task)
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if err := s.RunDue(ctx); err != nil {
+				log.Printf("jobs: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for the job being run to finish and stops polling
+func (s *Scheduler) Stop() {
+	if s.stop != nil {
+		s.stop()
+	}
+	s.wg.Wait()
+}
+
+// RunDue runs every job whose time has come
+func (s *Scheduler) RunDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		due, err := s.repo.ListDue(s.now(), batchSize)
+		if err != nil {
+			return fmt.Errorf("listing due jobs: %w", err)
+		}
+		for _, job := range due {
+			if err := s.run(ctx, job); err != nil {
+				return err
+			}
+		}
+		if len(due) < batchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// run performs the job and stores its outcome. Failed jobs are retried with an increasing delay.
+func (s *Scheduler) run(ctx context.Context, job models.Job) error {
+	handler, ok := s.handlers[job.Kind]
+	if !ok {
+		job.Status = models.JobFailed
+		job.LastError = fmt.Sprintf("no handler for job kind %q", job.Kind)
+		return s.save(job)
+	}
+
+	job.Attempts++
+	if err := handler(ctx, job); err != nil {
+		job.LastError = err.Error()
+		if job.Attempts >= s.maxAttempts {
+			job.Status = models.JobFailed
+			log.Printf("jobs: job %d (%s) failed after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+		} else {
+			job.RunAt = s.now().Add(s.retryDelay(job.Attempts))
+		}
+		return s.save(job)
+	}
+	job.Status = models.JobDone
+	job.LastError = ""
+	return s.save(job)
+}
+
+// retryDelay doubles the wait after every failed attempt
+func (s *Scheduler) retryDelay(attempts int) time.Duration {
+	return s.interval * time.Duration(1<<(attempts-1))
+}
+
+func (s *Scheduler) save(job models.Job) error {
+	if err := s.repo.Update(job); err != nil {
+		return fmt.Errorf("saving job %d: %w", job.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScheduler(repo *mocks.MockJobRepository, now time.Time) *Scheduler {
+	s := NewScheduler(repo, time.Minute)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestScheduler_RunDue_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockJobRepository(ctrl)
+	now := time.Now()
+	s := newTestScheduler(mockRepo, now)
+
+	var handled []uint
+	s.Register("test", func(ctx context.Context, job models.Job) error {
+		handled = append(handled, job.ID)
+		return nil
+	})
+
+	mockRepo.EXPECT().ListDue(now, batchSize).Return([]models.Job{{ID: 1, Kind: "test"}, {ID: 2, Kind: "test"}}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(job models.Job) error {
+		assert.Equal(t, models.JobDone, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		return nil
+	}).Times(2)
+
+	assert.NoError(t, s.RunDue(context.Background()))
+	assert.Equal(t, []uint{1, 2}, handled)
+}
+
+func TestScheduler_RunDue_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockJobRepository(ctrl)
+	now := time.Now()
+	s := newTestScheduler(mockRepo, now)
+	s.Register("test", func(ctx context.Context, job models.Job) error {
+		return errors.New("smtp down")
+	})
+
+	mockRepo.EXPECT().ListDue(now, batchSize).Return([]models.Job{{ID: 1, Kind: "test", Status: models.JobPending, Attempts: 2, RunAt: now.Add(-time.Hour)}}, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(job models.Job) error {
+		assert.Equal(t, models.JobPending, job.Status)
+		assert.Equal(t, 3, job.Attempts)
+		assert.Equal(t, "smtp down", job.LastError)
+		assert.Equal(t, now.Add(4*time.Minute), job.RunAt)
+		return nil
+	})
+
+	assert.NoError(t, s.RunDue(context.Background()))
+}
+
+func TestScheduler_RunDue_GivesUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockJobRepository(ctrl)
+	now := time.Now()
+	s := newTestScheduler(mockRepo, now)
+	s.Register("test", func(ctx context.Context, job models.Job) error {
+		return errors.New("smtp down")
+	})
+
+	mockRepo.EXPECT().ListDue(now, batchSize).Return([]models.Job{{ID: 1, Kind: "test", Attempts: DefaultMaxAttempts - 1}, {ID: 2, Kind: "unknown"}}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(job models.Job) error {
+			assert.Equal(t, models.JobFailed, job.Status)
+			assert.Equal(t, DefaultMaxAttempts, job.Attempts)
+			return nil
+		}),
+		mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(job models.Job) error {
+			assert.Equal(t, models.JobFailed, job.Status)
+			assert.Contains(t, job.LastError, "no handler")
+			return nil
+		}),
+	)
+
+	assert.NoError(t, s.RunDue(context.Background()))
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockJobRepository(ctrl)
+	s := NewScheduler(mockRepo, time.Hour)
+
+	polled := make(chan struct{})
+	mockRepo.EXPECT().ListDue(gomock.Any(), batchSize).DoAndReturn(func(time.Time, int) ([]models.Job, error) {
+		close(polled)
+		return nil, nil
+	})
+
+	// The first poll happens right away instead of after the interval
+	s.Start(context.Background())
+	select {
+	case <-polled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not poll")
+	}
+	s.Stop()
+}
+
+func TestScheduler_Every(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockJobRepository(ctrl)
+	s := NewScheduler(mockRepo, time.Hour)
+	mockRepo.EXPECT().ListDue(gomock.Any(), batchSize).Return(nil, nil).AnyTimes()
+
+	ran := make(chan struct{}, 10)
+	s.Every("test", 10*time.Millisecond, func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	})
+
+	s.Start(context.Background())
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ran:
+		case <-time.After(5 * time.Second):
+			t.Fatal("periodic task did not run")
+		}
+	}
+	s.Stop()
+}
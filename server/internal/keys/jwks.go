@@ -0,0 +1,96 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWK is the public half of a key, as described in RFC 7517 and RFC 8037
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// N and E are the modulus and exponent of RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are the curve and public key of Ed25519 keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the document other services read to verify the tokens
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every key of the set, including the ones kept only to verify tokens
+func (s *Set) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range s.sortedKeys() {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = encode(public.N.Bytes())
+			jwk.E = encode(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = encode(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// Key returns the public key described by the JWK, used to verify tokens issued by other services
+func (j JWK) Key() (Key, error) {
+	key := Key{ID: j.Kid}
+	switch j.Kty {
+	case "RSA":
+		n, err := decode(j.N)
+		if err != nil {
+			return Key{}, err
+		}
+		e, err := decode(j.E)
+		if err != nil {
+			return Key{}, err
+		}
+		public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if public.N.BitLen() < MinRSABits || public.E < 3 {
+			return Key{}, fmt.Errorf("key %q: invalid RSA key", j.Kid)
+		}
+		key.Method, key.Public = jwt.SigningMethodRS256, public
+	case "OKP":
+		x, err := decode(j.X)
+		if err != nil {
+			return Key{}, err
+		}
+		if j.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return Key{}, fmt.Errorf("key %q: invalid Ed25519 key", j.Kid)
+		}
+		key.Method, key.Public = jwt.SigningMethodEdDSA, ed25519.PublicKey(x)
+	default:
+		return Key{}, fmt.Errorf("key %q: %w", j.Kid, ErrUnsupportedKey)
+	}
+	if j.Alg != "" && j.Alg != key.Method.Alg() {
+		return Key{}, fmt.Errorf("key %q: unsupported algorithm %q", j.Kid, j.Alg)
+	}
+	return key, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
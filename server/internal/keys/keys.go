@@ -0,0 +1,254 @@
+// Package keys holds the key pairs that sign the access tokens and publishes their public halves as a JWKS
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MinRSABits is the smallest RSA key accepted for signing tokens
+const MinRSABits = 2048
+
+var (
+	ErrNoSigningKey   = errors.New("no private key available to sign tokens")
+	ErrUnsupportedKey = errors.New("unsupported key type, use RSA or Ed25519")
+)
+
+// Key is a key pair identified by its kid. Keys loaded from a public key alone can only verify tokens.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// CanSign reports whether the private half of the key is available
+func (k Key) CanSign() bool {
+	return k.Private != nil
+}
+
+// Set is the key set used to sign and verify tokens, safe for concurrent use.
+//
+// Tokens are signed with the active key, and verified with any key of the set. To rotate keys, add
+// the new key, make it active and keep the old one until the tokens it signed have expired. Removing
+// its file then retires it.
+type Set struct {
+	mu     sync.RWMutex
+	dir    string
+	active string
+	keys   map[string]Key
+	// signing is the kid of the key that signs new tokens
+	signing string
+}
+
+// NewSet builds a set from the given keys. When active is empty the private key with the greatest
+// kid signs the tokens, so naming keys after their creation date makes the newest one active.
+func NewSet(active string, keys ...Key) (*Set, error) {
+	s := &Set{active: active}
+	if err := s.replace(keys); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LoadDir builds a set from the PEM files of the directory, each named after its kid ("<kid>.pem").
+// Files may hold a PKCS#8 or PKCS#1 private key, or a PKIX public key for keys that only verify tokens.
+func LoadDir(dir, active string) (*Set, error) {
+	s := &Set{dir: dir, active: active}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload reads the directory again, picking up added and removed keys. The current keys are kept when
+// the directory holds an invalid key. Sets not loaded from a directory are left unchanged.
+func (s *Set) Reload() error {
+	if s.dir == "" {
+		return nil
+	}
+	keys, err := readDir(s.dir)
+	if err != nil {
+		return err
+	}
+	return s.replace(keys)
+}
+
+// Signing returns the key that signs new tokens
+func (s *Set) Signing() (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[s.signing]
+	if !ok {
+		return Key{}, ErrNoSigningKey
+	}
+	return key, nil
+}
+
+// Lookup returns the key with the kid, to verify a token
+func (s *Set) Lookup(kid string) (Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *Set) replace(keys []Key) error {
+	byID := make(map[string]Key, len(keys))
+	signing := ""
+	for _, key := range keys {
+		if _, ok := byID[key.ID]; ok {
+			return fmt.Errorf("duplicate key %q", key.ID)
+		}
+		byID[key.ID] = key
+		if key.CanSign() && key.ID > signing {
+			signing = key.ID
+		}
+	}
+	if s.active != "" {
+		key, ok := byID[s.active]
+		if !ok || !key.CanSign() {
+			return fmt.Errorf("active key %q: %w", s.active, ErrNoSigningKey)
+		}
+		signing = s.active
+	}
+	if signing == "" {
+		return ErrNoSigningKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = byID
+	s.signing = signing
+	return nil
+}
+
+// HasKeys reports whether the directory holds any PEM file
+func HasKeys(dir string) (bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	return len(paths) > 0, err
+}
+
+func readDir(dir string) ([]Key, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]Key, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := Parse(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Parse reads a PEM encoded key, private or public
+func Parse(id string, data []byte) (Key, error) {
+	if id == "" {
+		return Key{}, errors.New("missing key id")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	key := Key{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return Key{}, ErrUnsupportedKey
+	}
+	if rsaKey, ok := key.Public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < MinRSABits {
+		return Key{}, fmt.Errorf("RSA key has %d bits, at least %d are required", rsaKey.N.BitLen(), MinRSABits)
+	}
+	return key, nil
+}
+
+// GenerateEd25519 creates a new key pair signing with EdDSA
+func GenerateEd25519(id string) (Key, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Method: jwt.SigningMethodEdDSA, Private: private, Public: public}, nil
+}
+
+// GenerateRSA creates a new key pair signing with RS256
+func GenerateRSA(id string, bits int) (Key, error) {
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Method: jwt.SigningMethodRS256, Private: private, Public: &private.PublicKey}, nil
+}
+
+// Write saves the private key of the pair in the directory as "<kid>.pem", readable only by its owner
+func Write(dir string, key Key) error {
+	if !key.CanSign() {
+		return ErrNoSigningKey
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.Private)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return os.WriteFile(filepath.Join(dir, key.ID+".pem"), data, 0o600)
+}
+
+// sortedKeys returns the keys ordered by kid
+func (s *Set) sortedKeys() []Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		list = append(list, key)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
@@ -0,0 +1,154 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePublic saves only the public half of the key, as done once its private half is destroyed
+func writePublic(t *testing.T, dir string, key Key) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, key.ID+".pem"), data, 0o600))
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := GenerateRSA("2026-01-01", MinRSABits)
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, rsaKey))
+	edKey, err := GenerateEd25519("2026-02-01")
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, edKey))
+	verifyOnly, err := GenerateEd25519("2026-03-01")
+	require.NoError(t, err)
+	writePublic(t, dir, verifyOnly)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0o600))
+
+	set, err := LoadDir(dir, "")
+	require.NoError(t, err)
+
+	// The newest key able to sign is the active one
+	signing, err := set.Signing()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02-01", signing.ID)
+	assert.Equal(t, "EdDSA", signing.Method.Alg())
+
+	loaded, ok := set.Lookup("2026-01-01")
+	require.True(t, ok)
+	assert.Equal(t, "RS256", loaded.Method.Alg())
+	assert.True(t, loaded.CanSign())
+
+	loaded, ok = set.Lookup("2026-03-01")
+	require.True(t, ok)
+	assert.False(t, loaded.CanSign())
+	assert.Equal(t, verifyOnly.Public, loaded.Public)
+
+	_, ok = set.Lookup("missing")
+	assert.False(t, ok)
+}
+
+func TestLoadDir_ActiveKey(t *testing.T) {
+	dir := t.TempDir()
+	for _, id := range []string{"a", "b"} {
+		key, err := GenerateEd25519(id)
+		require.NoError(t, err)
+		require.NoError(t, Write(dir, key))
+	}
+
+	set, err := LoadDir(dir, "a")
+	require.NoError(t, err)
+	signing, err := set.Signing()
+	require.NoError(t, err)
+	assert.Equal(t, "a", signing.ID)
+
+	_, err = LoadDir(dir, "c")
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestLoadDir_NoPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenerateEd25519("a")
+	require.NoError(t, err)
+	writePublic(t, dir, key)
+
+	_, err = LoadDir(dir, "")
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestLoadDir_RejectsWeakRSA(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenerateRSA("weak", 1024)
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, key))
+
+	_, err = LoadDir(dir, "")
+	assert.Error(t, err)
+}
+
+func TestReload_KeepsKeysOnError(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenerateEd25519("a")
+	require.NoError(t, err)
+	require.NoError(t, Write(dir, key))
+	set, err := LoadDir(dir, "")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.pem"), []byte("garbage"), 0o600))
+	assert.Error(t, set.Reload())
+
+	_, ok := set.Lookup("a")
+	assert.True(t, ok)
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, err := GenerateRSA("rsa", MinRSABits)
+	require.NoError(t, err)
+	edKey, err := GenerateEd25519("ed")
+	require.NoError(t, err)
+	set, err := NewSet("", rsaKey, edKey)
+	require.NoError(t, err)
+
+	jwks := set.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	ed := jwks.Keys[0]
+	assert.Equal(t, JWK{Kty: "OKP", Kid: "ed", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: encode(edKey.Public.(ed25519.PublicKey))}, ed)
+
+	rsa := jwks.Keys[1]
+	assert.Equal(t, "RSA", rsa.Kty)
+	assert.Equal(t, "RS256", rsa.Alg)
+	assert.Equal(t, "AQAB", rsa.E)
+	assert.NotEmpty(t, rsa.N)
+}
+
+func TestJWK_Key(t *testing.T) {
+	rsaKey, err := GenerateRSA("rsa", MinRSABits)
+	require.NoError(t, err)
+	edKey, err := GenerateEd25519("ed")
+	require.NoError(t, err)
+	set, err := NewSet("", rsaKey, edKey)
+	require.NoError(t, err)
+
+	for _, jwk := range set.JWKS().Keys {
+		key, err := jwk.Key()
+		require.NoError(t, err)
+		original, _ := set.Lookup(jwk.Kid)
+		assert.Equal(t, original.Public, key.Public)
+		assert.Equal(t, original.Method, key.Method)
+		assert.False(t, key.CanSign())
+	}
+
+	_, err = JWK{Kty: "EC", Kid: "ec"}.Key()
+	assert.ErrorIs(t, err, ErrUnsupportedKey)
+	_, err = JWK{Kty: "OKP", Kid: "short", Crv: "Ed25519", X: "AQID"}.Key()
+	assert.Error(t, err)
+}
@@ -1,4 +1,25 @@
 package mocks
 
 //go:generate mockgen -source=../repository/service_repository.go -destination=mock_service_repository.go -package=mocks
+//go:generate mockgen -source=../repository/appointment_repository.go -destination=mock_appointment_repository.go -package=mocks
+//go:generate mockgen -source=../repository/professional_repository.go -destination=mock_professional_repository.go -package=mocks
+//go:generate mockgen -source=../repository/schedule_repository.go -destination=mock_schedule_repository.go -package=mocks
+//go:generate mockgen -source=../repository/cancellation_policy_repository.go -destination=mock_cancellation_policy_repository.go -package=mocks
+//go:generate mockgen -source=../repository/report_repository.go -destination=mock_report_repository.go -package=mocks
+//go:generate mockgen -source=../repository/job_repository.go -destination=mock_job_repository.go -package=mocks
+//go:generate mockgen -source=../repository/sweeper_rules_repository.go -destination=mock_sweeper_rules_repository.go -package=mocks
+//go:generate mockgen -source=../repository/refresh_token_repository.go -destination=mock_refresh_token_repository.go -package=mocks
+//go:generate mockgen -source=../repository/account_token_repository.go -destination=mock_account_token_repository.go -package=mocks
+//go:generate mockgen -source=../repository/login_throttle_repository.go -destination=mock_login_throttle_repository.go -package=mocks
+//go:generate mockgen -source=../repository/identity_repository.go -destination=mock_identity_repository.go -package=mocks
+//go:generate mockgen -source=../repository/waitlist_repository.go -destination=mock_waitlist_repository.go -package=mocks
+//go:generate mockgen -source=../repository/user_repository.go -destination=mock_user_repository.go -package=mocks
+//go:generate mockgen -source=../notification/dispatcher.go -destination=mock_notifier.go -package=mocks
 //go:generate mockgen -source=../service/service_service.go -destination=mock_service_service.go -package=mocks
+//go:generate mockgen -source=../service/appointment_service.go -destination=mock_appointment_service.go -package=mocks
+//go:generate mockgen -source=../service/professional_service.go -destination=mock_professional_service.go -package=mocks
+//go:generate mockgen -source=../service/session_service.go -destination=mock_session_service.go -package=mocks
+//go:generate mockgen -source=../service/account_service.go -destination=mock_account_service.go -package=mocks
+//go:generate mockgen -source=../service/user_service.go -destination=mock_user_service.go -package=mocks
+//go:generate mockgen -source=../service/login_guard.go -destination=mock_login_guard.go -package=mocks
+//go:generate mockgen -source=../service/identity_service.go -destination=mock_identity_service.go -package=mocks
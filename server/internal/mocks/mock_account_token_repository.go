@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/account_token_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccountTokenRepository is a mock of AccountTokenRepository interface.
+type MockAccountTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountTokenRepositoryMockRecorder
+}
+
+// MockAccountTokenRepositoryMockRecorder is the mock recorder for MockAccountTokenRepository.
+type MockAccountTokenRepositoryMockRecorder struct {
+	mock *MockAccountTokenRepository
+}
+
+// NewMockAccountTokenRepository creates a new mock instance.
+func NewMockAccountTokenRepository(ctrl *gomock.Controller) *MockAccountTokenRepository {
+	mock := &MockAccountTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountTokenRepository) EXPECT() *MockAccountTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAccountTokenRepository) Create(token models.AccountToken) (models.AccountToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(models.AccountToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccountTokenRepositoryMockRecorder) Create(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountTokenRepository)(nil).Create), token)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockAccountTokenRepository) DeleteExpired(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockAccountTokenRepositoryMockRecorder) DeleteExpired(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockAccountTokenRepository)(nil).DeleteExpired), before)
+}
+
+// FindByHash mocks base method.
+func (m *MockAccountTokenRepository) FindByHash(purpose models.AccountTokenPurpose, hash string) (models.AccountToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", purpose, hash)
+	ret0, _ := ret[0].(models.AccountToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAccountTokenRepositoryMockRecorder) FindByHash(purpose, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAccountTokenRepository)(nil).FindByHash), purpose, hash)
+}
+
+// InvalidateUser mocks base method.
+func (m *MockAccountTokenRepository) InvalidateUser(userID uint, purpose models.AccountTokenPurpose, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUser", userID, purpose, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUser indicates an expected call of InvalidateUser.
+func (mr *MockAccountTokenRepositoryMockRecorder) InvalidateUser(userID, purpose, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUser", reflect.TypeOf((*MockAccountTokenRepository)(nil).InvalidateUser), userID, purpose, at)
+}
+
+// MarkUsed mocks base method.
+func (m *MockAccountTokenRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockAccountTokenRepositoryMockRecorder) MarkUsed(id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockAccountTokenRepository)(nil).MarkUsed), id, at)
+}
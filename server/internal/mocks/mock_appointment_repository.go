@@ -9,6 +9,7 @@ import (
 	time "time"
 
 	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	repository "github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,6 +36,20 @@ func (m *MockAppointmentRepository) EXPECT() *MockAppointmentRepositoryMockRecor
 	return m.recorder
 }
 
+// ChangeStatus mocks base method.
+func (m *MockAppointmentRepository) ChangeStatus(id uint, from, to models.AppointmentStatus, changedBy *uint, fee *models.CustomerFee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", id, from, to, changedBy, fee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockAppointmentRepositoryMockRecorder) ChangeStatus(id, from, to, changedBy, fee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockAppointmentRepository)(nil).ChangeStatus), id, from, to, changedBy, fee)
+}
+
 // Create mocks base method.
 func (m *MockAppointmentRepository) Create(ap models.Appointment) (models.Appointment, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,36 @@ func (mr *MockAppointmentRepositoryMockRecorder) Create(ap interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAppointmentRepository)(nil).Create), ap)
 }
 
+// CreateIfFree mocks base method.
+func (m *MockAppointmentRepository) CreateIfFree(ap models.Appointment, check func(repository.AppointmentRepository, *models.Appointment) error) (models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfFree", ap, check)
+	ret0, _ := ret[0].(models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIfFree indicates an expected call of CreateIfFree.
+func (mr *MockAppointmentRepositoryMockRecorder) CreateIfFree(ap, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfFree", reflect.TypeOf((*MockAppointmentRepository)(nil).CreateIfFree), ap, check)
+}
+
+// CreateSeries mocks base method.
+func (m *MockAppointmentRepository) CreateSeries(series models.AppointmentSeries) (models.AppointmentSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", series)
+	ret0, _ := ret[0].(models.AppointmentSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockAppointmentRepositoryMockRecorder) CreateSeries(series interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockAppointmentRepository)(nil).CreateSeries), series)
+}
+
 // FindByID mocks base method.
 func (m *MockAppointmentRepository) FindByID(id uint) (models.Appointment, error) {
 	m.ctrl.T.Helper()
@@ -80,47 +125,154 @@ func (mr *MockAppointmentRepositoryMockRecorder) FindUserAppointmentsInWeek(user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserAppointmentsInWeek", reflect.TypeOf((*MockAppointmentRepository)(nil).FindUserAppointmentsInWeek), userID, weekStart, weekEnd)
 }
 
-// ListAll mocks base method.
-func (m *MockAppointmentRepository) ListAll() ([]models.Appointment, error) {
+// ListActiveByPeriod mocks base method.
+func (m *MockAppointmentRepository) ListActiveByPeriod(start, end time.Time) ([]models.Appointment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAll")
+	ret := m.ctrl.Call(m, "ListActiveByPeriod", start, end)
 	ret0, _ := ret[0].([]models.Appointment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListActiveByPeriod indicates an expected call of ListActiveByPeriod.
+func (mr *MockAppointmentRepositoryMockRecorder) ListActiveByPeriod(start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByPeriod", reflect.TypeOf((*MockAppointmentRepository)(nil).ListActiveByPeriod), start, end)
+}
+
+// ListAll mocks base method.
+func (m *MockAppointmentRepository) ListAll(page models.PageRequest) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", page)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // ListAll indicates an expected call of ListAll.
-func (mr *MockAppointmentRepositoryMockRecorder) ListAll() *gomock.Call {
+func (mr *MockAppointmentRepositoryMockRecorder) ListAll(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockAppointmentRepository)(nil).ListAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockAppointmentRepository)(nil).ListAll), page)
 }
 
 // ListByPeriod mocks base method.
-func (m *MockAppointmentRepository) ListByPeriod(start, end time.Time) ([]models.Appointment, error) {
+func (m *MockAppointmentRepository) ListByPeriod(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByPeriod", start, end)
-	ret0, _ := ret[0].([]models.Appointment)
+	ret := m.ctrl.Call(m, "ListByPeriod", start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByPeriod indicates an expected call of ListByPeriod.
-func (mr *MockAppointmentRepositoryMockRecorder) ListByPeriod(start, end interface{}) *gomock.Call {
+func (mr *MockAppointmentRepositoryMockRecorder) ListByPeriod(start, end, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPeriod", reflect.TypeOf((*MockAppointmentRepository)(nil).ListByPeriod), start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPeriod", reflect.TypeOf((*MockAppointmentRepository)(nil).ListByPeriod), start, end, page)
 }
 
-func (m *MockAppointmentRepository) ListByPeriodAndUser(userID uint, start, end time.Time) ([]models.Appointment, error) {
+// ListByPeriodAndUser mocks base method.
+func (m *MockAppointmentRepository) ListByPeriodAndUser(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByPeriod", userID, start, end)
+	ret := m.ctrl.Call(m, "ListByPeriodAndUser", userID, start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPeriodAndUser indicates an expected call of ListByPeriodAndUser.
+func (mr *MockAppointmentRepositoryMockRecorder) ListByPeriodAndUser(userID, start, end, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPeriodAndUser", reflect.TypeOf((*MockAppointmentRepository)(nil).ListByPeriodAndUser), userID, start, end, page)
+}
+
+// ListByProfessionalAndPeriod mocks base method.
+func (m *MockAppointmentRepository) ListByProfessionalAndPeriod(professionalID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProfessionalAndPeriod", professionalID, start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProfessionalAndPeriod indicates an expected call of ListByProfessionalAndPeriod.
+func (mr *MockAppointmentRepositoryMockRecorder) ListByProfessionalAndPeriod(professionalID, start, end, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProfessionalAndPeriod", reflect.TypeOf((*MockAppointmentRepository)(nil).ListByProfessionalAndPeriod), professionalID, start, end, page)
+}
+
+// ListSeries mocks base method.
+func (m *MockAppointmentRepository) ListSeries(seriesID uint, from time.Time) ([]models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", seriesID, from)
+	ret0, _ := ret[0].([]models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockAppointmentRepositoryMockRecorder) ListSeries(seriesID, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockAppointmentRepository)(nil).ListSeries), seriesID, from)
+}
+
+// ListStartedBefore mocks base method.
+func (m *MockAppointmentRepository) ListStartedBefore(statuses []models.AppointmentStatus, before time.Time) ([]models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStartedBefore", statuses, before)
 	ret0, _ := ret[0].([]models.Appointment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockAppointmentRepositoryMockRecorder) ListByPeriodAndUser(userID, start, end interface{}) *gomock.Call {
+// ListStartedBefore indicates an expected call of ListStartedBefore.
+func (mr *MockAppointmentRepositoryMockRecorder) ListStartedBefore(statuses, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStartedBefore", reflect.TypeOf((*MockAppointmentRepository)(nil).ListStartedBefore), statuses, before)
+}
+
+// ListStatusHistory mocks base method.
+func (m *MockAppointmentRepository) ListStatusHistory(appointmentID uint) ([]models.AppointmentStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusHistory", appointmentID)
+	ret0, _ := ret[0].([]models.AppointmentStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusHistory indicates an expected call of ListStatusHistory.
+func (mr *MockAppointmentRepositoryMockRecorder) ListStatusHistory(appointmentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPeriod", reflect.TypeOf((*MockAppointmentRepository)(nil).ListByPeriod), userID, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusHistory", reflect.TypeOf((*MockAppointmentRepository)(nil).ListStatusHistory), appointmentID)
+}
+
+// ListUpcomingByUser mocks base method.
+func (m *MockAppointmentRepository) ListUpcomingByUser(userID uint, statuses []models.AppointmentStatus, after time.Time) ([]models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUpcomingByUser", userID, statuses, after)
+	ret0, _ := ret[0].([]models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUpcomingByUser indicates an expected call of ListUpcomingByUser.
+func (mr *MockAppointmentRepositoryMockRecorder) ListUpcomingByUser(userID, statuses, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpcomingByUser", reflect.TypeOf((*MockAppointmentRepository)(nil).ListUpcomingByUser), userID, statuses, after)
+}
+
+// Search mocks base method.
+func (m *MockAppointmentRepository) Search(query models.AppointmentQuery) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAppointmentRepositoryMockRecorder) Search(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAppointmentRepository)(nil).Search), query)
 }
 
 // Update mocks base method.
@@ -136,3 +288,18 @@ func (mr *MockAppointmentRepositoryMockRecorder) Update(ap interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAppointmentRepository)(nil).Update), ap)
 }
+
+// UpdateIfFree mocks base method.
+func (m *MockAppointmentRepository) UpdateIfFree(ap models.Appointment, check func(repository.AppointmentRepository, *models.Appointment) error) (models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIfFree", ap, check)
+	ret0, _ := ret[0].(models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIfFree indicates an expected call of UpdateIfFree.
+func (mr *MockAppointmentRepositoryMockRecorder) UpdateIfFree(ap, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIfFree", reflect.TypeOf((*MockAppointmentRepository)(nil).UpdateIfFree), ap, check)
+}
@@ -35,24 +35,69 @@ func (m *MockAppointmentService) EXPECT() *MockAppointmentServiceMockRecorder {
 	return m.recorder
 }
 
-// ConfirmAppointment mocks base method.
-func (m *MockAppointmentService) ConfirmAppointment(id uint) error {
+// CancelAppointment mocks base method.
+func (m *MockAppointmentService) CancelAppointment(claims *models.CustomClaims, id uint) (models.Appointment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmAppointment", id)
+	ret := m.ctrl.Call(m, "CancelAppointment", claims, id)
+	ret0, _ := ret[0].(models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAppointment indicates an expected call of CancelAppointment.
+func (mr *MockAppointmentServiceMockRecorder) CancelAppointment(claims, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAppointment", reflect.TypeOf((*MockAppointmentService)(nil).CancelAppointment), claims, id)
+}
+
+// CancelFollowing mocks base method.
+func (m *MockAppointmentService) CancelFollowing(claims *models.CustomClaims, id uint) (models.SeriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelFollowing", claims, id)
+	ret0, _ := ret[0].(models.SeriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelFollowing indicates an expected call of CancelFollowing.
+func (mr *MockAppointmentServiceMockRecorder) CancelFollowing(claims, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFollowing", reflect.TypeOf((*MockAppointmentService)(nil).CancelFollowing), claims, id)
+}
+
+// CancelUpcoming mocks base method.
+func (m *MockAppointmentService) CancelUpcoming(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUpcoming", userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ConfirmAppointment indicates an expected call of ConfirmAppointment.
-func (mr *MockAppointmentServiceMockRecorder) ConfirmAppointment(id interface{}) *gomock.Call {
+// CancelUpcoming indicates an expected call of CancelUpcoming.
+func (mr *MockAppointmentServiceMockRecorder) CancelUpcoming(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpcoming", reflect.TypeOf((*MockAppointmentService)(nil).CancelUpcoming), userID)
+}
+
+// ChangeStatus mocks base method.
+func (m *MockAppointmentService) ChangeStatus(claims *models.CustomClaims, id uint, status models.AppointmentStatus) (models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", claims, id, status)
+	ret0, _ := ret[0].(models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockAppointmentServiceMockRecorder) ChangeStatus(claims, id, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAppointment", reflect.TypeOf((*MockAppointmentService)(nil).ConfirmAppointment), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockAppointmentService)(nil).ChangeStatus), claims, id, status)
 }
 
 // CreateAppointment mocks base method.
-func (m *MockAppointmentService) CreateAppointment(customerID uint, services []models.Service, date time.Time) (models.Appointment, *models.Appointment, error) {
+func (m *MockAppointmentService) CreateAppointment(userID uint, services []models.Service, date time.Time, professionalID *uint) (models.Appointment, *models.Appointment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAppointment", customerID, services, date)
+	ret := m.ctrl.Call(m, "CreateAppointment", userID, services, date, professionalID)
 	ret0, _ := ret[0].(models.Appointment)
 	ret1, _ := ret[1].(*models.Appointment)
 	ret2, _ := ret[2].(error)
@@ -60,68 +105,157 @@ func (m *MockAppointmentService) CreateAppointment(customerID uint, services []m
 }
 
 // CreateAppointment indicates an expected call of CreateAppointment.
-func (mr *MockAppointmentServiceMockRecorder) CreateAppointment(customerID, services, date interface{}) *gomock.Call {
+func (mr *MockAppointmentServiceMockRecorder) CreateAppointment(userID, services, date, professionalID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppointment", reflect.TypeOf((*MockAppointmentService)(nil).CreateAppointment), customerID, services, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppointment", reflect.TypeOf((*MockAppointmentService)(nil).CreateAppointment), userID, services, date, professionalID)
 }
 
-// GetWeeklyPerformance mocks base method.
-func (m *MockAppointmentService) GetWeeklyPerformance() (int, int, error) {
+// CreateSeries mocks base method.
+func (m *MockAppointmentService) CreateSeries(userID uint, services []models.Service, date time.Time, professionalID *uint, rule models.Recurrence) (models.SeriesResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWeeklyPerformance")
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "CreateSeries", userID, services, date, professionalID, rule)
+	ret0, _ := ret[0].(models.SeriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetWeeklyPerformance indicates an expected call of GetWeeklyPerformance.
-func (mr *MockAppointmentServiceMockRecorder) GetWeeklyPerformance() *gomock.Call {
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockAppointmentServiceMockRecorder) CreateSeries(userID, services, date, professionalID, rule interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklyPerformance", reflect.TypeOf((*MockAppointmentService)(nil).GetWeeklyPerformance))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockAppointmentService)(nil).CreateSeries), userID, services, date, professionalID, rule)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockAppointmentService) GetStatusHistory(claims *models.CustomClaims, id uint) ([]models.AppointmentStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", claims, id)
+	ret0, _ := ret[0].([]models.AppointmentStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockAppointmentServiceMockRecorder) GetStatusHistory(claims, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockAppointmentService)(nil).GetStatusHistory), claims, id)
 }
 
 // ListAll mocks base method.
-func (m *MockAppointmentService) ListAll() ([]models.Appointment, error) {
+func (m *MockAppointmentService) ListAll(page models.PageRequest) (models.AppointmentPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAll")
-	ret0, _ := ret[0].([]models.Appointment)
+	ret := m.ctrl.Call(m, "ListAll", page)
+	ret0, _ := ret[0].(models.AppointmentPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAll indicates an expected call of ListAll.
-func (mr *MockAppointmentServiceMockRecorder) ListAll() *gomock.Call {
+func (mr *MockAppointmentServiceMockRecorder) ListAll(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockAppointmentService)(nil).ListAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockAppointmentService)(nil).ListAll), page)
 }
 
 // ListHistory mocks base method.
-func (m *MockAppointmentService) ListHistory(start, end time.Time) ([]models.Appointment, error) {
+func (m *MockAppointmentService) ListHistory(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHistory", start, end)
-	ret0, _ := ret[0].([]models.Appointment)
+	ret := m.ctrl.Call(m, "ListHistory", start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListHistory indicates an expected call of ListHistory.
-func (mr *MockAppointmentServiceMockRecorder) ListHistory(start, end interface{}) *gomock.Call {
+func (mr *MockAppointmentServiceMockRecorder) ListHistory(start, end, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockAppointmentService)(nil).ListHistory), start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockAppointmentService)(nil).ListHistory), start, end, page)
+}
+
+// ListProfessionalAgenda mocks base method.
+func (m *MockAppointmentService) ListProfessionalAgenda(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfessionalAgenda", userID, start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfessionalAgenda indicates an expected call of ListProfessionalAgenda.
+func (mr *MockAppointmentServiceMockRecorder) ListProfessionalAgenda(userID, start, end, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfessionalAgenda", reflect.TypeOf((*MockAppointmentService)(nil).ListProfessionalAgenda), userID, start, end, page)
+}
+
+// ListUserHistory mocks base method.
+func (m *MockAppointmentService) ListUserHistory(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserHistory", userID, start, end, page)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserHistory indicates an expected call of ListUserHistory.
+func (mr *MockAppointmentServiceMockRecorder) ListUserHistory(userID, start, end, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserHistory", reflect.TypeOf((*MockAppointmentService)(nil).ListUserHistory), userID, start, end, page)
+}
+
+// MergeAppointments mocks base method.
+func (m *MockAppointmentService) MergeAppointments(claims *models.CustomClaims, existingID uint, newServices []models.Service) (models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeAppointments", claims, existingID, newServices)
+	ret0, _ := ret[0].(models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeAppointments indicates an expected call of MergeAppointments.
+func (mr *MockAppointmentServiceMockRecorder) MergeAppointments(claims, existingID, newServices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeAppointments", reflect.TypeOf((*MockAppointmentService)(nil).MergeAppointments), claims, existingID, newServices)
+}
+
+// SearchAppointments mocks base method.
+func (m *MockAppointmentService) SearchAppointments(query models.AppointmentQuery) (models.AppointmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAppointments", query)
+	ret0, _ := ret[0].(models.AppointmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAppointments indicates an expected call of SearchAppointments.
+func (mr *MockAppointmentServiceMockRecorder) SearchAppointments(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAppointments", reflect.TypeOf((*MockAppointmentService)(nil).SearchAppointments), query)
 }
 
 // UpdateAppointment mocks base method.
-func (m *MockAppointmentService) UpdateAppointment(id uint, newAp models.Appointment) (models.Appointment, error) {
+func (m *MockAppointmentService) UpdateAppointment(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.Appointment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAppointment", id, newAp)
+	ret := m.ctrl.Call(m, "UpdateAppointment", claims, id, newAp)
 	ret0, _ := ret[0].(models.Appointment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAppointment indicates an expected call of UpdateAppointment.
-func (mr *MockAppointmentServiceMockRecorder) UpdateAppointment(id, newAp interface{}) *gomock.Call {
+func (mr *MockAppointmentServiceMockRecorder) UpdateAppointment(claims, id, newAp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppointment", reflect.TypeOf((*MockAppointmentService)(nil).UpdateAppointment), claims, id, newAp)
+}
+
+// UpdateFollowing mocks base method.
+func (m *MockAppointmentService) UpdateFollowing(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.SeriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFollowing", claims, id, newAp)
+	ret0, _ := ret[0].(models.SeriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFollowing indicates an expected call of UpdateFollowing.
+func (mr *MockAppointmentServiceMockRecorder) UpdateFollowing(claims, id, newAp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppointment", reflect.TypeOf((*MockAppointmentService)(nil).UpdateAppointment), id, newAp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFollowing", reflect.TypeOf((*MockAppointmentService)(nil).UpdateFollowing), claims, id, newAp)
 }
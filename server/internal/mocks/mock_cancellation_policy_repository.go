@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/cancellation_policy_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCancellationPolicyRepository is a mock of CancellationPolicyRepository interface.
+type MockCancellationPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCancellationPolicyRepositoryMockRecorder
+}
+
+// MockCancellationPolicyRepositoryMockRecorder is the mock recorder for MockCancellationPolicyRepository.
+type MockCancellationPolicyRepositoryMockRecorder struct {
+	mock *MockCancellationPolicyRepository
+}
+
+// NewMockCancellationPolicyRepository creates a new mock instance.
+func NewMockCancellationPolicyRepository(ctrl *gomock.Controller) *MockCancellationPolicyRepository {
+	mock := &MockCancellationPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockCancellationPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCancellationPolicyRepository) EXPECT() *MockCancellationPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCancellationPolicyRepository) Get() (models.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(models.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Get))
+}
+
+// Save mocks base method.
+func (m *MockCancellationPolicyRepository) Save(policy models.CancellationPolicy) (models.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", policy)
+	ret0, _ := ret[0].(models.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Save(policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Save), policy)
+}
+
+// MockFeeRepository is a mock of FeeRepository interface.
+type MockFeeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeRepositoryMockRecorder
+}
+
+// MockFeeRepositoryMockRecorder is the mock recorder for MockFeeRepository.
+type MockFeeRepositoryMockRecorder struct {
+	mock *MockFeeRepository
+}
+
+// NewMockFeeRepository creates a new mock instance.
+func NewMockFeeRepository(ctrl *gomock.Controller) *MockFeeRepository {
+	mock := &MockFeeRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeRepository) EXPECT() *MockFeeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFeeRepository) Create(fee models.CustomerFee) (models.CustomerFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", fee)
+	ret0, _ := ret[0].(models.CustomerFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFeeRepositoryMockRecorder) Create(fee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFeeRepository)(nil).Create), fee)
+}
+
+// ListByUser mocks base method.
+func (m *MockFeeRepository) ListByUser(userID uint) ([]models.CustomerFee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]models.CustomerFee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockFeeRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockFeeRepository)(nil).ListByUser), userID)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/identity_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdentityService is a mock of IdentityService interface.
+type MockIdentityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityServiceMockRecorder
+}
+
+// MockIdentityServiceMockRecorder is the mock recorder for MockIdentityService.
+type MockIdentityServiceMockRecorder struct {
+	mock *MockIdentityService
+}
+
+// NewMockIdentityService creates a new mock instance.
+func NewMockIdentityService(ctrl *gomock.Controller) *MockIdentityService {
+	mock := &MockIdentityService{ctrl: ctrl}
+	mock.recorder = &MockIdentityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityService) EXPECT() *MockIdentityServiceMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdentityService) Begin(ctx context.Context, provider string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdentityServiceMockRecorder) Begin(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdentityService)(nil).Begin), ctx, provider)
+}
+
+// Complete mocks base method.
+func (m *MockIdentityService) Complete(ctx context.Context, provider, state, code string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, provider, state, code)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdentityServiceMockRecorder) Complete(ctx, provider, state, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdentityService)(nil).Complete), ctx, provider, state, code)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityService) Exchange(loginCode string) (models.User, models.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", loginCode)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(models.TokenPair)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityServiceMockRecorder) Exchange(loginCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityService)(nil).Exchange), loginCode)
+}
+
+// Providers mocks base method.
+func (m *MockIdentityService) Providers() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Providers")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Providers indicates an expected call of Providers.
+func (mr *MockIdentityServiceMockRecorder) Providers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Providers", reflect.TypeOf((*MockIdentityService)(nil).Providers))
+}
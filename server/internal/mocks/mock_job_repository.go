@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/job_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelPending mocks base method.
+func (m *MockJobRepository) CancelPending(kind string, appointmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPending", kind, appointmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPending indicates an expected call of CancelPending.
+func (mr *MockJobRepositoryMockRecorder) CancelPending(kind, appointmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPending", reflect.TypeOf((*MockJobRepository)(nil).CancelPending), kind, appointmentID)
+}
+
+// Create mocks base method.
+func (m *MockJobRepository) Create(job models.Job) (models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRepositoryMockRecorder) Create(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRepository)(nil).Create), job)
+}
+
+// ListByAppointment mocks base method.
+func (m *MockJobRepository) ListByAppointment(appointmentID uint) ([]models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAppointment", appointmentID)
+	ret0, _ := ret[0].([]models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAppointment indicates an expected call of ListByAppointment.
+func (mr *MockJobRepositoryMockRecorder) ListByAppointment(appointmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAppointment", reflect.TypeOf((*MockJobRepository)(nil).ListByAppointment), appointmentID)
+}
+
+// ListDue mocks base method.
+func (m *MockJobRepository) ListDue(now time.Time, limit int) ([]models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", now, limit)
+	ret0, _ := ret[0].([]models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockJobRepositoryMockRecorder) ListDue(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockJobRepository)(nil).ListDue), now, limit)
+}
+
+// Update mocks base method.
+func (m *MockJobRepository) Update(job models.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockJobRepositoryMockRecorder) Update(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockJobRepository)(nil).Update), job)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/login_throttle_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginThrottleRepository is a mock of LoginThrottleRepository interface.
+type MockLoginThrottleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleRepositoryMockRecorder
+}
+
+// MockLoginThrottleRepositoryMockRecorder is the mock recorder for MockLoginThrottleRepository.
+type MockLoginThrottleRepositoryMockRecorder struct {
+	mock *MockLoginThrottleRepository
+}
+
+// NewMockLoginThrottleRepository creates a new mock instance.
+func NewMockLoginThrottleRepository(ctrl *gomock.Controller) *MockLoginThrottleRepository {
+	mock := &MockLoginThrottleRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleRepository) EXPECT() *MockLoginThrottleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLoginThrottleRepository) Delete(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLoginThrottleRepositoryMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoginThrottleRepository)(nil).Delete), key)
+}
+
+// DeleteStale mocks base method.
+func (m *MockLoginThrottleRepository) DeleteStale(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStale", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStale indicates an expected call of DeleteStale.
+func (mr *MockLoginThrottleRepositoryMockRecorder) DeleteStale(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStale", reflect.TypeOf((*MockLoginThrottleRepository)(nil).DeleteStale), before)
+}
+
+// Find mocks base method.
+func (m *MockLoginThrottleRepository) Find(key string) (models.LoginThrottle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", key)
+	ret0, _ := ret[0].(models.LoginThrottle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockLoginThrottleRepositoryMockRecorder) Find(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockLoginThrottleRepository)(nil).Find), key)
+}
+
+// Modify mocks base method.
+func (m *MockLoginThrottleRepository) Modify(key string, change func(*models.LoginThrottle)) (models.LoginThrottle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Modify", key, change)
+	ret0, _ := ret[0].(models.LoginThrottle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Modify indicates an expected call of Modify.
+func (mr *MockLoginThrottleRepositoryMockRecorder) Modify(key, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Modify", reflect.TypeOf((*MockLoginThrottleRepository)(nil).Modify), key, change)
+}
+
+// Save mocks base method.
+func (m *MockLoginThrottleRepository) Save(throttle models.LoginThrottle) (models.LoginThrottle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", throttle)
+	ret0, _ := ret[0].(models.LoginThrottle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLoginThrottleRepositoryMockRecorder) Save(throttle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLoginThrottleRepository)(nil).Save), throttle)
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../notification/dispatcher.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	notification "github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAppointmentNotifier is a mock of AppointmentNotifier interface.
+type MockAppointmentNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppointmentNotifierMockRecorder
+}
+
+// MockAppointmentNotifierMockRecorder is the mock recorder for MockAppointmentNotifier.
+type MockAppointmentNotifierMockRecorder struct {
+	mock *MockAppointmentNotifier
+}
+
+// NewMockAppointmentNotifier creates a new mock instance.
+func NewMockAppointmentNotifier(ctrl *gomock.Controller) *MockAppointmentNotifier {
+	mock := &MockAppointmentNotifier{ctrl: ctrl}
+	mock.recorder = &MockAppointmentNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppointmentNotifier) EXPECT() *MockAppointmentNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAppointmentNotifier) Notify(event notification.Event, ap models.Appointment) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify", event, ap)
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAppointmentNotifierMockRecorder) Notify(event, ap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAppointmentNotifier)(nil).Notify), event, ap)
+}
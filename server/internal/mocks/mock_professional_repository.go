@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/professional_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfessionalRepository is a mock of ProfessionalRepository interface.
+type MockProfessionalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfessionalRepositoryMockRecorder
+}
+
+// MockProfessionalRepositoryMockRecorder is the mock recorder for MockProfessionalRepository.
+type MockProfessionalRepositoryMockRecorder struct {
+	mock *MockProfessionalRepository
+}
+
+// NewMockProfessionalRepository creates a new mock instance.
+func NewMockProfessionalRepository(ctrl *gomock.Controller) *MockProfessionalRepository {
+	mock := &MockProfessionalRepository{ctrl: ctrl}
+	mock.recorder = &MockProfessionalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfessionalRepository) EXPECT() *MockProfessionalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProfessionalRepository) Create(professional models.Professional) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", professional)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProfessionalRepositoryMockRecorder) Create(professional interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProfessionalRepository)(nil).Create), professional)
+}
+
+// Delete mocks base method.
+func (m *MockProfessionalRepository) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfessionalRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfessionalRepository)(nil).Delete), id)
+}
+
+// FindActive mocks base method.
+func (m *MockProfessionalRepository) FindActive() ([]models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive")
+	ret0, _ := ret[0].([]models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockProfessionalRepositoryMockRecorder) FindActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockProfessionalRepository)(nil).FindActive))
+}
+
+// FindAll mocks base method.
+func (m *MockProfessionalRepository) FindAll() ([]models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProfessionalRepositoryMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProfessionalRepository)(nil).FindAll))
+}
+
+// FindByID mocks base method.
+func (m *MockProfessionalRepository) FindByID(id uint) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProfessionalRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProfessionalRepository)(nil).FindByID), id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockProfessionalRepository) FindByUserID(userID uint) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", userID)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockProfessionalRepositoryMockRecorder) FindByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockProfessionalRepository)(nil).FindByUserID), userID)
+}
+
+// Update mocks base method.
+func (m *MockProfessionalRepository) Update(professional models.Professional) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", professional)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfessionalRepositoryMockRecorder) Update(professional interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfessionalRepository)(nil).Update), professional)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/professional_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfessionalService is a mock of ProfessionalService interface.
+type MockProfessionalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfessionalServiceMockRecorder
+}
+
+// MockProfessionalServiceMockRecorder is the mock recorder for MockProfessionalService.
+type MockProfessionalServiceMockRecorder struct {
+	mock *MockProfessionalService
+}
+
+// NewMockProfessionalService creates a new mock instance.
+func NewMockProfessionalService(ctrl *gomock.Controller) *MockProfessionalService {
+	mock := &MockProfessionalService{ctrl: ctrl}
+	mock.recorder = &MockProfessionalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfessionalService) EXPECT() *MockProfessionalServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateProfessional mocks base method.
+func (m *MockProfessionalService) CreateProfessional(professional models.Professional) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProfessional", professional)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProfessional indicates an expected call of CreateProfessional.
+func (mr *MockProfessionalServiceMockRecorder) CreateProfessional(professional interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfessional", reflect.TypeOf((*MockProfessionalService)(nil).CreateProfessional), professional)
+}
+
+// DeleteProfessional mocks base method.
+func (m *MockProfessionalService) DeleteProfessional(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProfessional", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProfessional indicates an expected call of DeleteProfessional.
+func (mr *MockProfessionalServiceMockRecorder) DeleteProfessional(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfessional", reflect.TypeOf((*MockProfessionalService)(nil).DeleteProfessional), id)
+}
+
+// GetProfessional mocks base method.
+func (m *MockProfessionalService) GetProfessional(id uint) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfessional", id)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfessional indicates an expected call of GetProfessional.
+func (mr *MockProfessionalServiceMockRecorder) GetProfessional(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfessional", reflect.TypeOf((*MockProfessionalService)(nil).GetProfessional), id)
+}
+
+// ListActiveProfessionals mocks base method.
+func (m *MockProfessionalService) ListActiveProfessionals() ([]models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveProfessionals")
+	ret0, _ := ret[0].([]models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveProfessionals indicates an expected call of ListActiveProfessionals.
+func (mr *MockProfessionalServiceMockRecorder) ListActiveProfessionals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveProfessionals", reflect.TypeOf((*MockProfessionalService)(nil).ListActiveProfessionals))
+}
+
+// ListProfessionals mocks base method.
+func (m *MockProfessionalService) ListProfessionals() ([]models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfessionals")
+	ret0, _ := ret[0].([]models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfessionals indicates an expected call of ListProfessionals.
+func (mr *MockProfessionalServiceMockRecorder) ListProfessionals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfessionals", reflect.TypeOf((*MockProfessionalService)(nil).ListProfessionals))
+}
+
+// UpdateProfessional mocks base method.
+func (m *MockProfessionalService) UpdateProfessional(professional models.Professional) (models.Professional, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfessional", professional)
+	ret0, _ := ret[0].(models.Professional)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfessional indicates an expected call of UpdateProfessional.
+func (mr *MockProfessionalServiceMockRecorder) UpdateProfessional(professional interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfessional", reflect.TypeOf((*MockProfessionalService)(nil).UpdateProfessional), professional)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/report_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BusiestHours mocks base method.
+func (m *MockReportRepository) BusiestHours(start, end time.Time) ([]models.HourLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BusiestHours", start, end)
+	ret0, _ := ret[0].([]models.HourLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BusiestHours indicates an expected call of BusiestHours.
+func (mr *MockReportRepositoryMockRecorder) BusiestHours(start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BusiestHours", reflect.TypeOf((*MockReportRepository)(nil).BusiestHours), start, end)
+}
+
+// CountByStatus mocks base method.
+func (m *MockReportRepository) CountByStatus(start, end time.Time, bucket models.ReportBucket) ([]models.StatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", start, end, bucket)
+	ret0, _ := ret[0].([]models.StatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockReportRepositoryMockRecorder) CountByStatus(start, end, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockReportRepository)(nil).CountByStatus), start, end, bucket)
+}
+
+// RevenueByPeriod mocks base method.
+func (m *MockReportRepository) RevenueByPeriod(start, end time.Time, bucket models.ReportBucket) ([]models.PeriodRevenue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevenueByPeriod", start, end, bucket)
+	ret0, _ := ret[0].([]models.PeriodRevenue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevenueByPeriod indicates an expected call of RevenueByPeriod.
+func (mr *MockReportRepositoryMockRecorder) RevenueByPeriod(start, end, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevenueByPeriod", reflect.TypeOf((*MockReportRepository)(nil).RevenueByPeriod), start, end, bucket)
+}
+
+// TopServices mocks base method.
+func (m *MockReportRepository) TopServices(start, end time.Time, limit int) ([]models.ServiceRanking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopServices", start, end, limit)
+	ret0, _ := ret[0].([]models.ServiceRanking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopServices indicates an expected call of TopServices.
+func (mr *MockReportRepositoryMockRecorder) TopServices(start, end, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopServices", reflect.TypeOf((*MockReportRepository)(nil).TopServices), start, end, limit)
+}
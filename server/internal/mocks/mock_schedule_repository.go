@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/schedule_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHoliday mocks base method.
+func (m *MockScheduleRepository) CreateHoliday(holiday models.Holiday) (models.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHoliday", holiday)
+	ret0, _ := ret[0].(models.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHoliday indicates an expected call of CreateHoliday.
+func (mr *MockScheduleRepositoryMockRecorder) CreateHoliday(holiday interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHoliday", reflect.TypeOf((*MockScheduleRepository)(nil).CreateHoliday), holiday)
+}
+
+// DeleteHoliday mocks base method.
+func (m *MockScheduleRepository) DeleteHoliday(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteHoliday(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteHoliday), id)
+}
+
+// FindBusinessHours mocks base method.
+func (m *MockScheduleRepository) FindBusinessHours(weekday time.Weekday) (models.BusinessHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBusinessHours", weekday)
+	ret0, _ := ret[0].(models.BusinessHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBusinessHours indicates an expected call of FindBusinessHours.
+func (mr *MockScheduleRepositoryMockRecorder) FindBusinessHours(weekday interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBusinessHours", reflect.TypeOf((*MockScheduleRepository)(nil).FindBusinessHours), weekday)
+}
+
+// IsHoliday mocks base method.
+func (m *MockScheduleRepository) IsHoliday(day time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHoliday", day)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsHoliday indicates an expected call of IsHoliday.
+func (mr *MockScheduleRepositoryMockRecorder) IsHoliday(day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHoliday", reflect.TypeOf((*MockScheduleRepository)(nil).IsHoliday), day)
+}
+
+// ListBusinessHours mocks base method.
+func (m *MockScheduleRepository) ListBusinessHours() ([]models.BusinessHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBusinessHours")
+	ret0, _ := ret[0].([]models.BusinessHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBusinessHours indicates an expected call of ListBusinessHours.
+func (mr *MockScheduleRepositoryMockRecorder) ListBusinessHours() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBusinessHours", reflect.TypeOf((*MockScheduleRepository)(nil).ListBusinessHours))
+}
+
+// ListHolidays mocks base method.
+func (m *MockScheduleRepository) ListHolidays() ([]models.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolidays")
+	ret0, _ := ret[0].([]models.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolidays indicates an expected call of ListHolidays.
+func (mr *MockScheduleRepositoryMockRecorder) ListHolidays() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolidays", reflect.TypeOf((*MockScheduleRepository)(nil).ListHolidays))
+}
+
+// SaveBusinessHours mocks base method.
+func (m *MockScheduleRepository) SaveBusinessHours(hours []models.BusinessHours) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBusinessHours", hours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBusinessHours indicates an expected call of SaveBusinessHours.
+func (mr *MockScheduleRepositoryMockRecorder) SaveBusinessHours(hours interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBusinessHours", reflect.TypeOf((*MockScheduleRepository)(nil).SaveBusinessHours), hours)
+}
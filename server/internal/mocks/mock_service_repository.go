@@ -64,18 +64,33 @@ func (mr *MockServiceRepositoryMockRecorder) Delete(id interface{}) *gomock.Call
 }
 
 // FindAll mocks base method.
-func (m *MockServiceRepository) FindAll() ([]models.Service, error) {
+func (m *MockServiceRepository) FindAll(page models.PageRequest) (models.ServicePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]models.Service)
+	ret := m.ctrl.Call(m, "FindAll", page)
+	ret0, _ := ret[0].(models.ServicePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockServiceRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) FindAll(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockServiceRepository)(nil).FindAll), page)
+}
+
+// FindAllIncludingInactive mocks base method.
+func (m *MockServiceRepository) FindAllIncludingInactive(page models.PageRequest) (models.ServicePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllIncludingInactive", page)
+	ret0, _ := ret[0].(models.ServicePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllIncludingInactive indicates an expected call of FindAllIncludingInactive.
+func (mr *MockServiceRepositoryMockRecorder) FindAllIncludingInactive(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockServiceRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllIncludingInactive", reflect.TypeOf((*MockServiceRepository)(nil).FindAllIncludingInactive), page)
 }
 
 // FindByID mocks base method.
@@ -93,6 +108,21 @@ func (mr *MockServiceRepositoryMockRecorder) FindByID(id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockServiceRepository)(nil).FindByID), id)
 }
 
+// FindByIDs mocks base method.
+func (m *MockServiceRepository) FindByIDs(ids []uint) ([]models.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ids)
+	ret0, _ := ret[0].([]models.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockServiceRepositoryMockRecorder) FindByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockServiceRepository)(nil).FindByIDs), ids)
+}
+
 // FindByName mocks base method.
 func (m *MockServiceRepository) FindByName(name string) (models.Service, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +138,21 @@ func (mr *MockServiceRepositoryMockRecorder) FindByName(name interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockServiceRepository)(nil).FindByName), name)
 }
 
+// Restore mocks base method.
+func (m *MockServiceRepository) Restore(id uint) (models.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(models.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockServiceRepositoryMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockServiceRepository)(nil).Restore), id)
+}
+
 // Update mocks base method.
 func (m *MockServiceRepository) Update(service models.Service) error {
 	m.ctrl.T.Helper()
@@ -79,18 +79,48 @@ func (mr *MockServiceServiceMockRecorder) GetService(id interface{}) *gomock.Cal
 }
 
 // ListServices mocks base method.
-func (m *MockServiceService) ListServices() ([]models.Service, error) {
+func (m *MockServiceService) ListServices(page models.PageRequest) (models.ServicePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListServices")
-	ret0, _ := ret[0].([]models.Service)
+	ret := m.ctrl.Call(m, "ListServices", page)
+	ret0, _ := ret[0].(models.ServicePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListServices indicates an expected call of ListServices.
-func (mr *MockServiceServiceMockRecorder) ListServices() *gomock.Call {
+func (mr *MockServiceServiceMockRecorder) ListServices(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockServiceService)(nil).ListServices))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockServiceService)(nil).ListServices), page)
+}
+
+// ListServicesIncludingInactive mocks base method.
+func (m *MockServiceService) ListServicesIncludingInactive(page models.PageRequest) (models.ServicePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServicesIncludingInactive", page)
+	ret0, _ := ret[0].(models.ServicePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServicesIncludingInactive indicates an expected call of ListServicesIncludingInactive.
+func (mr *MockServiceServiceMockRecorder) ListServicesIncludingInactive(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesIncludingInactive", reflect.TypeOf((*MockServiceService)(nil).ListServicesIncludingInactive), page)
+}
+
+// RestoreService mocks base method.
+func (m *MockServiceService) RestoreService(id uint) (models.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreService", id)
+	ret0, _ := ret[0].(models.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreService indicates an expected call of RestoreService.
+func (mr *MockServiceServiceMockRecorder) RestoreService(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreService", reflect.TypeOf((*MockServiceService)(nil).RestoreService), id)
 }
 
 // UpdateService mocks base method.
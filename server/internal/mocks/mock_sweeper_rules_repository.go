@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/sweeper_rules_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSweeperRulesRepository is a mock of SweeperRulesRepository interface.
+type MockSweeperRulesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweeperRulesRepositoryMockRecorder
+}
+
+// MockSweeperRulesRepositoryMockRecorder is the mock recorder for MockSweeperRulesRepository.
+type MockSweeperRulesRepositoryMockRecorder struct {
+	mock *MockSweeperRulesRepository
+}
+
+// NewMockSweeperRulesRepository creates a new mock instance.
+func NewMockSweeperRulesRepository(ctrl *gomock.Controller) *MockSweeperRulesRepository {
+	mock := &MockSweeperRulesRepository{ctrl: ctrl}
+	mock.recorder = &MockSweeperRulesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweeperRulesRepository) EXPECT() *MockSweeperRulesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSweeperRulesRepository) Get() (models.SweeperRules, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get")
+	ret0, _ := ret[0].(models.SweeperRules)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSweeperRulesRepositoryMockRecorder) Get() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSweeperRulesRepository)(nil).Get))
+}
+
+// Save mocks base method.
+func (m *MockSweeperRulesRepository) Save(rules models.SweeperRules) (models.SweeperRules, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", rules)
+	ret0, _ := ret[0].(models.SweeperRules)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSweeperRulesRepositoryMockRecorder) Save(rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSweeperRulesRepository)(nil).Save), rules)
+}
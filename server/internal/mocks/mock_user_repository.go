@@ -1,11 +1,14 @@
 // Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/user_repository.go
+
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	"reflect"
+	reflect "reflect"
 
-	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
-	"github.com/golang/mock/gomock"
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
 )
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -21,9 +24,9 @@ type MockUserRepositoryMockRecorder struct {
 
 // NewMockUserRepository creates a new mock instance.
 func NewMockUserRepository(ctrl *gomock.Controller) *MockUserRepository {
-	m := &MockUserRepository{ctrl: ctrl}
-	m.recorder = &MockUserRepositoryMockRecorder{mock: m}
-	return m
+	mock := &MockUserRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryMockRecorder{mock}
+	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
@@ -46,77 +49,78 @@ func (mr *MockUserRepositoryMockRecorder) Create(user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), user)
 }
 
-// FindByID mocks base method.
-func (m *MockUserRepository) FindByID(id uint) (models.User, error) {
+// Delete mocks base method.
+func (m *MockUserRepository) Delete(id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
-	ret0, _ := ret[0].(models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FindByID indicates an expected call of FindByID.
-func (mr *MockUserRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockUserRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), id)
 }
 
-// FindByEmail mocks base method.
-func (m *MockUserRepository) FindByEmail(email string) (models.User, error) {
+// FindAll mocks base method.
+func (m *MockUserRepository) FindAll(page models.PageRequest) (models.UserPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", email)
-	ret0, _ := ret[0].(models.User)
+	ret := m.ctrl.Call(m, "FindAll", page)
+	ret0, _ := ret[0].(models.UserPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserRepositoryMockRecorder) FindByEmail(email interface{}) *gomock.Call {
+// FindAll indicates an expected call of FindAll.
+func (mr *MockUserRepositoryMockRecorder) FindAll(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll), page)
 }
 
-// Update mocks base method.
-func (m *MockUserRepository) Update(user models.User) error {
+// FindAllIncludingInactive mocks base method.
+func (m *MockUserRepository) FindAllIncludingInactive(page models.PageRequest) (models.UserPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", user)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindAllIncludingInactive", page)
+	ret0, _ := ret[0].(models.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockUserRepositoryMockRecorder) Update(user interface{}) *gomock.Call {
+// FindAllIncludingInactive indicates an expected call of FindAllIncludingInactive.
+func (mr *MockUserRepositoryMockRecorder) FindAllIncludingInactive(page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllIncludingInactive", reflect.TypeOf((*MockUserRepository)(nil).FindAllIncludingInactive), page)
 }
 
-// Delete mocks base method.
-func (m *MockUserRepository) Delete(id uint) error {
+// FindByEmail mocks base method.
+func (m *MockUserRepository) FindByEmail(email string) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByEmail", email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserRepositoryMockRecorder) FindByEmail(email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindByEmail), email)
 }
 
-// FindAll mocks base method.
-func (m *MockUserRepository) FindAll() ([]models.User, error) {
+// FindByID mocks base method.
+func (m *MockUserRepository) FindByID(id uint) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].([]models.User)
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAll indicates an expected call of FindAll.
-func (mr *MockUserRepositoryMockRecorder) FindAll() *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), id)
 }
 
 // FindByRole mocks base method.
@@ -133,3 +137,32 @@ func (mr *MockUserRepositoryMockRecorder) FindByRole(role interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRole", reflect.TypeOf((*MockUserRepository)(nil).FindByRole), role)
 }
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), id)
+}
+
+// Update mocks base method.
+func (m *MockUserRepository) Update(user models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserRepositoryMockRecorder) Update(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), user)
+}
@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/waitlist_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWaitlistRepository is a mock of WaitlistRepository interface.
+type MockWaitlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitlistRepositoryMockRecorder
+}
+
+// MockWaitlistRepositoryMockRecorder is the mock recorder for MockWaitlistRepository.
+type MockWaitlistRepositoryMockRecorder struct {
+	mock *MockWaitlistRepository
+}
+
+// NewMockWaitlistRepository creates a new mock instance.
+func NewMockWaitlistRepository(ctrl *gomock.Controller) *MockWaitlistRepository {
+	mock := &MockWaitlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWaitlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitlistRepository) EXPECT() *MockWaitlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MockWaitlistRepository) ChangeStatus(id uint, from, to models.WaitlistStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockWaitlistRepositoryMockRecorder) ChangeStatus(id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockWaitlistRepository)(nil).ChangeStatus), id, from, to)
+}
+
+// CloseOffer mocks base method.
+func (m *MockWaitlistRepository) CloseOffer(id uint, status models.WaitlistOfferStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseOffer", id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseOffer indicates an expected call of CloseOffer.
+func (mr *MockWaitlistRepositoryMockRecorder) CloseOffer(id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseOffer", reflect.TypeOf((*MockWaitlistRepository)(nil).CloseOffer), id, status)
+}
+
+// Create mocks base method.
+func (m *MockWaitlistRepository) Create(entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWaitlistRepositoryMockRecorder) Create(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWaitlistRepository)(nil).Create), entry)
+}
+
+// CreateOffer mocks base method.
+func (m *MockWaitlistRepository) CreateOffer(offer models.WaitlistOffer) (models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOffer", offer)
+	ret0, _ := ret[0].(models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOffer indicates an expected call of CreateOffer.
+func (mr *MockWaitlistRepositoryMockRecorder) CreateOffer(offer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOffer", reflect.TypeOf((*MockWaitlistRepository)(nil).CreateOffer), offer)
+}
+
+// FindByID mocks base method.
+func (m *MockWaitlistRepository) FindByID(id uint) (models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWaitlistRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWaitlistRepository)(nil).FindByID), id)
+}
+
+// FindOffer mocks base method.
+func (m *MockWaitlistRepository) FindOffer(id uint) (models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOffer", id)
+	ret0, _ := ret[0].(models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOffer indicates an expected call of FindOffer.
+func (mr *MockWaitlistRepositoryMockRecorder) FindOffer(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOffer", reflect.TypeOf((*MockWaitlistRepository)(nil).FindOffer), id)
+}
+
+// ListByUser mocks base method.
+func (m *MockWaitlistRepository) ListByUser(userID uint) ([]models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockWaitlistRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockWaitlistRepository)(nil).ListByUser), userID)
+}
+
+// ListExpiredOffers mocks base method.
+func (m *MockWaitlistRepository) ListExpiredOffers(now time.Time) ([]models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredOffers", now)
+	ret0, _ := ret[0].([]models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredOffers indicates an expected call of ListExpiredOffers.
+func (mr *MockWaitlistRepositoryMockRecorder) ListExpiredOffers(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredOffers", reflect.TypeOf((*MockWaitlistRepository)(nil).ListExpiredOffers), now)
+}
+
+// ListHolds mocks base method.
+func (m *MockWaitlistRepository) ListHolds(start, end, now time.Time) ([]models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolds", start, end, now)
+	ret0, _ := ret[0].([]models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolds indicates an expected call of ListHolds.
+func (mr *MockWaitlistRepositoryMockRecorder) ListHolds(start, end, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolds", reflect.TypeOf((*MockWaitlistRepository)(nil).ListHolds), start, end, now)
+}
+
+// ListOffersBySource mocks base method.
+func (m *MockWaitlistRepository) ListOffersBySource(appointmentID uint) ([]models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOffersBySource", appointmentID)
+	ret0, _ := ret[0].([]models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOffersBySource indicates an expected call of ListOffersBySource.
+func (mr *MockWaitlistRepositoryMockRecorder) ListOffersBySource(appointmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOffersBySource", reflect.TypeOf((*MockWaitlistRepository)(nil).ListOffersBySource), appointmentID)
+}
+
+// ListPendingOffers mocks base method.
+func (m *MockWaitlistRepository) ListPendingOffers(userID uint, now time.Time) ([]models.WaitlistOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingOffers", userID, now)
+	ret0, _ := ret[0].([]models.WaitlistOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingOffers indicates an expected call of ListPendingOffers.
+func (mr *MockWaitlistRepositoryMockRecorder) ListPendingOffers(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingOffers", reflect.TypeOf((*MockWaitlistRepository)(nil).ListPendingOffers), userID, now)
+}
+
+// ListWaiting mocks base method.
+func (m *MockWaitlistRepository) ListWaiting(day string) ([]models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaiting", day)
+	ret0, _ := ret[0].([]models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaiting indicates an expected call of ListWaiting.
+func (mr *MockWaitlistRepositoryMockRecorder) ListWaiting(day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaiting", reflect.TypeOf((*MockWaitlistRepository)(nil).ListWaiting), day)
+}
+
+// SetOfferAppointment mocks base method.
+func (m *MockWaitlistRepository) SetOfferAppointment(id, appointmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOfferAppointment", id, appointmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOfferAppointment indicates an expected call of SetOfferAppointment.
+func (mr *MockWaitlistRepositoryMockRecorder) SetOfferAppointment(id, appointmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOfferAppointment", reflect.TypeOf((*MockWaitlistRepository)(nil).SetOfferAppointment), id, appointmentID)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidAccountToken  = errors.New("invalid or expired token")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrEmailNotVerified     = errors.New("confirme o seu e-mail antes de agendar")
+)
+
+// AccountTokenPurpose tells what an account token may be used for
+type AccountTokenPurpose string
+
+const (
+	PurposePasswordReset     AccountTokenPurpose = "password_reset"
+	PurposeEmailVerification AccountTokenPurpose = "email_verification"
+	// PurposeOIDCLogin is the code handed to the web app after an OpenID Connect login, traded for the tokens
+	PurposeOIDCLogin AccountTokenPurpose = "oidc_login"
+)
+
+// AccountToken is a single-use token sent by email to reset a password or verify an address, or
+// handed to the web app after a login through an identity provider. Only its hash is stored.
+type AccountToken struct {
+	ID        uint                `gorm:"primaryKey" json:"id"`
+	UserID    uint                `gorm:"index" json:"user_id"`
+	Purpose   AccountTokenPurpose `gorm:"index" json:"purpose"`
+	TokenHash string              `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt time.Time           `json:"expires_at"`
+	UsedAt    *time.Time          `json:"used_at,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+}
@@ -12,12 +12,60 @@ const (
 	StatusConfirmed AppointmentStatus = "CONFIRMED"
 	StatusDone      AppointmentStatus = "DONE"
 	StatusCanceled  AppointmentStatus = "CANCELED"
+	StatusNoShow    AppointmentStatus = "NO_SHOW"
+	// StatusExpired is set by the system on pending appointments whose date passed without being confirmed
+	StatusExpired AppointmentStatus = "EXPIRED"
 )
 
 var (
-	ErrAppointmentNoServices = errors.New("appointment must have at least one service")
+	ErrAppointmentNoServices   = errors.New("appointment must have at least one service")
+	ErrInvalidStatus           = errors.New("status de agendamento inválido")
+	ErrInvalidStatusTransition = errors.New("mudança de status não permitida")
+	ErrAppointmentClosed       = errors.New("agendamento já encerrado não pode ser alterado")
 )
 
+// statusTransitions lists, for each status, the statuses it may move to. DONE, CANCELED, NO_SHOW and EXPIRED are final.
+var statusTransitions = map[AppointmentStatus][]AppointmentStatus{
+	StatusPending:   {StatusConfirmed, StatusCanceled, StatusNoShow, StatusExpired},
+	StatusConfirmed: {StatusDone, StatusCanceled, StatusNoShow},
+	StatusDone:      {},
+	StatusCanceled:  {},
+	StatusNoShow:    {},
+	StatusExpired:   {},
+}
+
+// IsValid reports whether the status is one of the known appointment statuses
+func (s AppointmentStatus) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an appointment in this status may move to next
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether no further changes are allowed in this status
+func (s AppointmentStatus) IsFinal() bool {
+	return s.IsValid() && len(statusTransitions[s]) == 0
+}
+
+// ValidateTransition returns the error describing why the status cannot move to next, if any
+func (s AppointmentStatus) ValidateTransition(next AppointmentStatus) error {
+	if !next.IsValid() {
+		return ErrInvalidStatus
+	}
+	if !s.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+	return nil
+}
+
 type Appointment struct {
 	ID        uint              `gorm:"primaryKey" json:"id"`
 	User      User              `gorm:"foreignKey:UserID" json:"user"`
@@ -27,6 +75,40 @@ type Appointment struct {
 	Status    AppointmentStatus `json:"status"`
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
+
+	// ProfessionalID is nil when the salon has no professionals registered
+	ProfessionalID *uint         `json:"professional_id,omitempty"`
+	Professional   *Professional `gorm:"foreignKey:ProfessionalID" json:"professional,omitempty"`
+
+	// RescheduleCount is how many times the appointment was moved to another date
+	RescheduleCount int `json:"reschedule_count"`
+
+	// SeriesID links the appointments booked together by a recurrence rule
+	SeriesID *uint `gorm:"index" json:"series_id,omitempty"`
+
+	// Items are the services as they were booked, Services link them to the current catalog
+	Items []AppointmentItem `gorm:"foreignKey:AppointmentID" json:"items"`
+}
+
+// AppointmentItem is a service as it was when it was booked, so later changes to the catalog
+// do not alter the value or length of past appointments
+type AppointmentItem struct {
+	ID              uint    `gorm:"primaryKey" json:"id"`
+	AppointmentID   uint    `gorm:"index" json:"appointment_id"`
+	ServiceID       uint    `gorm:"index" json:"service_id"`
+	Name            string  `json:"name"`
+	Price           float64 `json:"price"`
+	DurationMinutes int     `json:"duration_minutes"`
+}
+
+// NewAppointmentItem snapshots the current name, price and duration of the service
+func NewAppointmentItem(s Service) AppointmentItem {
+	return AppointmentItem{
+		ServiceID:       s.ID,
+		Name:            s.Name,
+		Price:           s.Price,
+		DurationMinutes: s.DurationMinutes,
+	}
 }
 
 // Validate checks if the appointment is valid
@@ -37,6 +119,129 @@ func (a *Appointment) Validate() error {
 	return nil
 }
 
+// Duration returns the total time needed to perform all services of the appointment, as booked.
+// The catalog services are only used while the appointment has no items yet.
+func (a Appointment) Duration() time.Duration {
+	if len(a.Items) == 0 {
+		return TotalDuration(a.Services)
+	}
+	total := 0
+	for _, item := range a.Items {
+		total += item.DurationMinutes
+	}
+	return time.Duration(total) * time.Minute
+}
+
+// EndDate returns the moment the appointment is expected to finish
+func (a Appointment) EndDate() time.Time {
+	return a.Date.Add(a.Duration())
+}
+
+// Overlaps reports whether the appointment occupies any time within [start, end)
+func (a Appointment) Overlaps(start, end time.Time) bool {
+	return a.Date.Before(end) && a.EndDate().After(start)
+}
+
+// AssignedTo reports whether the appointment occupies the agenda of the given professional.
+// A nil professional stands for the shared agenda used while the salon has no professionals. Unassigned
+// appointments, booked before the salon registered any, hold every agenda as nobody knows who will take them.
+func (a Appointment) AssignedTo(professionalID *uint) bool {
+	if a.ProfessionalID == nil || professionalID == nil {
+		return true
+	}
+	return *a.ProfessionalID == *professionalID
+}
+
+// TotalPrice returns the amount charged for all services of the appointment, as booked
+func (a Appointment) TotalPrice() float64 {
+	total := 0.0
+	if len(a.Items) == 0 {
+		for _, s := range a.Services {
+			total += s.Price
+		}
+		return total
+	}
+	for _, item := range a.Items {
+		total += item.Price
+	}
+	return total
+}
+
+// TotalDuration sums the duration of the given services
+func TotalDuration(services []Service) time.Duration {
+	total := 0
+	for _, s := range services {
+		total += s.DurationMinutes
+	}
+	return time.Duration(total) * time.Minute
+}
+
+// AppointmentStatusHistory records a status change of an appointment
+type AppointmentStatusHistory struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	AppointmentID uint              `gorm:"index" json:"appointment_id"`
+	FromStatus    AppointmentStatus `json:"from_status"`
+	ToStatus      AppointmentStatus `json:"to_status"`
+	// ChangedByID is nil when the change was made by the system
+	ChangedByID *uint     `json:"changed_by_id"`
+	ChangedBy   *User     `gorm:"foreignKey:ChangedByID" json:"changed_by,omitempty"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+func (AppointmentStatusHistory) TableName() string {
+	return "appointment_status_history"
+}
+
+var ErrInvalidSort = errors.New("ordenação inválida, use date, -date, created_at ou -created_at")
+
+// AppointmentSort orders searched appointments by a column, descending when prefixed by a minus sign
+type AppointmentSort string
+
+const (
+	SortDateAsc       AppointmentSort = "date"
+	SortDateDesc      AppointmentSort = "-date"
+	SortCreatedAtAsc  AppointmentSort = "created_at"
+	SortCreatedAtDesc AppointmentSort = "-created_at"
+)
+
+// IsValid reports whether the sort is one of the supported orders, empty meaning SortDateAsc
+func (s AppointmentSort) IsValid() bool {
+	switch s {
+	case "", SortDateAsc, SortDateDesc, SortCreatedAtAsc, SortCreatedAtDesc:
+		return true
+	}
+	return false
+}
+
+// AppointmentQuery selects the appointments searched by the staff. Empty fields match every appointment.
+type AppointmentQuery struct {
+	Statuses []AppointmentStatus
+	// ServiceIDs matches the appointments with any of the services
+	ServiceIDs     []uint
+	UserID         *uint
+	ProfessionalID *uint
+	// From and To bound the appointment date, both inclusive
+	From *time.Time
+	To   *time.Time
+	// Text matches part of the customer name, email or phone, ignoring case
+	Text string
+	Sort AppointmentSort
+	Page PageRequest
+}
+
+// Validate checks the statuses and the sort
+func (q AppointmentQuery) Validate() error {
+	for _, status := range q.Statuses {
+		if !status.IsValid() {
+			return ErrInvalidStatus
+		}
+	}
+	if !q.Sort.IsValid() {
+		return ErrInvalidSort
+	}
+	return nil
+}
+
 type AppointmentFilter struct {
 	UserID    *uint      `json:"user_id"`
 	StartDate *time.Time `json:"start_date"`
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var ErrInvalidCancellationPolicy = errors.New("política de cancelamento inválida")
+
+// CancellationPolicy holds the rules customers must follow to reschedule or cancel their appointments.
+// There is a single policy for the salon.
+type CancellationPolicy struct {
+	ID                    uint `gorm:"primaryKey" json:"-"`
+	RescheduleNoticeHours int  `json:"reschedule_notice_hours"`
+	CancelNoticeHours     int  `json:"cancel_notice_hours"`
+	// LateCancelFeePercent allows cancellations within the notice period in exchange for a fee. When zero they are refused.
+	LateCancelFeePercent float64 `json:"late_cancel_fee_percent"`
+	NoShowFeePercent     float64 `json:"no_show_fee_percent"`
+	// MaxReschedules is how many times a customer may move an appointment, zero means unlimited
+	MaxReschedules int       `json:"max_reschedules"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// DefaultCancellationPolicy is used while the admin has not configured one: 48 hours of notice and no fees
+func DefaultCancellationPolicy() CancellationPolicy {
+	return CancellationPolicy{
+		ID:                    1,
+		RescheduleNoticeHours: 48,
+		CancelNoticeHours:     48,
+	}
+}
+
+// Validate checks that notices and limits are not negative and fees are between 0 and 100%
+func (p CancellationPolicy) Validate() error {
+	if p.RescheduleNoticeHours < 0 || p.CancelNoticeHours < 0 || p.MaxReschedules < 0 {
+		return ErrInvalidCancellationPolicy
+	}
+	if p.LateCancelFeePercent < 0 || p.LateCancelFeePercent > 100 {
+		return ErrInvalidCancellationPolicy
+	}
+	if p.NoShowFeePercent < 0 || p.NoShowFeePercent > 100 {
+		return ErrInvalidCancellationPolicy
+	}
+	return nil
+}
+
+// CheckChange returns ErrRescheduleTooLate when the appointment is too close to be changed by the customer
+func (p CancellationPolicy) CheckChange(ap Appointment, now time.Time) error {
+	if ap.Date.Sub(now) < time.Duration(p.RescheduleNoticeHours)*time.Hour {
+		return ErrRescheduleTooLate
+	}
+	return nil
+}
+
+// CheckReschedule also enforces the maximum number of times the appointment may be moved
+func (p CancellationPolicy) CheckReschedule(ap Appointment, now time.Time) error {
+	if err := p.CheckChange(ap, now); err != nil {
+		return err
+	}
+	if p.MaxReschedules > 0 && ap.RescheduleCount >= p.MaxReschedules {
+		return ErrRescheduleLimitReached
+	}
+	return nil
+}
+
+// LateCancellationFee returns the fee owed for cancelling the appointment now, if any.
+// Late cancellations are refused with ErrCancelTooLate unless the policy charges for them.
+func (p CancellationPolicy) LateCancellationFee(ap Appointment, now time.Time) (*CustomerFee, error) {
+	if ap.Date.Sub(now) >= time.Duration(p.CancelNoticeHours)*time.Hour {
+		return nil, nil
+	}
+	if p.LateCancelFeePercent == 0 {
+		return nil, ErrCancelTooLate
+	}
+	return newFee(ap, FeeLateCancellation, p.LateCancelFeePercent), nil
+}
+
+// NoShowFee returns the fee owed when the customer misses the appointment, if any
+func (p CancellationPolicy) NoShowFee(ap Appointment) *CustomerFee {
+	if p.NoShowFeePercent == 0 {
+		return nil
+	}
+	return newFee(ap, FeeNoShow, p.NoShowFeePercent)
+}
+
+type FeeReason string
+
+const (
+	FeeLateCancellation FeeReason = "LATE_CANCELLATION"
+	FeeNoShow           FeeReason = "NO_SHOW"
+)
+
+// CustomerFee is an amount charged to the customer's account because of an appointment
+type CustomerFee struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	UserID        uint      `gorm:"index" json:"user_id"`
+	AppointmentID uint      `json:"appointment_id"`
+	Reason        FeeReason `json:"reason"`
+	Percent       float64   `json:"percent"`
+	Amount        float64   `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newFee(ap Appointment, reason FeeReason, percent float64) *CustomerFee {
+	return &CustomerFee{
+		UserID:        ap.UserID,
+		AppointmentID: ap.ID,
+		Reason:        reason,
+		Percent:       percent,
+		Amount:        math.Round(ap.TotalPrice()*percent) / 100,
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import "github.com/golang-jwt/jwt/v5"
+
+// CustomClaims is the payload of the access tokens, stored in the request context by the JWT middleware
+type CustomClaims struct {
+	UserID uint     `json:"user_id"`
+	Email  string   `json:"email"`
+	Role   UserRole `json:"role"`
+	// PasswordChangeRequired restricts the token to changing the password
+	PasswordChangeRequired bool `json:"pwd_change,omitempty"`
+	jwt.RegisteredClaims
+}
@@ -3,5 +3,18 @@ package models
 import "errors"
 
 var (
-	ErrCannotUpdateWithingTwoDays = errors.New("alterações dentro de 2 dias não são permitidas")
+	ErrRescheduleTooLate         = errors.New("alterações com tão pouca antecedência não são permitidas")
+	ErrRescheduleLimitReached    = errors.New("limite de reagendamentos atingido")
+	ErrCancelTooLate             = errors.New("cancelamento com tão pouca antecedência não é permitido")
+	ErrTimeSlotUnavailable       = errors.New("horário indisponível para os serviços selecionados")
+	ErrUnknownService            = errors.New("serviço não encontrado")
+	ErrUnknownProfessional       = errors.New("profissional não encontrado")
+	ErrProfessionalCannotPerform = errors.New("profissional não realiza os serviços selecionados")
+	ErrNoProfessionalForServices = errors.New("nenhum profissional realiza os serviços selecionados")
+	ErrSalonClosed               = errors.New("o salão não abre neste dia da semana")
+	ErrHolidayClosure            = errors.New("o salão estará fechado nesta data")
+	ErrOutsideBusinessHours      = errors.New("horário fora do expediente do salão")
+	ErrAppointmentNotOwned       = errors.New("você só pode acessar seus próprios agendamentos")
+	ErrNoLinkedProfessional      = errors.New("usuário não está vinculado a um profissional")
+	ErrAdminOnly                 = errors.New("ação permitida apenas para a equipe do salão")
 )
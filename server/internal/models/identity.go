@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownIdentityProvider = errors.New("unknown identity provider")
+	ErrInvalidLoginState       = errors.New("invalid or expired login attempt")
+	// ErrIdentityEmailNotVerified keeps unverified provider accounts from taking over accounts with the same email
+	ErrIdentityEmailNotVerified = errors.New("the identity provider has not verified this email")
+)
+
+// UserIdentity links a user to their account at an OpenID Connect provider
+type UserIdentity struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"index" json:"user_id"`
+	// Provider and Subject identify the account at the provider, the email may change there
+	Provider  string    `gorm:"uniqueIndex:idx_identity_subject" json:"provider"`
+	Subject   string    `gorm:"uniqueIndex:idx_identity_subject" json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginState is kept between sending the user to the provider and their return, for a few minutes.
+// Only the hash of the state is stored, the PKCE verifier and nonce never leave the server.
+type LoginState struct {
+	ID           uint   `gorm:"primaryKey"`
+	StateHash    string `gorm:"uniqueIndex"`
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time `gorm:"index"`
+	CreatedAt    time.Time
+}
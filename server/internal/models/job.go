@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending  JobStatus = "PENDING"
+	JobDone     JobStatus = "DONE"
+	JobFailed   JobStatus = "FAILED"
+	JobCanceled JobStatus = "CANCELED"
+)
+
+// JobAppointmentReminder reminds the customer of a confirmed appointment, its payload is the appointment date it was planned for
+const JobAppointmentReminder = "appointment_reminder"
+
+// JobWaitlistOffer tells a waitlisted customer about the slot held for them, its payload is the offer ID
+const JobWaitlistOffer = "waitlist_offer"
+
+// Job is a unit of background work. Jobs are stored so that work planned before a restart is not lost.
+type Job struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Kind string `gorm:"index" json:"kind"`
+	// AppointmentID is set for jobs about an appointment, so they can be dropped when it changes
+	AppointmentID *uint     `gorm:"index" json:"appointment_id,omitempty"`
+	Payload       string    `json:"payload"`
+	RunAt         time.Time `gorm:"index" json:"run_at"`
+	Status        JobStatus `gorm:"index" json:"status"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
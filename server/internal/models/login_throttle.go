@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginThrottle counts the recent failed logins of an account or of an IP address, and the recent password
+// reset requests of an email or of an IP address
+type LoginThrottle struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	// Key is "account:<email>", "ip:<address>", "reset:<email>" or "reset-ip:<address>"
+	Key           string     `gorm:"uniqueIndex" json:"key"`
+	Failures      int        `json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func AccountThrottleKey(email string) string {
+	return "account:" + email
+}
+
+func IPThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+func PasswordResetThrottleKey(email string) string {
+	return "reset:" + email
+}
+
+func PasswordResetIPThrottleKey(ip string) string {
+	return "reset-ip:" + ip
+}
+
+// ThrottlePolicy tells how long a key must wait after failed logins. The first FreeAttempts failures
+// cost nothing, then each failure doubles the wait starting at BaseDelay, and after LockAfter failures
+// the key is locked for LockDuration.
+type ThrottlePolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	LockAfter    int
+	LockDuration time.Duration
+	// Window is how long failures are remembered when no new failure happens
+	Window time.Duration
+}
+
+// Delay is the wait imposed after the given number of failures
+func (p ThrottlePolicy) Delay(failures int) time.Duration {
+	if failures < p.FreeAttempts {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// BlockedUntil returns until when the key cannot try to log in, the zero time when it can now
+func (p ThrottlePolicy) BlockedUntil(t LoginThrottle, now time.Time) time.Time {
+	if p.expired(t, now) {
+		return time.Time{}
+	}
+	until := t.LastFailureAt.Add(p.Delay(t.Failures))
+	if t.LockedUntil != nil && t.LockedUntil.After(until) {
+		until = *t.LockedUntil
+	}
+	if !until.After(now) {
+		return time.Time{}
+	}
+	return until
+}
+
+// RecordFailure counts a failed login and reports whether it locked the key
+func (p ThrottlePolicy) RecordFailure(t *LoginThrottle, now time.Time) bool {
+	if p.expired(*t, now) {
+		t.Failures = 0
+		t.LockedUntil = nil
+	}
+	t.Failures++
+	t.LastFailureAt = now
+	if p.LockAfter > 0 && t.Failures >= p.LockAfter && t.LockedUntil == nil {
+		until := now.Add(p.LockDuration)
+		t.LockedUntil = &until
+		return true
+	}
+	return false
+}
+
+// expired reports whether the past failures no longer count, because the lock ended or they are too old
+func (p ThrottlePolicy) expired(t LoginThrottle, now time.Time) bool {
+	if t.LockedUntil != nil {
+		return !now.Before(*t.LockedUntil)
+	}
+	return p.Window > 0 && now.Sub(t.LastFailureAt) > p.Window
+}
+
+// LoginThrottledError is returned while an account or an IP address must wait before trying to log in again
+type LoginThrottledError struct {
+	Until  time.Time
+	Locked bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, locked until %s", e.Until.Format(time.RFC3339))
+	}
+	return "too many failed login attempts, try again later"
+}
+
+// RetryAfter is how long the client should wait before trying again
+func (e *LoginThrottledError) RetryAfter(now time.Time) time.Duration {
+	return e.Until.Sub(now)
+}
+
+// RequestThrottledError is returned while an email or an IP address must wait before asking for another email
+type RequestThrottledError struct {
+	Until time.Time
+}
+
+func (e *RequestThrottledError) Error() string {
+	return "too many requests, try again later"
+}
+
+// RetryAfter is how long the client should wait before trying again
+func (e *RequestThrottledError) RetryAfter(now time.Time) time.Duration {
+	return e.Until.Sub(now)
+}
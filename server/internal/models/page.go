@@ -0,0 +1,53 @@
+package models
+
+import "errors"
+
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+const (
+	// DefaultPageLimit is the page size used when the client does not ask for one
+	DefaultPageLimit = 50
+	// MaxPageLimit bounds the page size a client may ask for
+	MaxPageLimit = 200
+)
+
+// PageRequest asks for the page after Cursor, which is empty for the first page
+type PageRequest struct {
+	Cursor string
+	Limit  int
+	// WithTotal asks for the size of the whole list, which costs one more query
+	WithTotal bool
+}
+
+// Size returns the requested page size, within DefaultPageLimit and MaxPageLimit
+func (p PageRequest) Size() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// PageInfo locates a page within the whole list. NextCursor is empty on the last page.
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the size of the whole list, only counted when asked for
+	Total *int64 `json:"-"`
+}
+
+type AppointmentPage struct {
+	Items []Appointment `json:"items"`
+	PageInfo
+}
+
+type UserPage struct {
+	Items []User `json:"items"`
+	PageInfo
+}
+
+type ServicePage struct {
+	Items []Service `json:"items"`
+	PageInfo
+}
@@ -0,0 +1,61 @@
+package models
+
+import "errors"
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// Permission is an action a role may perform. Routes require permissions, never roles.
+type Permission string
+
+const (
+	PermManageUsers         Permission = "users:manage"
+	PermManageCatalog       Permission = "catalog:manage"
+	PermManageProfessionals Permission = "professionals:manage"
+	// PermManageSettings covers business hours, holidays, the cancellation policy and the sweeper rules
+	PermManageSettings Permission = "settings:manage"
+	// PermViewAppointments lists the appointments of every customer
+	PermViewAppointments Permission = "appointments:view"
+	// PermManageAppointments books for customers and changes any appointment, including its status
+	PermManageAppointments Permission = "appointments:manage"
+	// PermViewOwnAgenda lists the appointments assigned to the professional linked to the user
+	PermViewOwnAgenda Permission = "agenda:view_own"
+	PermViewReports   Permission = "reports:view"
+)
+
+// rolePermissions defines each role as a set of permissions. Customers only act on their own data.
+var rolePermissions = map[UserRole][]Permission{
+	RoleAdmin: {
+		PermManageUsers, PermManageCatalog, PermManageProfessionals, PermManageSettings,
+		PermViewAppointments, PermManageAppointments, PermViewOwnAgenda, PermViewReports,
+	},
+	RoleManager:      {PermViewAppointments, PermViewReports},
+	RoleReceptionist: {PermViewAppointments, PermManageAppointments},
+	RoleProfessional: {PermViewOwnAgenda},
+	RoleCustomer:     {},
+}
+
+// Roles lists every role, from the most to the least privileged
+func Roles() []UserRole {
+	return []UserRole{RoleAdmin, RoleManager, RoleReceptionist, RoleProfessional, RoleCustomer}
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r UserRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions granted to the role
+func (r UserRole) Permissions() []Permission {
+	return append([]Permission{}, rolePermissions[r]...)
+}
+
+// Can reports whether the role grants the permission
+func (r UserRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type Professional struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	IsActive bool   `json:"is_active" gorm:"default:true"`
+	// UserID links the professional to the account they log in with, to see their own agenda
+	UserID    *uint     `gorm:"uniqueIndex" json:"user_id,omitempty"`
+	Services  []Service `json:"services" gorm:"many2many:professional_services;"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CanPerform reports whether the professional is able to perform every given service
+func (p Professional) CanPerform(services []Service) bool {
+	skills := make(map[uint]bool, len(p.Services))
+	for _, s := range p.Services {
+		skills[s.ID] = true
+	}
+	for _, s := range services {
+		if !skills[s.ID] {
+			return false
+		}
+	}
+	return true
+}
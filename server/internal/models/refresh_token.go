@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused means a rotated token was presented again, so it may have been stolen
+	ErrRefreshTokenReused = errors.New("refresh token already used, all sessions of this login were revoked")
+)
+
+// RefreshToken is an opaque token that trades for a new access token. Only its hash is stored.
+// Each use rotates it: the token is marked as used and a new one of the same family is issued.
+type RefreshToken struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"index" json:"user_id"`
+	// FamilyID groups the tokens rotated from the same login, they are revoked together
+	FamilyID  string     `gorm:"index" json:"family_id"`
+	TokenHash string     `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TokenPair is what a client receives when it logs in or refreshes its session
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidReportBucket = errors.New("agrupamento de relatório inválido, use day, week ou month")
+	ErrInvalidReportRange  = errors.New("período de relatório inválido")
+)
+
+// ReportBucket is the size of the periods a report is split into
+type ReportBucket string
+
+const (
+	BucketDay   ReportBucket = "day"
+	BucketWeek  ReportBucket = "week"
+	BucketMonth ReportBucket = "month"
+)
+
+// IsValid reports whether the bucket is one of the supported period sizes
+func (b ReportBucket) IsValid() bool {
+	return b == BucketDay || b == BucketWeek || b == BucketMonth
+}
+
+// Truncate returns the start of the period containing t. Weeks start on Monday.
+func (b ReportBucket) Truncate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch b {
+	case BucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case BucketMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the period following the one that starts at t
+func (b ReportBucket) Next(t time.Time) time.Time {
+	switch b {
+	case BucketWeek:
+		return t.AddDate(0, 0, 7)
+	case BucketMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// StatusCount is the number of appointments in a status within a period
+type StatusCount struct {
+	Period string
+	Status AppointmentStatus
+	Count  int64
+}
+
+// PeriodRevenue is the amount earned with completed appointments within a period
+type PeriodRevenue struct {
+	Period  string
+	Revenue float64
+}
+
+// PeriodPerformance summarizes the appointments of a period. Rates are relative to the total of the period.
+type PeriodPerformance struct {
+	Period           string                      `json:"period"`
+	Total            int64                       `json:"total"`
+	ByStatus         map[AppointmentStatus]int64 `json:"by_status"`
+	CompletionRate   float64                     `json:"completion_rate"`
+	CancellationRate float64                     `json:"cancellation_rate"`
+	Revenue          float64                     `json:"revenue"`
+}
+
+// AddCount accumulates the appointments of a status and refreshes the rates
+func (p *PeriodPerformance) AddCount(status AppointmentStatus, count int64) {
+	if p.ByStatus == nil {
+		p.ByStatus = make(map[AppointmentStatus]int64)
+	}
+	p.ByStatus[status] += count
+	p.Total += count
+	p.CompletionRate = float64(p.ByStatus[StatusDone]) / float64(p.Total)
+	p.CancellationRate = float64(p.ByStatus[StatusCanceled]) / float64(p.Total)
+}
+
+// PerformanceReport is the performance of the salon over a date range, overall and per period
+type PerformanceReport struct {
+	Start   time.Time           `json:"start"`
+	End     time.Time           `json:"end"`
+	Bucket  ReportBucket        `json:"bucket"`
+	Totals  PeriodPerformance   `json:"totals"`
+	Periods []PeriodPerformance `json:"periods"`
+}
+
+// ServiceRanking is how often a service was booked and how much it earned
+type ServiceRanking struct {
+	ServiceID uint    `json:"service_id"`
+	Name      string  `json:"name"`
+	Bookings  int64   `json:"bookings"`
+	Revenue   float64 `json:"revenue"`
+}
+
+// HourLoad is how many appointments start at a given hour of the day
+type HourLoad struct {
+	Hour  int   `json:"hour"`
+	Total int64 `json:"total"`
+}
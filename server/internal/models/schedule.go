@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DateLayout is the format used for calendar days such as holidays
+	DateLayout = "2006-01-02"
+	// ClockLayout is the format used for opening and closing times
+	ClockLayout = "15:04"
+)
+
+var (
+	ErrInvalidBusinessHours = errors.New("horário de funcionamento inválido")
+	ErrDuplicateWeekday     = errors.New("dia da semana informado mais de uma vez")
+	ErrInvalidHolidayDate   = errors.New("a data do feriado deve usar o formato AAAA-MM-DD")
+)
+
+// BusinessHours is the opening schedule of a weekday
+type BusinessHours struct {
+	Weekday  time.Weekday `gorm:"primaryKey;autoIncrement:false" json:"weekday"`
+	IsOpen   bool         `json:"is_open"`
+	OpensAt  string       `json:"opens_at"`
+	ClosesAt string       `json:"closes_at"`
+}
+
+// DefaultBusinessHours is used for weekdays the admin never configured: Monday to Saturday, 09:00 to 18:00
+func DefaultBusinessHours(weekday time.Weekday) BusinessHours {
+	return BusinessHours{
+		Weekday:  weekday,
+		IsOpen:   weekday != time.Sunday,
+		OpensAt:  "09:00",
+		ClosesAt: "18:00",
+	}
+}
+
+// Validate checks that the opening time comes before the closing time
+func (b BusinessHours) Validate() error {
+	if b.Weekday < time.Sunday || b.Weekday > time.Saturday {
+		return ErrInvalidBusinessHours
+	}
+	if !b.IsOpen {
+		return nil
+	}
+	opens, err := time.Parse(ClockLayout, b.OpensAt)
+	if err != nil {
+		return ErrInvalidBusinessHours
+	}
+	closes, err := time.Parse(ClockLayout, b.ClosesAt)
+	if err != nil {
+		return ErrInvalidBusinessHours
+	}
+	if !opens.Before(closes) {
+		return ErrInvalidBusinessHours
+	}
+	return nil
+}
+
+// Window returns the opening and closing moments of the given day
+func (b BusinessHours) Window(day time.Time) (time.Time, time.Time, error) {
+	opens, err := time.Parse(ClockLayout, b.OpensAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidBusinessHours
+	}
+	closes, err := time.Parse(ClockLayout, b.ClosesAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidBusinessHours
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, opens.Hour(), opens.Minute(), 0, 0, day.Location()),
+		time.Date(y, m, d, closes.Hour(), closes.Minute(), 0, 0, day.Location()), nil
+}
+
+// Holiday is a calendar day on which the salon is closed
+type Holiday struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Date        string `gorm:"uniqueIndex" json:"date"`
+	Description string `json:"description"`
+}
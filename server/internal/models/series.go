@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("regra de recorrência inválida")
+	ErrSeriesUnavailable = errors.New("nenhuma das datas da série está disponível")
+	ErrNotInSeries       = errors.New("agendamento não faz parte de uma série")
+)
+
+// MaxSeriesOccurrences bounds how many appointments a single series may book
+const MaxSeriesOccurrences = 52
+
+type RecurrenceFrequency string
+
+const (
+	// FrequencyWeekly repeats on the same weekday every Interval weeks
+	FrequencyWeekly RecurrenceFrequency = "WEEKLY"
+	// FrequencyMonthlyWeekday repeats on the same weekday of the same week of the month every Interval months,
+	// as in every second Tuesday. Months without that day, such as a fifth Monday, are skipped.
+	FrequencyMonthlyWeekday RecurrenceFrequency = "MONTHLY_WEEKDAY"
+)
+
+// Recurrence describes when the appointments of a series happen. It ends after Count appointments
+// or on the Until day, whichever is set.
+type Recurrence struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	// Interval is how many weeks or months pass between two appointments, 1 when empty
+	Interval int `json:"interval"`
+	Count    int `json:"count,omitempty"`
+	// Until is the last day an appointment may fall on, in DateLayout
+	Until string `json:"until,omitempty"`
+}
+
+// Validate checks the frequency and that the series ends within MaxSeriesOccurrences
+func (r Recurrence) Validate() error {
+	if r.Frequency != FrequencyWeekly && r.Frequency != FrequencyMonthlyWeekday {
+		return ErrInvalidRecurrence
+	}
+	if r.Interval < 0 || r.Count < 0 || r.Count > MaxSeriesOccurrences {
+		return ErrInvalidRecurrence
+	}
+	if (r.Count == 0) == (r.Until == "") {
+		return ErrInvalidRecurrence
+	}
+	if r.Until != "" {
+		if _, err := time.Parse(DateLayout, r.Until); err != nil {
+			return ErrInvalidRecurrence
+		}
+	}
+	return nil
+}
+
+// Occurrences returns the dates of the series starting at start, which is always the first one
+func (r Recurrence) Occurrences(start time.Time) ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	interval := r.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	var until time.Time
+	if r.Until != "" {
+		day, _ := time.ParseInLocation(DateLayout, r.Until, start.Location())
+		until = day.AddDate(0, 0, 1)
+		if !start.Before(until) {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+
+	dates := []time.Time{start}
+	// Each step is tried once; steps landing on a missing day of the month produce no date
+	for step := 1; r.Count == 0 || len(dates) < r.Count; step++ {
+		next, ok := r.step(start, step*interval)
+		if !until.IsZero() && !next.Before(until) {
+			break
+		}
+		if !ok {
+			continue
+		}
+		if len(dates) == MaxSeriesOccurrences {
+			return nil, ErrInvalidRecurrence
+		}
+		dates = append(dates, next)
+	}
+	return dates, nil
+}
+
+// step returns the date n weeks or months after start, and false when that month has no such day
+func (r Recurrence) step(start time.Time, n int) (time.Time, bool) {
+	if r.Frequency == FrequencyWeekly {
+		return start.AddDate(0, 0, 7*n), true
+	}
+	week := (start.Day() - 1) / 7
+	first := time.Date(start.Year(), start.Month()+time.Month(n), 1, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	day := 1 + (int(start.Weekday())-int(first.Weekday())+7)%7 + 7*week
+	next := first.AddDate(0, 0, day-1)
+	return next, next.Month() == first.Month()
+}
+
+// AppointmentSeries links the appointments booked together by a recurrence rule
+type AppointmentSeries struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index" json:"user_id"`
+	Recurrence Recurrence `gorm:"embedded" json:"recurrence"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// SeriesConflict is a date of a series that could not be booked or changed, and why
+type SeriesConflict struct {
+	AppointmentID uint      `json:"appointment_id,omitempty"`
+	Date          time.Time `json:"date"`
+	Error         string    `json:"error"`
+}
+
+// SeriesResult lists the appointments a series operation booked or changed, and the ones it could not
+type SeriesResult struct {
+	Series       *AppointmentSeries `json:"series,omitempty"`
+	Appointments []Appointment      `json:"appointments"`
+	Conflicts    []SeriesConflict   `json:"conflicts"`
+}
@@ -1,8 +1,12 @@
 package models
 
+import "gorm.io/gorm"
+
 type Service struct {
 	ID              uint    `gorm:"primaryKey" json:"id"`
 	Name            string  `json:"name"`
 	Price           float64 `json:"price"`
 	DurationMinutes int     `json:"duration_minutes"`
+	// DeletedAt is set when the service is no longer offered, past appointments keep referencing it
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidSweeperRules = errors.New("regras de encerramento automático inválidas")
+
+// SweeperRules configure how appointments left open after their date are closed automatically.
+// There is a single set of rules for the salon.
+type SweeperRules struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+	// ExpirePending marks pending appointments as EXPIRED once they ended ExpireAfterMinutes ago
+	ExpirePending      bool `json:"expire_pending"`
+	ExpireAfterMinutes int  `json:"expire_after_minutes"`
+	// AutoComplete marks confirmed appointments as DONE once they ended CompleteAfterMinutes ago
+	AutoComplete         bool      `json:"auto_complete"`
+	CompleteAfterMinutes int       `json:"complete_after_minutes"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// DefaultSweeperRules are used while the admin has not configured any: pending appointments expire an hour
+// after they end and confirmed ones are left for the admin to close
+func DefaultSweeperRules() SweeperRules {
+	return SweeperRules{
+		ID:                   1,
+		ExpirePending:        true,
+		ExpireAfterMinutes:   60,
+		CompleteAfterMinutes: 120,
+	}
+}
+
+// Validate checks that the grace periods are not negative
+func (r SweeperRules) Validate() error {
+	if r.ExpireAfterMinutes < 0 || r.CompleteAfterMinutes < 0 {
+		return ErrInvalidSweeperRules
+	}
+	return nil
+}
+
+// NextStatus returns the status the appointment is moved to automatically at the given time, if any
+func (r SweeperRules) NextStatus(ap Appointment, now time.Time) (AppointmentStatus, bool) {
+	switch {
+	case ap.Status == StatusPending && r.ExpirePending:
+		return StatusExpired, !now.Before(ap.EndDate().Add(time.Duration(r.ExpireAfterMinutes) * time.Minute))
+	case ap.Status == StatusConfirmed && r.AutoComplete:
+		return StatusDone, !now.Before(ap.EndDate().Add(time.Duration(r.CompleteAfterMinutes) * time.Minute))
+	}
+	return "", false
+}
+
+// Statuses returns the statuses the rules close automatically
+func (r SweeperRules) Statuses() []AppointmentStatus {
+	var statuses []AppointmentStatus
+	if r.ExpirePending {
+		statuses = append(statuses, StatusPending)
+	}
+	if r.AutoComplete {
+		statuses = append(statuses, StatusConfirmed)
+	}
+	return statuses
+}
+
+// SweepResult counts the appointments closed by a sweep
+type SweepResult struct {
+	Expired   int `json:"expired"`
+	Completed int `json:"completed"`
+	// Skipped appointments were changed by someone else while the sweep ran
+	Skipped int `json:"skipped"`
+}
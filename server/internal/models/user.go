@@ -1,28 +1,69 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWrongPassword = errors.New("current password is incorrect")
+	ErrUserInactive  = errors.New("user account is inactive")
+	// ErrUserNotFound wraps gorm.ErrRecordNotFound, so callers may check for either
+	ErrUserNotFound = fmt.Errorf("user not found: %w", gorm.ErrRecordNotFound)
 )
 
 type UserRole string
 
 const (
-	RoleAdmin    UserRole = "admin"
-	RoleCustomer UserRole = "customer"
+	RoleAdmin        UserRole = "admin"
+	RoleManager      UserRole = "manager"
+	RoleReceptionist UserRole = "receptionist"
+	RoleProfessional UserRole = "professional"
+	RoleCustomer     UserRole = "customer"
 )
 
 type User struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Email     string    `gorm:"uniqueIndex" json:"email"`
-	Password  string    `json:"-"`
-	Role      UserRole  `json:"role"`
-	Name      string    `json:"name"`
-	Phone     string    `json:"phone"`
-	IsActive  bool      `json:"is_active" gorm:"default:true"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       uint     `gorm:"primaryKey" json:"id"`
+	Email    string   `gorm:"uniqueIndex" json:"email"`
+	Password string   `json:"-"`
+	Role     UserRole `json:"role"`
+	Name     string   `json:"name"`
+	Phone    string   `json:"phone"`
+	IsActive bool     `json:"is_active" gorm:"default:true"`
+	// EmailVerifiedAt is nil until the user opens the link sent to their email
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// MustChangePassword is set on accounts created with a well-known password, such as the seeded admin
+	MustChangePassword bool      `json:"must_change_password"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	// DeletedAt archives the user, past appointments keep referencing it
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ProfileUpdate holds the fields a user may change in their own profile
+type ProfileUpdate struct {
+	Name  string
+	Phone string
 }
 
 func (u User) TableName() string {
 	return "users"
 }
+
+// Anonymize erases the personal data of a closed account. The user row is kept so its appointments remain in the history.
+func (u *User) Anonymize() {
+	u.Email = fmt.Sprintf("deleted-%d@anonymized.invalid", u.ID)
+	u.Name = "Cliente removido"
+	u.Phone = ""
+	u.Password = ""
+	u.IsActive = false
+	u.EmailVerifiedAt = nil
+}
+
+// EmailVerified reports whether the user confirmed they own their email address
+func (u User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
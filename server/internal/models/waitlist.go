@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidWaitlistEntry = errors.New("período ou faixa de horário da lista de espera inválidos")
+	ErrWaitlistEntryClosed  = errors.New("você não está mais nesta lista de espera")
+	ErrWaitlistOfferClosed  = errors.New("esta oferta de horário não está mais disponível")
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting WaitlistStatus = "WAITING"
+	// WaitlistOffered is set while the customer holds an offer, so they get a single offer at a time
+	WaitlistOffered WaitlistStatus = "OFFERED"
+	WaitlistBooked  WaitlistStatus = "BOOKED"
+	WaitlistLeft    WaitlistStatus = "LEFT"
+)
+
+// WaitlistEntry is a customer waiting for a slot with the services within the days and hours they prefer
+type WaitlistEntry struct {
+	ID       uint      `gorm:"primaryKey" json:"id"`
+	UserID   uint      `gorm:"index" json:"user_id"`
+	User     User      `gorm:"foreignKey:UserID" json:"-"`
+	Services []Service `json:"services" gorm:"many2many:waitlist_entry_services;"`
+	// StartDate and EndDate are the first and last days the customer can come, in DateLayout
+	StartDate string `gorm:"index" json:"start_date"`
+	EndDate   string `gorm:"index" json:"end_date"`
+	// WindowStart and WindowEnd bound the hours of the day the appointment may take, in ClockLayout
+	WindowStart string         `json:"window_start"`
+	WindowEnd   string         `json:"window_end"`
+	Status      WaitlistStatus `gorm:"index" json:"status"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// Validate checks that the period and the window are well formed and not reversed
+func (e WaitlistEntry) Validate() error {
+	if len(e.Services) == 0 {
+		return ErrAppointmentNoServices
+	}
+	start, err := time.Parse(DateLayout, e.StartDate)
+	if err != nil {
+		return ErrInvalidWaitlistEntry
+	}
+	end, err := time.Parse(DateLayout, e.EndDate)
+	if err != nil || end.Before(start) {
+		return ErrInvalidWaitlistEntry
+	}
+	from, err := time.Parse(ClockLayout, e.WindowStart)
+	if err != nil {
+		return ErrInvalidWaitlistEntry
+	}
+	to, err := time.Parse(ClockLayout, e.WindowEnd)
+	if err != nil || !from.Before(to) {
+		return ErrInvalidWaitlistEntry
+	}
+	return nil
+}
+
+// Accepts reports whether an appointment in [start, start+duration) falls within the days and hours of the entry
+func (e WaitlistEntry) Accepts(start time.Time, duration time.Duration) bool {
+	start = start.In(time.Local)
+	day := start.Format(DateLayout)
+	if day < e.StartDate || day > e.EndDate {
+		return false
+	}
+	end := start.Add(duration)
+	if end.Format(DateLayout) != day {
+		return false
+	}
+	return start.Format(ClockLayout) >= e.WindowStart && end.Format(ClockLayout) <= e.WindowEnd
+}
+
+type WaitlistOfferStatus string
+
+const (
+	OfferPending  WaitlistOfferStatus = "PENDING"
+	OfferAccepted WaitlistOfferStatus = "ACCEPTED"
+	OfferDeclined WaitlistOfferStatus = "DECLINED"
+	OfferExpired  WaitlistOfferStatus = "EXPIRED"
+)
+
+// WaitlistOffer holds a slot freed by a cancellation for a waitlisted customer until it expires.
+// While pending, nobody else can book the slot.
+type WaitlistOffer struct {
+	ID      uint          `gorm:"primaryKey" json:"id"`
+	EntryID uint          `gorm:"index" json:"entry_id"`
+	Entry   WaitlistEntry `gorm:"foreignKey:EntryID" json:"entry"`
+	UserID  uint          `gorm:"index" json:"user_id"`
+	// SourceAppointmentID is the canceled appointment that freed the slot
+	SourceAppointmentID uint                `gorm:"index" json:"source_appointment_id"`
+	Date                time.Time           `gorm:"index" json:"date"`
+	DurationMinutes     int                 `json:"duration_minutes"`
+	ProfessionalID      *uint               `json:"professional_id,omitempty"`
+	Status              WaitlistOfferStatus `gorm:"index" json:"status"`
+	ExpiresAt           time.Time           `gorm:"index" json:"expires_at"`
+	// AppointmentID is the appointment booked when the customer accepted the offer
+	AppointmentID *uint     `json:"appointment_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Hold returns the slot held by the offer as a booking, so it counts as taken when looking for free slots
+func (o WaitlistOffer) Hold() Appointment {
+	return Appointment{
+		Date:           o.Date,
+		Status:         StatusPending,
+		ProfessionalID: o.ProfessionalID,
+		Items:          []AppointmentItem{{DurationMinutes: o.DurationMinutes}},
+	}
+}
@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+// AppointmentNotifier tells customers about changes to their appointments
+type AppointmentNotifier interface {
+	// Notify queues the message about the event and returns without waiting for it to be delivered
+	Notify(event Event, ap models.Appointment)
+}
+
+// Multi forwards every event to all the given notifiers
+func Multi(notifiers ...AppointmentNotifier) AppointmentNotifier {
+	return multiNotifier(notifiers)
+}
+
+type multiNotifier []AppointmentNotifier
+
+func (m multiNotifier) Notify(event Event, ap models.Appointment) {
+	for _, n := range m {
+		n.Notify(event, ap)
+	}
+}
+
+// DefaultQueueSize is how many messages may wait for delivery before new ones are dropped
+const DefaultQueueSize = 100
+
+// sendTimeout bounds how long a single delivery may take
+const sendTimeout = 30 * time.Second
+
+var (
+	ErrDispatcherClosed = errors.New("notification: dispatcher closed")
+	ErrQueueFull        = errors.New("notification: queue full")
+)
+
+// job is an appointment message to render, or a message that is already rendered
+type job struct {
+	event Event
+	ap    models.Appointment
+	msg   *Message
+}
+
+// Dispatcher renders and delivers appointment messages in a background goroutine,
+// so a slow mail server never delays the request that triggered the message
+type Dispatcher struct {
+	notifier Notifier
+	renderer *Renderer
+	queue    chan job
+	wg       sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewDispatcher(notifier Notifier, renderer *Renderer, queueSize int) *Dispatcher {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	d := &Dispatcher{
+		notifier: notifier,
+		renderer: renderer,
+		queue:    make(chan job, queueSize),
+	}
+	d.wg.Add(1)
+	go d.run()
+	return d
+}
+
+func (d *Dispatcher) Notify(event Event, ap models.Appointment) {
+	if ap.User.Email == "" {
+		log.Printf("notification: appointment %d has no customer email, %s message not sent", ap.ID, event)
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return
+	}
+	select {
+	case d.queue <- job{event: event, ap: ap}:
+	default:
+		log.Printf("notification: queue full, %s message for appointment %d dropped", event, ap.ID)
+	}
+}
+
+// Send queues a message that is already rendered, such as the account emails, and returns without waiting
+// for it to be delivered. Delivery failures are only logged.
+func (d *Dispatcher) Send(_ context.Context, msg Message) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+	select {
+	case d.queue <- job{msg: &msg}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones to be delivered
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+	for j := range d.queue {
+		d.deliver(j)
+	}
+}
+
+func (d *Dispatcher) deliver(j job) {
+	msg := j.msg
+	if msg == nil {
+		rendered, err := d.renderer.Render(j.event, j.ap)
+		if err != nil {
+			log.Printf("notification: rendering %s message for appointment %d: %v", j.event, j.ap.ID, err)
+			return
+		}
+		msg = &rendered
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := d.notifier.Send(ctx, *msg); err != nil {
+		log.Printf("notification: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier keeps the messages it was asked to send
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+	block    chan struct{}
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, msg Message) error {
+	if n.block != nil {
+		<-n.block
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+func TestDispatcher_DeliversInBackground(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+	transport := &recordingNotifier{block: make(chan struct{})}
+	dispatcher := NewDispatcher(transport, renderer, 10)
+
+	// Notify returns while the transport is still blocked
+	dispatcher.Notify(EventBooked, testAppointment())
+	dispatcher.Notify(EventCanceled, testAppointment())
+	close(transport.block)
+	dispatcher.Close()
+
+	require.Len(t, transport.messages, 2)
+	assert.True(t, strings.HasPrefix(transport.messages[0].Subject, "Agendamento recebido"))
+	assert.True(t, strings.HasPrefix(transport.messages[1].Subject, "Agendamento cancelado"))
+
+	// Messages after Close are ignored
+	dispatcher.Notify(EventBooked, testAppointment())
+	assert.Len(t, transport.messages, 2)
+}
+
+func TestDispatcher_SendQueuesRenderedMessages(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+	transport := &recordingNotifier{block: make(chan struct{})}
+	dispatcher := NewDispatcher(transport, renderer, 1)
+
+	// Send returns while the transport is still blocked, and fails once the queue is full
+	msg := Message{To: "maria@example.com", Subject: "Redefinição de senha", Body: "link"}
+	require.NoError(t, dispatcher.Send(context.Background(), msg))
+	for dispatcher.Send(context.Background(), msg) == nil {
+	}
+	assert.ErrorIs(t, dispatcher.Send(context.Background(), msg), ErrQueueFull)
+	close(transport.block)
+	dispatcher.Close()
+
+	require.NotEmpty(t, transport.messages)
+	assert.Equal(t, msg, transport.messages[0])
+	assert.ErrorIs(t, dispatcher.Send(context.Background(), msg), ErrDispatcherClosed)
+}
+
+func TestDispatcher_SkipsMissingEmail(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+	transport := &recordingNotifier{}
+	dispatcher := NewDispatcher(transport, renderer, 10)
+
+	ap := testAppointment()
+	ap.User.Email = ""
+	dispatcher.Notify(EventBooked, ap)
+	dispatcher.Close()
+
+	assert.Empty(t, transport.messages)
+}
+
+func TestLogNotifier_Send(t *testing.T) {
+	var out bytes.Buffer
+	notifier := NewLogNotifier(&out)
+
+	err := notifier.Send(context.Background(), Message{To: "maria@example.com", Subject: "Olá", Body: "Corpo"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "To: maria@example.com")
+	assert.Contains(t, out.String(), "Subject: Olá")
+	assert.Contains(t, out.String(), "Corpo")
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	var gotAddr string
+	var gotTo []string
+	var gotMsg []byte
+	notifier := &smtpNotifier{
+		config: SMTPConfig{Host: "smtp.example.com", Port: "587", From: "salao@example.com"},
+		send: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr, gotTo, gotMsg = addr, to, msg
+			return nil
+		},
+	}
+
+	err := notifier.Send(context.Background(), Message{To: "maria@example.com", Subject: "Olá", Body: "linha 1\nlinha 2"})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, []string{"maria@example.com"}, gotTo)
+	assert.Contains(t, string(gotMsg), "Subject: Olá\r\n")
+	assert.Contains(t, string(gotMsg), "charset=\"UTF-8\"")
+	assert.True(t, strings.HasSuffix(string(gotMsg), "\r\n\r\nlinha 1\r\nlinha 2"))
+
+	notifier.send = func(string, smtp.Auth, string, []string, []byte) error { return errors.New("connection refused") }
+	assert.Error(t, notifier.Send(context.Background(), Message{To: "maria@example.com"}))
+}
@@ -0,0 +1,15 @@
+package notification
+
+import "context"
+
+// Message is an email ready to be delivered
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages through a transport such as SMTP
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
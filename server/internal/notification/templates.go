@@ -0,0 +1,189 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+// Event identifies which message is sent about an appointment
+type Event string
+
+const (
+	EventBooked      Event = "booked"
+	EventConfirmed   Event = "confirmed"
+	EventRescheduled Event = "rescheduled"
+	EventCanceled    Event = "canceled"
+	EventReminder    Event = "reminder"
+	// EventWaitlistOffer offers a freed slot to a waitlisted customer, see RenderOffer
+	EventWaitlistOffer Event = "waitlist_offer"
+
+	// Account events are about the user rather than an appointment, see RenderAccount
+	EventPasswordReset     Event = "password_reset"
+	EventEmailVerification Event = "email_verification"
+)
+
+// DefaultLocale is used when no templates exist for the requested one
+const DefaultLocale = "pt-BR"
+
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer turns an appointment event into the message sent to the customer
+type Renderer struct {
+	templates map[Event]*template.Template
+}
+
+// NewRenderer loads the templates of the locale, one file per event
+func NewRenderer(locale string) (*Renderer, error) {
+	if _, err := templateFS.ReadDir("templates/" + locale); err != nil {
+		locale = DefaultLocale
+	}
+	r := &Renderer{templates: make(map[Event]*template.Template)}
+	for _, event := range []Event{EventBooked, EventConfirmed, EventRescheduled, EventCanceled, EventReminder, EventWaitlistOffer, EventPasswordReset, EventEmailVerification} {
+		tmpl, err := template.ParseFS(templateFS, fmt.Sprintf("templates/%s/%s.tmpl", locale, event))
+		if err != nil {
+			return nil, err
+		}
+		r.templates[event] = tmpl
+	}
+	return r, nil
+}
+
+// ServiceLine is a booked service as shown in a message
+type ServiceLine struct {
+	Name  string
+	Price string
+}
+
+// TemplateData is what the templates can show about an appointment
+type TemplateData struct {
+	CustomerName string
+	Date         string
+	Services     []ServiceLine
+	Total        string
+	Professional string
+}
+
+func NewTemplateData(ap models.Appointment) TemplateData {
+	data := TemplateData{
+		CustomerName: ap.User.Name,
+		Date:         ap.Date.Format("02/01/2006 às 15:04"),
+		Total:        formatPrice(ap.TotalPrice()),
+	}
+	if len(ap.Items) > 0 {
+		for _, item := range ap.Items {
+			data.Services = append(data.Services, ServiceLine{Name: item.Name, Price: formatPrice(item.Price)})
+		}
+	} else {
+		for _, s := range ap.Services {
+			data.Services = append(data.Services, ServiceLine{Name: s.Name, Price: formatPrice(s.Price)})
+		}
+	}
+	if ap.Professional != nil {
+		data.Professional = ap.Professional.Name
+	}
+	return data
+}
+
+// Render builds the message about the event for the customer of the appointment
+func (r *Renderer) Render(event Event, ap models.Appointment) (Message, error) {
+	tmpl, ok := r.templates[event]
+	if !ok {
+		return Message{}, fmt.Errorf("no template for event %q", event)
+	}
+	data := NewTemplateData(ap)
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{To: ap.User.Email, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// OfferTemplateData is what the waitlist offer template can show
+type OfferTemplateData struct {
+	TemplateData
+	ExpiresAt string
+}
+
+// RenderOffer builds the message offering the slot of the appointment to its customer, who may accept it until expiresAt
+func (r *Renderer) RenderOffer(ap models.Appointment, expiresAt time.Time) (Message, error) {
+	tmpl, ok := r.templates[EventWaitlistOffer]
+	if !ok {
+		return Message{}, fmt.Errorf("no template for event %q", EventWaitlistOffer)
+	}
+	data := OfferTemplateData{TemplateData: NewTemplateData(ap), ExpiresAt: expiresAt.Format("02/01/2006 às 15:04")}
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{To: ap.User.Email, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// AccountTemplateData is what the account templates can show
+type AccountTemplateData struct {
+	CustomerName string
+	Link         string
+	ExpiresIn    string
+}
+
+// RenderAccount builds the message carrying the link of an account event, such as a password reset
+func (r *Renderer) RenderAccount(event Event, user models.User, link string, expiresIn time.Duration) (Message, error) {
+	tmpl, ok := r.templates[event]
+	if !ok {
+		return Message{}, fmt.Errorf("no template for event %q", event)
+	}
+	data := AccountTemplateData{CustomerName: user.Name, Link: link, ExpiresIn: formatDuration(expiresIn)}
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+	return Message{To: user.Email, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// formatDuration writes how long a link lasts, as in 2 horas or 30 minutos
+func formatDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if hours := int(d / time.Hour); hours > 1 {
+			return fmt.Sprintf("%d horas", hours)
+		}
+		return "1 hora"
+	}
+	if minutes := int(d / time.Minute); minutes != 1 {
+		return fmt.Sprintf("%d minutos", minutes)
+	}
+	return "1 minuto"
+}
+
+// formatPrice writes the amount in Brazilian reais, as in R$ 1.234,50
+func formatPrice(amount float64) string {
+	s := fmt.Sprintf("%.2f", amount)
+	integer, cents := s[:len(s)-3], s[len(s)-2:]
+	negative := strings.HasPrefix(integer, "-")
+	integer = strings.TrimPrefix(integer, "-")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	if negative {
+		return "-R$ " + grouped.String() + "," + cents
+	}
+	return "R$ " + grouped.String() + "," + cents
+}
@@ -0,0 +1,93 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAppointment() models.Appointment {
+	return models.Appointment{
+		ID:   7,
+		User: models.User{Name: "Maria", Email: "maria@example.com"},
+		Date: time.Date(2026, 3, 14, 15, 30, 0, 0, time.Local),
+		Items: []models.AppointmentItem{
+			{Name: "Corte", Price: 50, DurationMinutes: 30},
+			{Name: "Coloração", Price: 1200, DurationMinutes: 90},
+		},
+		Professional: &models.Professional{Name: "Leila"},
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+
+	msg, err := renderer.Render(EventConfirmed, testAppointment())
+	require.NoError(t, err)
+	assert.Equal(t, "maria@example.com", msg.To)
+	assert.Equal(t, "Agendamento confirmado - 14/03/2026 às 15:30", msg.Subject)
+	assert.Contains(t, msg.Body, "Olá, Maria!")
+	assert.Contains(t, msg.Body, "- Corte (R$ 50,00)")
+	assert.Contains(t, msg.Body, "- Coloração (R$ 1.200,00)")
+	assert.Contains(t, msg.Body, "Total: R$ 1.250,00")
+	assert.Contains(t, msg.Body, "Profissional: Leila")
+}
+
+func TestRenderer_AllEvents(t *testing.T) {
+	renderer, err := NewRenderer("")
+	require.NoError(t, err, "an unknown locale falls back to the default one")
+
+	for _, event := range []Event{EventBooked, EventConfirmed, EventRescheduled, EventCanceled, EventReminder} {
+		msg, err := renderer.Render(event, testAppointment())
+		require.NoError(t, err, event)
+		assert.NotEmpty(t, msg.Subject, event)
+		assert.NotContains(t, msg.Body, "<no value>", event)
+	}
+
+	_, err = renderer.Render(Event("unknown"), testAppointment())
+	assert.Error(t, err)
+}
+
+func TestRenderer_RenderAccount(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+	user := models.User{Name: "Maria", Email: "maria@example.com"}
+
+	msg, err := renderer.RenderAccount(EventPasswordReset, user, "http://localhost:5173/reset-password?token=abc", 2*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "maria@example.com", msg.To)
+	assert.Equal(t, "Redefinição de senha", msg.Subject)
+	assert.Contains(t, msg.Body, "Olá, Maria!")
+	assert.Contains(t, msg.Body, "http://localhost:5173/reset-password?token=abc")
+	assert.Contains(t, msg.Body, "2 horas")
+
+	msg, err = renderer.RenderAccount(EventEmailVerification, user, "http://localhost:5173/verify-email?token=abc", time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "http://localhost:5173/verify-email?token=abc")
+	assert.Contains(t, msg.Body, "1 hora.")
+}
+
+func TestRenderer_RenderOffer(t *testing.T) {
+	renderer, err := NewRenderer(DefaultLocale)
+	require.NoError(t, err)
+
+	msg, err := renderer.RenderOffer(testAppointment(), time.Date(2026, 3, 13, 18, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+	assert.Equal(t, "maria@example.com", msg.To)
+	assert.Equal(t, "Horário disponível - 14/03/2026 às 15:30", msg.Subject)
+	assert.Contains(t, msg.Body, "- Corte (R$ 50,00)")
+	assert.Contains(t, msg.Body, "até 13/03/2026 às 18:00")
+	assert.NotContains(t, msg.Body, "<no value>")
+}
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "R$ 0,00", formatPrice(0))
+	assert.Equal(t, "R$ 49,90", formatPrice(49.9))
+	assert.Equal(t, "R$ 999,00", formatPrice(999))
+	assert.Equal(t, "R$ 1.234.567,89", formatPrice(1234567.89))
+	assert.Equal(t, "-R$ 10,00", formatPrice(-10))
+}
@@ -0,0 +1,116 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8080/api/auth/oidc/mock/callback"
+
+// authorize follows the provider login page and returns the code sent back to the redirect URL
+func authorize(t *testing.T, provider *oidc.Provider, state, nonce, verifier string) string {
+	authURL, err := provider.AuthCodeURL(context.Background(), state, nonce, verifier)
+	require.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, state, location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func newProvider(t *testing.T) (*oidctest.Server, *oidc.Provider) {
+	server, err := oidctest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	server.SetUser(oidctest.User{Subject: "123", Email: "Maria@Example.com", EmailVerified: true, Name: "Maria"})
+	return server, oidc.NewProvider(server.Config("mock", redirectURL), nil)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	_, provider := newProvider(t)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	assert.Equal(t, "/authorize", parsed.Path)
+	assert.Equal(t, oidctest.ClientID, query.Get("client_id"))
+	assert.Equal(t, redirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, oidc.Challenge("verifier"), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	_, provider := newProvider(t)
+
+	code := authorize(t, provider, "state", "nonce", "verifier")
+	claims, err := provider.Exchange(context.Background(), code, "verifier", "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, oidc.Claims{Subject: "123", Email: "maria@example.com", EmailVerified: true, Name: "Maria"}, claims)
+
+	// Codes can only be used once
+	_, err = provider.Exchange(context.Background(), code, "verifier", "nonce")
+	assert.Error(t, err)
+}
+
+func TestProvider_Exchange_WrongVerifier(t *testing.T) {
+	_, provider := newProvider(t)
+
+	code := authorize(t, provider, "state", "nonce", "verifier")
+	_, err := provider.Exchange(context.Background(), code, "other-verifier", "nonce")
+	assert.Error(t, err)
+}
+
+func TestProvider_Exchange_InvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		nonce  string
+		tamper func(claims jwt.MapClaims)
+	}{
+		{"nonce mismatch", "other-nonce", nil},
+		{"other audience", "nonce", func(claims jwt.MapClaims) { claims["aud"] = "other-client" }},
+		{"other issuer", "nonce", func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }},
+		{"expired", "nonce", func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"missing expiration", "nonce", func(claims jwt.MapClaims) { delete(claims, "exp") }},
+		{"missing subject", "nonce", func(claims jwt.MapClaims) { delete(claims, "sub") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, provider := newProvider(t)
+			server.Tamper = tt.tamper
+
+			code := authorize(t, provider, "state", "nonce", "verifier")
+			_, err := provider.Exchange(context.Background(), code, "verifier", tt.nonce)
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestProvider_UnverifiedEmailAsString(t *testing.T) {
+	server, provider := newProvider(t)
+	server.Tamper = func(claims jwt.MapClaims) { claims["email_verified"] = "false" }
+
+	code := authorize(t, provider, "state", "nonce", "verifier")
+	claims, err := provider.Exchange(context.Background(), code, "verifier", "nonce")
+	require.NoError(t, err)
+	assert.False(t, claims.EmailVerified)
+}
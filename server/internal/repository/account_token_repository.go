@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type AccountTokenRepository interface {
+	Create(token models.AccountToken) (models.AccountToken, error)
+	FindByHash(purpose models.AccountTokenPurpose, hash string) (models.AccountToken, error)
+	// MarkUsed consumes the token. It returns false when the token was already used.
+	MarkUsed(id uint, at time.Time) (bool, error)
+	// InvalidateUser consumes every pending token of the user for the purpose
+	InvalidateUser(userID uint, purpose models.AccountTokenPurpose, at time.Time) error
+	DeleteExpired(before time.Time) error
+}
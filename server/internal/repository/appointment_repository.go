@@ -8,10 +8,34 @@ import (
 
 type AppointmentRepository interface {
 	Create(ap models.Appointment) (models.Appointment, error)
+	// CreateIfFree creates the appointment once check, given the repository of the same transaction, accepts it.
+	// Bookings are serialized, so the appointments check sees include those of every booking made before.
+	CreateIfFree(ap models.Appointment, check func(repo AppointmentRepository, ap *models.Appointment) error) (models.Appointment, error)
 	Update(ap models.Appointment) error
+	// UpdateIfFree saves the appointment like Update once check accepts it, serialized with the bookings like
+	// CreateIfFree. It returns the appointment as check left it. Writes check makes through the repository it is
+	// given are part of the same transaction.
+	UpdateIfFree(ap models.Appointment, check func(repo AppointmentRepository, ap *models.Appointment) error) (models.Appointment, error)
 	FindByID(id uint) (models.Appointment, error)
 	FindUserAppointmentsInWeek(userID uint, weekStart, weekEnd time.Time) ([]models.Appointment, error)
-	ListByPeriod(start, end time.Time) ([]models.Appointment, error)
-	ListByPeriodAndUser(userID uint, start, end time.Time) ([]models.Appointment, error)
-	ListAll() ([]models.Appointment, error)
+	// ListByPeriod, ListByPeriodAndUser, ListByProfessionalAndPeriod and ListAll return pages in date order
+	ListByPeriod(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	ListByPeriodAndUser(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	ListActiveByPeriod(start, end time.Time) ([]models.Appointment, error)
+	ListByProfessionalAndPeriod(professionalID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	// ListStartedBefore returns the appointments in one of the statuses that started before the given time
+	ListStartedBefore(statuses []models.AppointmentStatus, before time.Time) ([]models.Appointment, error)
+	// ListUpcomingByUser returns the appointments of the user in one of the statuses that start after the given time,
+	// with their details
+	ListUpcomingByUser(userID uint, statuses []models.AppointmentStatus, after time.Time) ([]models.Appointment, error)
+	ListAll(page models.PageRequest) (models.AppointmentPage, error)
+	// Search returns a page of the appointments matching the query, failing with models.ErrInvalidCursor
+	// when the cursor was not issued by a previous search
+	Search(query models.AppointmentQuery) (models.AppointmentPage, error)
+	// ChangeStatus records the fee incurred by the change, when given, in the same transaction
+	ChangeStatus(id uint, from, to models.AppointmentStatus, changedBy *uint, fee *models.CustomerFee) error
+	ListStatusHistory(appointmentID uint) ([]models.AppointmentStatusHistory, error)
+	CreateSeries(series models.AppointmentSeries) (models.AppointmentSeries, error)
+	// ListSeries returns the appointments of the series starting at or after the given time, in date order
+	ListSeries(seriesID uint, from time.Time) ([]models.Appointment, error)
 }
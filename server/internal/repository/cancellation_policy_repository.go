@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+
+type CancellationPolicyRepository interface {
+	Get() (models.CancellationPolicy, error)
+	Save(policy models.CancellationPolicy) (models.CancellationPolicy, error)
+}
+
+type FeeRepository interface {
+	Create(fee models.CustomerFee) (models.CustomerFee, error)
+	ListByUser(userID uint) ([]models.CustomerFee, error)
+}
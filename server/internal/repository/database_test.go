@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/database"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// The repository tests run against SQLite, or against PostgreSQL when TEST_DB_DRIVER is postgres. The PostgreSQL
+// server is the one of TEST_POSTGRES_DSN or, when it is not set, an ephemeral one started from the initdb and pg_ctl
+// binaries or in a docker container. The tests are skipped when none is available.
+var testPostgres struct {
+	once sync.Once
+	dsn  string
+	err  error
+	stop func()
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testPostgres.stop != nil {
+		testPostgres.stop()
+	}
+	os.Exit(code)
+}
+
+// openTestDB opens an empty database of the driver under test
+func openTestDB(t *testing.T) *gorm.DB {
+	driver := os.Getenv("TEST_DB_DRIVER")
+	if driver == "" || driver == database.DriverSQLite {
+		dsn := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", time.Now().UnixNano())
+		db, err := database.Open(database.Config{Driver: database.DriverSQLite, DSN: dsn})
+		require.NoError(t, err, "failed to create in-memory database")
+		return db
+	}
+	require.Equal(t, database.DriverPostgres, driver, "unknown TEST_DB_DRIVER")
+
+	testPostgres.once.Do(func() {
+		testPostgres.dsn, testPostgres.stop, testPostgres.err = startPostgres()
+	})
+	if testPostgres.err != nil {
+		t.Skipf("PostgreSQL is not available: %v", testPostgres.err)
+	}
+
+	// Each test gets its own schema, dropped when it ends
+	admin, err := database.Open(database.Config{Driver: database.DriverPostgres, DSN: testPostgres.dsn})
+	require.NoError(t, err)
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+
+	db, err := database.Open(database.Config{Driver: database.DriverPostgres, DSN: withSearchPath(testPostgres.dsn, schema)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// withSearchPath makes the connections of the DSN use the schema
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err == nil {
+			query := u.Query()
+			query.Set("search_path", schema)
+			u.RawQuery = query.Encode()
+			return u.String()
+		}
+	}
+	return dsn + " search_path=" + schema
+}
+
+// startPostgres returns the DSN of a PostgreSQL server for the tests and how to stop it
+func startPostgres() (string, func(), error) {
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		return dsn, nil, waitForPostgres(dsn)
+	}
+	var errs []error
+	if _, err := exec.LookPath("pg_ctl"); err == nil {
+		dsn, stop, err := startLocalPostgres()
+		if err == nil {
+			return dsn, stop, nil
+		}
+		errs = append(errs, err)
+	}
+	if _, err := exec.LookPath("docker"); err == nil {
+		dsn, stop, err := startPostgresContainer()
+		if err == nil {
+			return dsn, stop, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", nil, errors.New("set TEST_POSTGRES_DSN, or install PostgreSQL or docker")
+	}
+	return "", nil, errors.Join(errs...)
+}
+
+// startLocalPostgres runs a throwaway cluster in a temporary directory
+func startLocalPostgres() (string, func(), error) {
+	dir, err := os.MkdirTemp("", "repository-postgres")
+	if err != nil {
+		return "", nil, err
+	}
+	data := filepath.Join(dir, "data")
+	if out, err := exec.Command("initdb", "-D", data, "-U", "postgres", "-A", "trust", "--no-sync").CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("initdb: %v: %s", err, out)
+	}
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=localhost -F", port, dir)
+	if out, err := exec.Command("pg_ctl", "-D", data, "-l", filepath.Join(dir, "log"), "-o", options, "-w", "start").CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("pg_ctl start: %v: %s", err, out)
+	}
+	stop := func() {
+		exec.Command("pg_ctl", "-D", data, "-m", "immediate", "stop").Run()
+		os.RemoveAll(dir)
+	}
+	dsn := fmt.Sprintf("host=localhost port=%d user=postgres dbname=postgres sslmode=disable", port)
+	if err := waitForPostgres(dsn); err != nil {
+		stop()
+		return "", nil, err
+	}
+	return dsn, stop, nil
+}
+
+// startPostgresContainer runs a throwaway server in docker, published on a random local port
+func startPostgresContainer() (string, func(), error) {
+	out, err := exec.Command("docker", "run", "--rm", "-d", "-e", "POSTGRES_HOST_AUTH_METHOD=trust",
+		"-p", "127.0.0.1::5432", "postgres:16-alpine").Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("docker run: %w", err)
+	}
+	id := strings.TrimSpace(string(out))
+	stop := func() {
+		exec.Command("docker", "stop", id).Run()
+	}
+	out, err = exec.Command("docker", "port", id, "5432/tcp").Output()
+	if err != nil {
+		stop()
+		return "", nil, fmt.Errorf("docker port: %w", err)
+	}
+	// The container may publish the port on several addresses, one per line
+	address := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		stop()
+		return "", nil, err
+	}
+	dsn := fmt.Sprintf("host=%s port=%s user=postgres dbname=postgres sslmode=disable", host, port)
+	if err := waitForPostgres(dsn); err != nil {
+		stop()
+		return "", nil, err
+	}
+	return dsn, stop, nil
+}
+
+// waitForPostgres waits for the server to accept queries, a new container restarts once while initializing
+func waitForPostgres(dsn string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for {
+		err := pingPostgres(ctx, dsn)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for PostgreSQL: %w", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func pingPostgres(ctx context.Context, dsn string) error {
+	db, err := database.Open(database.Config{Driver: database.DriverPostgres, DSN: dsn})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	return sqlDB.PingContext(ctx)
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type IdentityRepository interface {
+	Create(identity models.UserIdentity) (models.UserIdentity, error)
+	FindBySubject(provider, subject string) (models.UserIdentity, error)
+	ListByUser(userID uint) ([]models.UserIdentity, error)
+	CreateState(state models.LoginState) error
+	// ConsumeState deletes the state and returns it, so it cannot be used twice
+	ConsumeState(hash string) (models.LoginState, error)
+	DeleteExpiredStates(before time.Time) error
+}
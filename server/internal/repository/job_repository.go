@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type JobRepository interface {
+	Create(job models.Job) (models.Job, error)
+	// ListDue returns the pending jobs whose time has come, oldest first
+	ListDue(now time.Time, limit int) ([]models.Job, error)
+	Update(job models.Job) error
+	// CancelPending drops the pending jobs of the given kind planned for the appointment
+	CancelPending(kind string, appointmentID uint) error
+	ListByAppointment(appointmentID uint) ([]models.Job, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type LoginThrottleRepository interface {
+	// Find returns an empty throttle with the key when the key has no failures
+	Find(key string) (models.LoginThrottle, error)
+	Save(throttle models.LoginThrottle) (models.LoginThrottle, error)
+	// Modify applies the change to the throttle of the key, empty when the key has no failures, and saves it.
+	// The row is held from the read to the write, so concurrent changes of a key are applied one after the other.
+	Modify(key string, change func(throttle *models.LoginThrottle)) (models.LoginThrottle, error)
+	Delete(key string) error
+	// DeleteStale removes the throttles whose last failure and lock ended before the given time
+	DeleteStale(before time.Time) error
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+// pageCursor is the position of the last row of a page in the list order: its sort key, when the list is not
+// ordered by ID alone, and its ID, which breaks ties. Clients receive it encoded and send it back unchanged.
+type pageCursor struct {
+	Key *time.Time `json:"k,omitempty"`
+	ID  uint       `json:"id"`
+}
+
+func encodeCursor(cursor pageCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor returns nil for the first page
+func decodeCursor(value string) (*pageCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, models.ErrInvalidCursor
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == 0 {
+		return nil, models.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// keyset orders the rows by the column, then by ID, and skips the ones up to the cursor. An empty column orders by ID alone.
+// The column must not come from user input.
+func keyset(table, column string, desc bool, after *pageCursor) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		direction, op := "ASC", ">"
+		if desc {
+			direction, op = "DESC", "<"
+		}
+		id := table + ".id"
+		if column == "" {
+			db = db.Order(fmt.Sprintf("%s %s", id, direction))
+		} else {
+			column = table + "." + column
+			db = db.Order(fmt.Sprintf("%s %s, %s %s", column, direction, id, direction))
+		}
+		if after == nil {
+			return db
+		}
+		if column == "" || after.Key == nil {
+			return db.Where(fmt.Sprintf("%s %s ?", id, op), after.ID)
+		}
+		// The stored dates are in the local zone, see sqlAppointmentRepo.Create
+		key := after.Key.In(time.Local)
+		return db.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column, op, column, id, op), key, key, after.ID)
+	}
+}
+
+// byID is the cursor of the lists ordered by ID alone
+func byID(id uint) pageCursor {
+	return pageCursor{ID: id}
+}
+
+// readPage reads a page of the rows selected by the filters, ordered by the column of the table as keyset does,
+// and counts the selected rows when the page asks for the total. The details are only loaded for the rows of the page.
+func readPage[T any](db *gorm.DB, filters, details func(*gorm.DB) *gorm.DB, table, column string, desc bool, page models.PageRequest, cursorOf func(T) pageCursor) ([]T, models.PageInfo, error) {
+	var info models.PageInfo
+	after, err := decodeCursor(page.Cursor)
+	if err != nil {
+		return nil, info, err
+	}
+	if page.WithTotal {
+		var total int64
+		if err := db.Model(new(T)).Scopes(filters).Count(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
+	}
+
+	limit := page.Size()
+	// One more row than the page tells whether another page follows
+	rows := []T{}
+	if err := db.Scopes(details, filters, keyset(table, column, desc, after)).Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, info, err
+	}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		info.NextCursor = encodeCursor(cursorOf(rows[limit-1]))
+	}
+	return rows, info, nil
+}
+
+// noDetails is the details scope of the rows without relations to load
+func noDetails(db *gorm.DB) *gorm.DB {
+	return db
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type ProfessionalRepository interface {
+	Create(professional models.Professional) (models.Professional, error)
+	FindByID(id uint) (models.Professional, error)
+	FindByUserID(userID uint) (models.Professional, error)
+	FindAll() ([]models.Professional, error)
+	FindActive() ([]models.Professional, error)
+	Update(professional models.Professional) error
+	Delete(id uint) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type RefreshTokenRepository interface {
+	Create(token models.RefreshToken) (models.RefreshToken, error)
+	FindByHash(hash string) (models.RefreshToken, error)
+	// MarkUsed flags the token as rotated. It returns false when the token was already used or revoked.
+	MarkUsed(id uint, at time.Time) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeUser(userID uint) error
+	DeleteExpired(before time.Time) error
+}
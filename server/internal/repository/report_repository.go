@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+// ReportRepository aggregates appointments in the database. Ranges include start and exclude end.
+type ReportRepository interface {
+	CountByStatus(start, end time.Time, bucket models.ReportBucket) ([]models.StatusCount, error)
+	RevenueByPeriod(start, end time.Time, bucket models.ReportBucket) ([]models.PeriodRevenue, error)
+	TopServices(start, end time.Time, limit int) ([]models.ServiceRanking, error)
+	BusiestHours(start, end time.Time) ([]models.HourLoad, error)
+}
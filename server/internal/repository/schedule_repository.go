@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type ScheduleRepository interface {
+	ListBusinessHours() ([]models.BusinessHours, error)
+	FindBusinessHours(weekday time.Weekday) (models.BusinessHours, error)
+	SaveBusinessHours(hours []models.BusinessHours) error
+	ListHolidays() ([]models.Holiday, error)
+	CreateHoliday(holiday models.Holiday) (models.Holiday, error)
+	DeleteHoliday(id uint) error
+	IsHoliday(day time.Time) (bool, error)
+}
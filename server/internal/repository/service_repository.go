@@ -7,8 +7,12 @@ import (
 type ServiceRepository interface {
     Create(service models.Service) (models.Service, error)
     FindByID(id uint) (models.Service, error)
-    FindAll() ([]models.Service, error)
+    FindByIDs(ids []uint) ([]models.Service, error)
+    // FindAll and FindAllIncludingInactive return pages in ID order
+    FindAll(page models.PageRequest) (models.ServicePage, error)
+    FindAllIncludingInactive(page models.PageRequest) (models.ServicePage, error)
     Update(service models.Service) error
     Delete(id uint) error
+    Restore(id uint) (models.Service, error)
     FindByName(name string) (models.Service, error)
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlAccountTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountTokenRepository(db *gorm.DB) AccountTokenRepository {
+	return &sqlAccountTokenRepository{db: db}
+}
+
+func (r *sqlAccountTokenRepository) Create(token models.AccountToken) (models.AccountToken, error) {
+	if err := r.db.Create(&token).Error; err != nil {
+		return models.AccountToken{}, err
+	}
+	return token, nil
+}
+
+func (r *sqlAccountTokenRepository) FindByHash(purpose models.AccountTokenPurpose, hash string) (models.AccountToken, error) {
+	var token models.AccountToken
+	err := r.db.Where("purpose = ? AND token_hash = ?", purpose, hash).First(&token).Error
+	return token, err
+}
+
+// MarkUsed only updates an unused token, so the same link cannot be used twice concurrently
+func (r *sqlAccountTokenRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.AccountToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *sqlAccountTokenRepository) InvalidateUser(userID uint, purpose models.AccountTokenPurpose, at time.Time) error {
+	return r.db.Model(&models.AccountToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error
+}
+
+func (r *sqlAccountTokenRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.AccountToken{}).Error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAccountToken(t *testing.T, repo AccountTokenRepository, userID uint, purpose models.AccountTokenPurpose, hash string, expiresAt time.Time) models.AccountToken {
+	token, err := repo.Create(models.AccountToken{UserID: userID, Purpose: purpose, TokenHash: hash, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+	return token
+}
+
+func TestAccountTokenRepository_FindByHash(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountTokenRepository(db)
+	createTestAccountToken(t, repo, 1, models.PurposePasswordReset, "hash", time.Now().Add(time.Hour))
+
+	found, err := repo.FindByHash(models.PurposePasswordReset, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), found.UserID)
+
+	_, err = repo.FindByHash(models.PurposeEmailVerification, "hash")
+	assert.Error(t, err, "a token only works for its purpose")
+}
+
+func TestAccountTokenRepository_MarkUsedOnce(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountTokenRepository(db)
+	token := createTestAccountToken(t, repo, 1, models.PurposePasswordReset, "hash", time.Now().Add(time.Hour))
+
+	used, err := repo.MarkUsed(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = repo.MarkUsed(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, used, "a token can only be used once")
+}
+
+func TestAccountTokenRepository_InvalidateUser(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountTokenRepository(db)
+	createTestAccountToken(t, repo, 1, models.PurposePasswordReset, "a", time.Now().Add(time.Hour))
+	createTestAccountToken(t, repo, 1, models.PurposeEmailVerification, "b", time.Now().Add(time.Hour))
+	createTestAccountToken(t, repo, 2, models.PurposePasswordReset, "c", time.Now().Add(time.Hour))
+
+	require.NoError(t, repo.InvalidateUser(1, models.PurposePasswordReset, time.Now()))
+
+	for hash, purpose := range map[string]models.AccountTokenPurpose{"a": models.PurposePasswordReset, "b": models.PurposeEmailVerification, "c": models.PurposePasswordReset} {
+		found, err := repo.FindByHash(purpose, hash)
+		require.NoError(t, err)
+		assert.Equal(t, hash == "a", found.UsedAt != nil, hash)
+	}
+}
+
+func TestAccountTokenRepository_DeleteExpired(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountTokenRepository(db)
+	createTestAccountToken(t, repo, 1, models.PurposePasswordReset, "old", time.Now().Add(-time.Hour))
+	createTestAccountToken(t, repo, 1, models.PurposePasswordReset, "new", time.Now().Add(time.Hour))
+
+	require.NoError(t, repo.DeleteExpired(time.Now()))
+
+	_, err := repo.FindByHash(models.PurposePasswordReset, "old")
+	assert.Error(t, err)
+	_, err = repo.FindByHash(models.PurposePasswordReset, "new")
+	assert.NoError(t, err)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"strings"
 	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
@@ -15,54 +16,258 @@ func NewAppointmentRepository(db *gorm.DB) AppointmentRepository {
 	return &sqlAppointmentRepo{db}
 }
 
+// withDetails preloads the relations shown with an appointment. Customers and services deleted
+// after the booking are still loaded so the history stays intact.
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("User", unscoped).Preload("Services", unscoped).Preload("Items").Preload("Professional")
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
 func (r *sqlAppointmentRepo) Create(ap models.Appointment) (models.Appointment, error) {
-	err := r.db.Create(&ap).Error
-	if err != nil {
-		return ap, err
-	}
+	return r.CreateIfFree(ap, nil)
+}
 
-	// Associate services with appointment (many-to-many)
-	if len(ap.Services) > 0 {
-		err = r.db.Model(&ap).Association("Services").Append(ap.Services)
-		if err != nil {
-			return ap, err
+// CreateIfFree stores the date in the local zone. SQLite compares the dates as text, which only follows the time order
+// when they share a zone, and the reports group by their wall clock. PostgreSQL compares the instants either way.
+func (r *sqlAppointmentRepo) CreateIfFree(ap models.Appointment, check func(repo AppointmentRepository, ap *models.Appointment) error) (models.Appointment, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBookings(tx); err != nil {
+			return err
+		}
+		if check != nil {
+			if err := check(&sqlAppointmentRepo{tx}, &ap); err != nil {
+				return err
+			}
 		}
+		ap.Date = ap.Date.In(time.Local)
+		if err := tx.Omit("Services").Create(&ap).Error; err != nil {
+			return err
+		}
+		// Associate services with appointment (many-to-many)
+		if len(ap.Services) > 0 {
+			return tx.Model(&ap).Association("Services").Append(ap.Services)
+		}
+		return nil
+	})
+	if err != nil {
+		return ap, err
 	}
 
 	// Reload the appointment with User and Services preloaded
-	err = r.db.Preload("User").Preload("Services").First(&ap, ap.ID).Error
+	err = withDetails(r.db).First(&ap, ap.ID).Error
 	return ap, err
 }
 
+// lockBookings makes the transaction wait for the bookings of the others, so that each one sees the slots they took.
+// PostgreSQL locks the table against other writers but not readers, SQLite takes its only write lock with a write
+// that changes nothing.
+func lockBookings(tx *gorm.DB) error {
+	if tx.Dialector.Name() == "postgres" {
+		return tx.Exec("LOCK TABLE appointments IN SHARE ROW EXCLUSIVE MODE").Error
+	}
+	return tx.Exec("UPDATE appointments SET id = id WHERE 1 = 0").Error
+}
+
+// Update saves the appointment and makes its services and booked items match the given ones
 func (r *sqlAppointmentRepo) Update(ap models.Appointment) error {
-	return r.db.Save(&ap).Error
+	_, err := r.UpdateIfFree(ap, nil)
+	return err
+}
+
+func (r *sqlAppointmentRepo) UpdateIfFree(ap models.Appointment, check func(repo AppointmentRepository, ap *models.Appointment) error) (models.Appointment, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBookings(tx); err != nil {
+			return err
+		}
+		if check != nil {
+			if err := check(&sqlAppointmentRepo{tx}, &ap); err != nil {
+				return err
+			}
+		}
+		ap.Date = ap.Date.In(time.Local)
+		if err := tx.Omit("Services", "Items").Save(&ap).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ap).Association("Services").Replace(ap.Services); err != nil {
+			return err
+		}
+		return replaceItems(tx, ap.ID, ap.Items)
+	})
+	return ap, err
+}
+
+// replaceItems removes the booked items that are no longer part of the appointment and saves the others
+func replaceItems(tx *gorm.DB, appointmentID uint, items []models.AppointmentItem) error {
+	keep := []uint{}
+	for i := range items {
+		items[i].AppointmentID = appointmentID
+		if items[i].ID != 0 {
+			keep = append(keep, items[i].ID)
+		}
+	}
+	stale := tx.Where("appointment_id = ?", appointmentID)
+	if len(keep) > 0 {
+		stale = stale.Where("id NOT IN ?", keep)
+	}
+	if err := stale.Delete(&models.AppointmentItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return tx.Save(&items).Error
 }
 
 func (r *sqlAppointmentRepo) FindByID(id uint) (models.Appointment, error) {
 	var ap models.Appointment
-	err := r.db.Preload("User").Preload("Services").First(&ap, id).Error
+	err := withDetails(r.db).First(&ap, id).Error
 	return ap, err
 }
 
 func (r *sqlAppointmentRepo) FindUserAppointmentsInWeek(userID uint, weekStart, weekEnd time.Time) ([]models.Appointment, error) {
 	var list []models.Appointment
-	err := r.db.Preload("User").Preload("Services").Where("user_id = ? AND date BETWEEN ? AND ?", userID, weekStart, weekEnd).Find(&list).Error
+	err := withDetails(r.db).Where("user_id = ? AND date BETWEEN ? AND ?", userID, weekStart.In(time.Local), weekEnd.In(time.Local)).Find(&list).Error
+	return list, err
+}
+
+func (r *sqlAppointmentRepo) ListByPeriod(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	return r.Search(models.AppointmentQuery{From: &start, To: &end, Page: page})
+}
+
+func (r *sqlAppointmentRepo) ListByPeriodAndUser(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	return r.Search(models.AppointmentQuery{UserID: &userID, From: &start, To: &end, Page: page})
+}
+
+func (r *sqlAppointmentRepo) ListByProfessionalAndPeriod(professionalID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	return r.Search(models.AppointmentQuery{ProfessionalID: &professionalID, From: &start, To: &end, Page: page})
+}
+
+// ListActiveByPeriod returns the appointments starting within the period that still hold their time slot
+func (r *sqlAppointmentRepo) ListActiveByPeriod(start, end time.Time) ([]models.Appointment, error) {
+	var list []models.Appointment
+	err := r.db.Preload("Services", unscoped).Preload("Items").Where("status <> ? AND date BETWEEN ? AND ?", models.StatusCanceled, start.In(time.Local), end.In(time.Local)).Order("date").Find(&list).Error
 	return list, err
 }
 
-func (r *sqlAppointmentRepo) ListByPeriod(start, end time.Time) ([]models.Appointment, error) {
+func (r *sqlAppointmentRepo) ListAll(page models.PageRequest) (models.AppointmentPage, error) {
+	return r.Search(models.AppointmentQuery{Page: page})
+}
+
+func (r *sqlAppointmentRepo) Search(query models.AppointmentQuery) (models.AppointmentPage, error) {
+	if err := query.Validate(); err != nil {
+		return models.AppointmentPage{}, err
+	}
+
+	column, desc := "date", false
+	switch query.Sort {
+	case models.SortDateDesc:
+		desc = true
+	case models.SortCreatedAtAsc:
+		column = "created_at"
+	case models.SortCreatedAtDesc:
+		column, desc = "created_at", true
+	}
+	cursorOf := func(ap models.Appointment) pageCursor {
+		key := ap.Date
+		if column == "created_at" {
+			key = ap.CreatedAt
+		}
+		return pageCursor{Key: &key, ID: ap.ID}
+	}
+
+	items, info, err := readPage(r.db, matchAppointments(query), withDetails, "appointments", column, desc, query.Page, cursorOf)
+	return models.AppointmentPage{Items: items, PageInfo: info}, err
+}
+
+// matchAppointments applies the filters of the query, each one only when set
+func matchAppointments(query models.AppointmentQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(query.Statuses) > 0 {
+			db = db.Where("appointments.status IN ?", query.Statuses)
+		}
+		if len(query.ServiceIDs) > 0 {
+			db = db.Where("appointments.id IN (SELECT appointment_id FROM appointment_services WHERE service_id IN ?)", query.ServiceIDs)
+		}
+		if query.UserID != nil {
+			db = db.Where("appointments.user_id = ?", *query.UserID)
+		}
+		if query.ProfessionalID != nil {
+			db = db.Where("appointments.professional_id = ?", *query.ProfessionalID)
+		}
+		if query.From != nil {
+			db = db.Where("appointments.date >= ?", query.From.In(time.Local))
+		}
+		if query.To != nil {
+			db = db.Where("appointments.date <= ?", query.To.In(time.Local))
+		}
+		if text := strings.TrimSpace(query.Text); text != "" {
+			pattern := "%" + likeEscaper.Replace(strings.ToLower(text)) + "%"
+			// Archived customers are searched too, their appointments stay in the history
+			db = db.Where(`appointments.user_id IN (SELECT id FROM users WHERE LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\' OR phone LIKE ? ESCAPE '\')`, pattern, pattern, pattern)
+		}
+		return db
+	}
+}
+
+// likeEscaper keeps the wildcards typed by the user from matching anything in a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *sqlAppointmentRepo) ListStartedBefore(statuses []models.AppointmentStatus, before time.Time) ([]models.Appointment, error) {
 	var list []models.Appointment
-	err := r.db.Preload("User").Preload("Services").Where("date BETWEEN ? AND ?", start, end).Find(&list).Error
+	err := r.db.Preload("Services", unscoped).Preload("Items").Where("status IN ? AND date < ?", statuses, before.In(time.Local)).Order("date").Find(&list).Error
 	return list, err
 }
-func (r *sqlAppointmentRepo) ListByPeriodAndUser(userID uint, start, end time.Time) ([]models.Appointment, error) {
+
+func (r *sqlAppointmentRepo) ListUpcomingByUser(userID uint, statuses []models.AppointmentStatus, after time.Time) ([]models.Appointment, error) {
 	var list []models.Appointment
-	err := r.db.Preload("User").Preload("Services").Where("user_id = ? AND date BETWEEN ? AND ?", userID, start, end).Find(&list).Error
+	err := withDetails(r.db).Where("user_id = ? AND status IN ? AND date > ?", userID, statuses, after.In(time.Local)).Order("date").Find(&list).Error
+	return list, err
+}
+
+// ChangeStatus moves the appointment from one status to another and records the change in its history.
+// It fails with models.ErrInvalidStatusTransition if the appointment is no longer in the from status.
+func (r *sqlAppointmentRepo) ChangeStatus(id uint, from, to models.AppointmentStatus, changedBy *uint, fee *models.CustomerFee) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Appointment{}).
+			Where("id = ? AND status = ?", id, from).
+			Updates(map[string]interface{}{"status": to, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrInvalidStatusTransition
+		}
+		err := tx.Create(&models.AppointmentStatusHistory{
+			AppointmentID: id,
+			FromStatus:    from,
+			ToStatus:      to,
+			ChangedByID:   changedBy,
+			ChangedAt:     now,
+		}).Error
+		if err != nil || fee == nil {
+			return err
+		}
+		return tx.Create(fee).Error
+	})
+}
+
+func (r *sqlAppointmentRepo) ListStatusHistory(appointmentID uint) ([]models.AppointmentStatusHistory, error) {
+	var list []models.AppointmentStatusHistory
+	err := r.db.Preload("ChangedBy", unscoped).Where("appointment_id = ?", appointmentID).Order("changed_at, id").Find(&list).Error
 	return list, err
 }
 
-func (r *sqlAppointmentRepo) ListAll() ([]models.Appointment, error) {
+func (r *sqlAppointmentRepo) CreateSeries(series models.AppointmentSeries) (models.AppointmentSeries, error) {
+	err := r.db.Create(&series).Error
+	return series, err
+}
+
+func (r *sqlAppointmentRepo) ListSeries(seriesID uint, from time.Time) ([]models.Appointment, error) {
 	var list []models.Appointment
-	err := r.db.Preload("User").Preload("Services").Find(&list).Error
+	err := withDetails(r.db).Where("series_id = ? AND date >= ?", seriesID, from.In(time.Local)).Order("date").Find(&list).Error
 	return list, err
 }
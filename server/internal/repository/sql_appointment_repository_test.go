@@ -1,28 +1,23 @@
 package repository
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/database"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
-	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
-	dsn := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", time.Now().UnixNano())
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
-	require.NoError(t, err, "failed to create in-memory database")
+	db := openTestDB(t)
 
 	// Migrate the schema
-	err = db.AutoMigrate(
-		&models.User{},
-		&models.Service{},
-		&models.Appointment{},
-	)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	err = migrator.Up()
 	require.NoError(t, err, "failed to migrate schema")
 
 	return db
@@ -53,9 +48,14 @@ func createTestService(t *testing.T, db *gorm.DB, name string, price float64, du
 }
 
 func createTestAppointment(t *testing.T, db *gorm.DB, userID uint, services []models.Service, date time.Time) models.Appointment {
+	items := make([]models.AppointmentItem, 0, len(services))
+	for _, service := range services {
+		items = append(items, models.NewAppointmentItem(service))
+	}
 	appointment := models.Appointment{
 		UserID:   userID,
 		Services: services,
+		Items:    items,
 		Date:     date,
 		Status:   models.StatusPending,
 	}
@@ -162,10 +162,12 @@ func TestAppointmentRepository_Update(t *testing.T) {
 func TestAppointmentRepository_Update_NonExistent(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewAppointmentRepository(db)
+	// The customer exists, PostgreSQL checks the foreign keys
+	user := createTestUser(t, db, "customer@example.com")
 
 	nonExistent := models.Appointment{
 		ID:     9999,
-		UserID: 1,
+		UserID: user.ID,
 		Status: models.StatusConfirmed,
 	}
 
@@ -284,7 +286,8 @@ func TestAppointmentRepository_ListByPeriod(t *testing.T) {
 	})
 
 	// List appointments in a 3-day period
-	appointments, err := repo.ListByPeriod(now, twoDaysLater.Add(1*time.Hour))
+	page, err := repo.ListByPeriod(now, twoDaysLater.Add(1*time.Hour), models.PageRequest{})
+	appointments := page.Items
 	assert.NoError(t, err)
 	assert.Len(t, appointments, 2)
 
@@ -313,11 +316,50 @@ func TestAppointmentRepository_ListByPeriod_NoResults(t *testing.T) {
 		Status:   models.StatusPending,
 	})
 
-	appointments, err := repo.ListByPeriod(now, now.Add(3*24*time.Hour))
+	page, err := repo.ListByPeriod(now, now.Add(3*24*time.Hour), models.PageRequest{})
+	appointments := page.Items
 	assert.NoError(t, err)
 	assert.Len(t, appointments, 0)
 }
 
+// TestAppointmentRepository_ListByPeriod_Zones checks that dates given in another zone compare as instants
+// and that the bounds of the period are included, in SQLite as in PostgreSQL
+func TestAppointmentRepository_ListByPeriod_Zones(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+
+	start := time.Date(2030, 5, 3, 10, 0, 0, 0, time.Local)
+	_, offset := start.Zone()
+	// Five hours ahead of the local zone, as a client elsewhere would send it
+	east := time.FixedZone("east", offset+5*60*60)
+	created, err := repo.Create(models.Appointment{
+		UserID:   user.ID,
+		Services: []models.Service{service},
+		Date:     start.In(east),
+		Status:   models.StatusPending,
+	})
+	require.NoError(t, err)
+	assert.True(t, created.Date.Equal(start))
+
+	page, err := repo.ListByPeriod(start, start, models.PageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 1, "both bounds are included")
+
+	page, err = repo.ListByPeriod(start.Add(-time.Hour).In(east), start.Add(-time.Minute).In(east), models.PageRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+
+	active, err := repo.ListActiveByPeriod(start.Add(-30*time.Minute).UTC(), start.Add(30*time.Minute).UTC())
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+
+	week, err := repo.FindUserAppointmentsInWeek(user.ID, start.In(east), start.Add(time.Hour).In(east))
+	require.NoError(t, err)
+	assert.Len(t, week, 1)
+}
+
 // TestAppointmentRepository_ListAll tests listing all appointments
 func TestAppointmentRepository_ListAll(t *testing.T) {
 	db := setupTestDB(t)
@@ -352,7 +394,8 @@ func TestAppointmentRepository_ListAll(t *testing.T) {
 		Status:   models.StatusDone,
 	})
 
-	appointments, err := repo.ListAll()
+	page, err := repo.ListAll(models.PageRequest{})
+	appointments := page.Items
 	assert.NoError(t, err)
 	assert.Len(t, appointments, 3)
 
@@ -378,7 +421,8 @@ func TestAppointmentRepository_ListAll_Empty(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewAppointmentRepository(db)
 
-	appointments, err := repo.ListAll()
+	page, err := repo.ListAll(models.PageRequest{})
+	appointments := page.Items
 	assert.NoError(t, err)
 	assert.Len(t, appointments, 0)
 }
@@ -510,8 +554,8 @@ func TestAppointmentRepository_ConcurrentCustomers(t *testing.T) {
 	}
 
 	// Verify ListAll returns all 15 appointments
-	all, _ := repo.ListAll()
-	assert.Len(t, all, 15)
+	all, _ := repo.ListAll(models.PageRequest{})
+	assert.Len(t, all.Items, 15)
 
 	// Verify each user has exactly 3 appointments
 	for _, user := range users {
@@ -696,3 +740,511 @@ func TestAppointmentRepository_Create_WithMultipleServices(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(found.Services), "all 3 services should be persisted in m2m table")
 }
+
+// TestAppointmentRepository_ListActiveByPeriod tests that canceled appointments do not hold their slot
+func TestAppointmentRepository_ListActiveByPeriod(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	active := createTestAppointment(t, db, user.ID, []models.Service{service}, tomorrow)
+	canceled := createTestAppointment(t, db, user.ID, []models.Service{service}, tomorrow.Add(time.Hour))
+	canceled.Status = models.StatusCanceled
+	require.NoError(t, db.Save(&canceled).Error)
+
+	list, err := repo.ListActiveByPeriod(tomorrow.Add(-time.Hour), tomorrow.Add(2*time.Hour))
+	assert.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, active.ID, list[0].ID)
+	// Services must be loaded to compute the end time
+	assert.Len(t, list[0].Services, 1)
+	assert.Equal(t, 30*time.Minute, list[0].Duration())
+}
+
+// TestAppointmentRepository_CreateIfFree_Concurrent tests that concurrent bookings of a slot see each other,
+// so only one of them takes it
+func TestAppointmentRepository_CreateIfFree_Concurrent(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+	date := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	check := func(tx AppointmentRepository, ap *models.Appointment) error {
+		booked, err := tx.ListActiveByPeriod(ap.Date.Add(-time.Minute), ap.Date.Add(time.Minute))
+		if err != nil {
+			return err
+		}
+		if len(booked) > 0 {
+			return models.ErrTimeSlotUnavailable
+		}
+		// Gives the other bookings the time to run their check before this one inserts
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	const bookings = 5
+	errs := make(chan error, bookings)
+	for i := 0; i < bookings; i++ {
+		go func() {
+			_, err := repo.CreateIfFree(models.Appointment{
+				UserID:   user.ID,
+				Services: []models.Service{service},
+				Date:     date,
+				Status:   models.StatusPending,
+			}, check)
+			errs <- err
+		}()
+	}
+	created := 0
+	for i := 0; i < bookings; i++ {
+		err := <-errs
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+	}
+	assert.Equal(t, 1, created)
+
+	list, err := repo.ListActiveByPeriod(date.Add(-time.Minute), date.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+// TestAppointmentRepository_UpdateIfFree_Concurrent tests that appointments moved to the same slot at the same time
+// cannot both take it
+func TestAppointmentRepository_UpdateIfFree_Concurrent(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+	date := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	check := func(tx AppointmentRepository, ap *models.Appointment) error {
+		booked, err := tx.ListActiveByPeriod(ap.Date.Add(-time.Minute), ap.Date.Add(time.Minute))
+		if err != nil {
+			return err
+		}
+		if len(booked) > 0 {
+			return models.ErrTimeSlotUnavailable
+		}
+		// Gives the other moves the time to run their check before this one saves
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	const moves = 5
+	errs := make(chan error, moves)
+	for i := 0; i < moves; i++ {
+		ap := createTestAppointment(t, db, user.ID, []models.Service{service}, date.Add(time.Duration(i+1)*time.Hour))
+		go func() {
+			ap.Date = date
+			_, err := repo.UpdateIfFree(ap, check)
+			errs <- err
+		}()
+	}
+	moved := 0
+	for i := 0; i < moves; i++ {
+		err := <-errs
+		if err == nil {
+			moved++
+			continue
+		}
+		assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+	}
+	assert.Equal(t, 1, moved)
+
+	list, err := repo.ListActiveByPeriod(date.Add(-time.Minute), date.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+// TestAppointmentRepository_CreateIfFree_Rejected tests that nothing is stored when the check fails
+func TestAppointmentRepository_CreateIfFree_Rejected(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+
+	_, err := repo.CreateIfFree(models.Appointment{
+		UserID:   user.ID,
+		Services: []models.Service{service},
+		Items:    []models.AppointmentItem{models.NewAppointmentItem(service)},
+		Date:     time.Now().Add(24 * time.Hour),
+		Status:   models.StatusPending,
+	}, func(AppointmentRepository, *models.Appointment) error {
+		return models.ErrTimeSlotUnavailable
+	})
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Appointment{}).Count(&count).Error)
+	assert.Zero(t, count)
+	require.NoError(t, db.Table("appointment_services").Count(&count).Error)
+	assert.Zero(t, count)
+	require.NoError(t, db.Model(&models.AppointmentItem{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+// TestAppointmentRepository_ChangeStatus tests that status changes are recorded in the history
+func TestAppointmentRepository_ChangeStatus(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	admin := createTestUser(t, db, "admin@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{service}, time.Now().Add(24*time.Hour))
+
+	require.NoError(t, repo.ChangeStatus(ap.ID, models.StatusPending, models.StatusConfirmed, &admin.ID, nil))
+	require.NoError(t, repo.ChangeStatus(ap.ID, models.StatusConfirmed, models.StatusDone, nil, nil))
+
+	found, err := repo.FindByID(ap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusDone, found.Status)
+
+	history, err := repo.ListStatusHistory(ap.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.StatusPending, history[0].FromStatus)
+	assert.Equal(t, models.StatusConfirmed, history[0].ToStatus)
+	require.NotNil(t, history[0].ChangedBy)
+	assert.Equal(t, "admin@example.com", history[0].ChangedBy.Email)
+	assert.Equal(t, models.StatusDone, history[1].ToStatus)
+	assert.Nil(t, history[1].ChangedByID)
+}
+
+// TestAppointmentRepository_ChangeStatus_StaleStatus tests that a concurrent change is not overwritten
+func TestAppointmentRepository_ChangeStatus_StaleStatus(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{service}, time.Now().Add(24*time.Hour))
+
+	require.NoError(t, repo.ChangeStatus(ap.ID, models.StatusPending, models.StatusCanceled, &user.ID, nil))
+
+	err := repo.ChangeStatus(ap.ID, models.StatusPending, models.StatusConfirmed, &user.ID, nil)
+	assert.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+
+	found, _ := repo.FindByID(ap.ID)
+	assert.Equal(t, models.StatusCanceled, found.Status)
+
+	history, err := repo.ListStatusHistory(ap.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+// TestAppointmentRepository_ChangeStatus_Fee tests that the fee is recorded together with the status change
+func TestAppointmentRepository_ChangeStatus_Fee(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+	feeRepo := NewFeeRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	service := createTestService(t, db, "Haircut", 50.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{service}, time.Now().Add(24*time.Hour))
+	other := createTestAppointment(t, db, user.ID, []models.Service{service}, time.Now().Add(48*time.Hour))
+
+	fee := models.CustomerFee{UserID: user.ID, AppointmentID: ap.ID, Reason: models.FeeLateCancellation, Percent: 50, Amount: 25}
+	require.NoError(t, repo.ChangeStatus(ap.ID, models.StatusPending, models.StatusCanceled, &user.ID, &fee))
+
+	fees, err := feeRepo.ListByUser(user.ID)
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	assert.Equal(t, ap.ID, fees[0].AppointmentID)
+
+	// A fee that cannot be recorded undoes the change
+	duplicate := fees[0]
+	err = repo.ChangeStatus(other.ID, models.StatusPending, models.StatusCanceled, &user.ID, &duplicate)
+	assert.Error(t, err)
+
+	found, err := repo.FindByID(other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusPending, found.Status)
+	history, err := repo.ListStatusHistory(other.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+// TestAppointmentRepository_ItemsSnapshotCatalog tests that editing or deleting a service does not change booked appointments
+func TestAppointmentRepository_ItemsSnapshotCatalog(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+	serviceRepo := NewServiceRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 120.00, 90)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{haircut, coloring}, time.Now().Add(24*time.Hour))
+
+	haircut.Price = 80.00
+	haircut.DurationMinutes = 45
+	require.NoError(t, serviceRepo.Update(haircut))
+	require.NoError(t, serviceRepo.Delete(coloring.ID))
+
+	found, err := repo.FindByID(ap.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Items, 2)
+	assert.Equal(t, "Haircut", found.Items[0].Name)
+	assert.Equal(t, 50.00, found.Items[0].Price)
+	assert.Equal(t, "Coloring", found.Items[1].Name)
+	assert.Equal(t, 170.00, found.TotalPrice())
+	assert.Equal(t, 2*time.Hour, found.Duration())
+}
+
+// TestAppointmentRepository_Update_ReplacesItems tests that removed services leave the appointment
+func TestAppointmentRepository_Update_ReplacesItems(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 120.00, 90)
+	manicure := createTestService(t, db, "Manicure", 30.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{haircut, coloring}, time.Now().Add(24*time.Hour))
+
+	found, err := repo.FindByID(ap.ID)
+	require.NoError(t, err)
+	found.Services = []models.Service{haircut, manicure}
+	found.Items = []models.AppointmentItem{found.Items[0], models.NewAppointmentItem(manicure)}
+	require.NoError(t, repo.Update(found))
+
+	updated, err := repo.FindByID(ap.ID)
+	require.NoError(t, err)
+	require.Len(t, updated.Services, 2)
+	require.Len(t, updated.Items, 2)
+	assert.Equal(t, found.Items[0].ID, updated.Items[0].ID, "the kept item must not be booked again")
+	assert.Equal(t, "Manicure", updated.Items[1].Name)
+
+	var count int64
+	db.Model(&models.AppointmentItem{}).Where("appointment_id = ?", ap.ID).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+// TestAppointmentRepository_ListStartedBefore tests that only appointments in the given statuses that already started are listed
+func TestAppointmentRepository_ListStartedBefore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	now := time.Now()
+	past := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(-3*time.Hour))
+	confirmed := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(-2*time.Hour))
+	require.NoError(t, repo.ChangeStatus(confirmed.ID, models.StatusPending, models.StatusConfirmed, nil, nil))
+	canceled := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(-time.Hour))
+	require.NoError(t, repo.ChangeStatus(canceled.ID, models.StatusPending, models.StatusCanceled, nil, nil))
+	createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(time.Hour))
+
+	list, err := repo.ListStartedBefore([]models.AppointmentStatus{models.StatusPending}, now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, past.ID, list[0].ID)
+	assert.Len(t, list[0].Items, 1, "the duration is needed to know when the appointment ended")
+
+	list, err = repo.ListStartedBefore([]models.AppointmentStatus{models.StatusPending, models.StatusConfirmed}, now)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, confirmed.ID, list[1].ID)
+}
+
+func TestAppointmentRepository_ListUpcomingByUser(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	other := createTestUser(t, db, "other@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	now := time.Now()
+	createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(-time.Hour))
+	upcoming := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(time.Hour))
+	canceled := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(2*time.Hour))
+	require.NoError(t, repo.ChangeStatus(canceled.ID, models.StatusPending, models.StatusCanceled, nil, nil))
+	createTestAppointment(t, db, other.ID, []models.Service{haircut}, now.Add(time.Hour))
+
+	list, err := repo.ListUpcomingByUser(user.ID, []models.AppointmentStatus{models.StatusPending, models.StatusConfirmed}, now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, upcoming.ID, list[0].ID)
+	assert.Equal(t, "customer@example.com", list[0].User.Email)
+	assert.Len(t, list[0].Services, 1)
+}
+
+func TestAppointmentRepository_ListByProfessionalAndPeriod(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	leila := models.Professional{Name: "Leila", IsActive: true}
+	require.NoError(t, db.Create(&leila).Error)
+	ana := models.Professional{Name: "Ana", IsActive: true}
+	require.NoError(t, db.Create(&ana).Error)
+
+	now := time.Now()
+	assigned := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(time.Hour))
+	other := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.Add(2*time.Hour))
+	later := createTestAppointment(t, db, user.ID, []models.Service{haircut}, now.AddDate(0, 1, 0))
+	require.NoError(t, db.Model(&models.Appointment{}).Where("id IN ?", []uint{assigned.ID, later.ID}).Update("professional_id", leila.ID).Error)
+	require.NoError(t, db.Model(&models.Appointment{}).Where("id = ?", other.ID).Update("professional_id", ana.ID).Error)
+
+	page, err := repo.ListByProfessionalAndPeriod(leila.ID, now, now.AddDate(0, 0, 7), models.PageRequest{})
+	require.NoError(t, err)
+	list := page.Items
+	require.Len(t, list, 1)
+	assert.Equal(t, assigned.ID, list[0].ID)
+	assert.Len(t, list[0].Items, 1)
+}
+
+// TestAppointmentRepository_SystemChangeIsAudited tests that automatic changes are recorded without an author
+func TestAppointmentRepository_SystemChangeIsAudited(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{haircut}, time.Now().Add(-3*time.Hour))
+	require.NoError(t, repo.ChangeStatus(ap.ID, models.StatusPending, models.StatusExpired, nil, nil))
+
+	history, err := repo.ListStatusHistory(ap.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, models.StatusExpired, history[0].ToStatus)
+	assert.Nil(t, history[0].ChangedByID)
+}
+
+// TestAppointmentRepository_ListSeries tests listing the appointments of a series from a date on
+func TestAppointmentRepository_ListSeries(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+	user := createTestUser(t, db, "maria@example.com")
+	service := createTestService(t, db, "Corte", 50, 30)
+
+	series, err := repo.CreateSeries(models.AppointmentSeries{UserID: user.ID, Recurrence: models.Recurrence{Frequency: models.FrequencyWeekly, Count: 3}})
+	require.NoError(t, err)
+	require.NotZero(t, series.ID)
+
+	start := time.Date(2030, 5, 6, 10, 0, 0, 0, time.Local)
+	for week := 2; week >= 0; week-- {
+		_, err := repo.Create(models.Appointment{
+			UserID:   user.ID,
+			Services: []models.Service{service},
+			Date:     start.AddDate(0, 0, 7*week),
+			Status:   models.StatusPending,
+			SeriesID: &series.ID,
+		})
+		require.NoError(t, err)
+	}
+	// Same day, outside the series
+	createTestAppointment(t, db, user.ID, []models.Service{service}, start.AddDate(0, 0, 7))
+
+	list, err := repo.ListSeries(series.ID, start.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.True(t, list[0].Date.Equal(start.AddDate(0, 0, 7)), "ordered by date")
+	assert.True(t, list[1].Date.Equal(start.AddDate(0, 0, 14)))
+	assert.Equal(t, "Corte", list[0].Services[0].Name)
+	assert.Equal(t, series.ID, *list[0].SeriesID)
+}
+
+// TestAppointmentRepository_Search tests combining the search filters
+func TestAppointmentRepository_Search(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	maria := createTestUser(t, db, "maria@example.com")
+	require.NoError(t, db.Model(&maria).Updates(models.User{Name: "Maria Souza", Phone: "11 98765-4321"}).Error)
+	joana := createTestUser(t, db, "joana_100@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 100.00, 120)
+	leila := models.Professional{Name: "Leila", IsActive: true}
+	require.NoError(t, db.Create(&leila).Error)
+
+	base := time.Date(2030, 5, 6, 10, 0, 0, 0, time.Local)
+	cut := createTestAppointment(t, db, maria.ID, []models.Service{haircut}, base)
+	color := createTestAppointment(t, db, maria.ID, []models.Service{coloring}, base.AddDate(0, 0, 1))
+	both := createTestAppointment(t, db, joana.ID, []models.Service{haircut, coloring}, base.AddDate(0, 0, 2))
+	require.NoError(t, repo.ChangeStatus(color.ID, models.StatusPending, models.StatusCanceled, nil, nil))
+	require.NoError(t, db.Model(&models.Appointment{}).Where("id = ?", both.ID).Update("professional_id", leila.ID).Error)
+
+	ids := func(page models.AppointmentPage) []uint {
+		list := []uint{}
+		for _, ap := range page.Items {
+			list = append(list, ap.ID)
+		}
+		return list
+	}
+	from, to := base.AddDate(0, 0, 1), base.AddDate(0, 0, 2)
+	tests := []struct {
+		name  string
+		query models.AppointmentQuery
+		want  []uint
+	}{
+		{"everything", models.AppointmentQuery{}, []uint{cut.ID, color.ID, both.ID}},
+		{"status", models.AppointmentQuery{Statuses: []models.AppointmentStatus{models.StatusPending}}, []uint{cut.ID, both.ID}},
+		{"service", models.AppointmentQuery{ServiceIDs: []uint{coloring.ID}}, []uint{color.ID, both.ID}},
+		{"customer", models.AppointmentQuery{UserID: &maria.ID}, []uint{cut.ID, color.ID}},
+		{"professional", models.AppointmentQuery{ProfessionalID: &leila.ID}, []uint{both.ID}},
+		{"period", models.AppointmentQuery{From: &from, To: &to}, []uint{color.ID, both.ID}},
+		{"name ignoring case", models.AppointmentQuery{Text: "SOUZA"}, []uint{cut.ID, color.ID}},
+		{"phone", models.AppointmentQuery{Text: "98765"}, []uint{cut.ID, color.ID}},
+		{"wildcards are literal", models.AppointmentQuery{Text: "a_1"}, []uint{both.ID}},
+		{"combined", models.AppointmentQuery{Text: "maria", ServiceIDs: []uint{haircut.ID}}, []uint{cut.ID}},
+		{"newest first", models.AppointmentQuery{Sort: models.SortDateDesc}, []uint{both.ID, color.ID, cut.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.Search(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(page))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+
+	_, err := repo.Search(models.AppointmentQuery{Sort: "price"})
+	assert.ErrorIs(t, err, models.ErrInvalidSort)
+}
+
+// TestAppointmentRepository_Search_Pages tests walking through the results with the cursors
+func TestAppointmentRepository_Search_Pages(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	base := time.Date(2030, 5, 6, 10, 0, 0, 0, time.Local)
+	// Appointments sharing a date are ordered by ID
+	a := createTestAppointment(t, db, user.ID, []models.Service{haircut}, base.AddDate(0, 0, 1))
+	b := createTestAppointment(t, db, user.ID, []models.Service{haircut}, base.AddDate(0, 0, 1))
+	c := createTestAppointment(t, db, user.ID, []models.Service{haircut}, base)
+	d := createTestAppointment(t, db, user.ID, []models.Service{haircut}, base)
+	e := createTestAppointment(t, db, user.ID, []models.Service{haircut}, base.AddDate(0, 0, 2))
+	want := []uint{c.ID, d.ID, a.ID, b.ID, e.ID}
+
+	var got []uint
+	// The first page ends between a and b, which share their date
+	query := models.AppointmentQuery{Page: models.PageRequest{Limit: 3}}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 2, "the last page has no cursor")
+		page, err := repo.Search(query)
+		require.NoError(t, err)
+		for _, ap := range page.Items {
+			got = append(got, ap.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Page.Cursor = page.NextCursor
+	}
+	assert.Equal(t, want, got)
+
+	_, err := repo.Search(models.AppointmentQuery{Page: models.PageRequest{Cursor: "not-a-cursor"}})
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+}
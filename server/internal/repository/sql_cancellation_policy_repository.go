@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlCancellationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewCancellationPolicyRepository(db *gorm.DB) CancellationPolicyRepository {
+	return &sqlCancellationPolicyRepository{db: db}
+}
+
+// Get returns the configured policy, or the default one while the admin has not saved any
+func (r *sqlCancellationPolicyRepository) Get() (models.CancellationPolicy, error) {
+	var policy models.CancellationPolicy
+	if err := r.db.First(&policy, 1).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DefaultCancellationPolicy(), nil
+		}
+		return models.CancellationPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (r *sqlCancellationPolicyRepository) Save(policy models.CancellationPolicy) (models.CancellationPolicy, error) {
+	policy.ID = 1
+	if err := r.db.Save(&policy).Error; err != nil {
+		return models.CancellationPolicy{}, err
+	}
+	return policy, nil
+}
+
+type sqlFeeRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeRepository(db *gorm.DB) FeeRepository {
+	return &sqlFeeRepository{db: db}
+}
+
+func (r *sqlFeeRepository) Create(fee models.CustomerFee) (models.CustomerFee, error) {
+	if err := r.db.Create(&fee).Error; err != nil {
+		return models.CustomerFee{}, err
+	}
+	return fee, nil
+}
+
+func (r *sqlFeeRepository) ListByUser(userID uint) ([]models.CustomerFee, error) {
+	var fees []models.CustomerFee
+	if err := r.db.Where("user_id = ?", userID).Order("created_at, id").Find(&fees).Error; err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellationPolicyRepository_DefaultsAndSave(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCancellationPolicyRepository(db)
+
+	policy, err := repo.Get()
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultCancellationPolicy(), policy)
+
+	_, err = repo.Save(models.CancellationPolicy{RescheduleNoticeHours: 24, CancelNoticeHours: 12, LateCancelFeePercent: 50, MaxReschedules: 2})
+	require.NoError(t, err)
+	// Saving again replaces the single policy row
+	_, err = repo.Save(models.CancellationPolicy{RescheduleNoticeHours: 6, CancelNoticeHours: 6, NoShowFeePercent: 100})
+	require.NoError(t, err)
+
+	policy, err = repo.Get()
+	require.NoError(t, err)
+	assert.Equal(t, 6, policy.RescheduleNoticeHours)
+	assert.Equal(t, 0.0, policy.LateCancelFeePercent)
+	assert.Equal(t, 100.0, policy.NoShowFeePercent)
+
+	var count int64
+	db.Model(&models.CancellationPolicy{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestFeeRepository_ListByUser(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewFeeRepository(db)
+
+	customer := createTestUser(t, db, "customer@example.com")
+	other := createTestUser(t, db, "other@example.com")
+
+	_, err := repo.Create(models.CustomerFee{UserID: customer.ID, AppointmentID: 1, Reason: models.FeeLateCancellation, Percent: 50, Amount: 25})
+	require.NoError(t, err)
+	_, err = repo.Create(models.CustomerFee{UserID: customer.ID, AppointmentID: 2, Reason: models.FeeNoShow, Percent: 100, Amount: 40})
+	require.NoError(t, err)
+	_, err = repo.Create(models.CustomerFee{UserID: other.ID, AppointmentID: 3, Reason: models.FeeNoShow, Percent: 100, Amount: 10})
+	require.NoError(t, err)
+
+	fees, err := repo.ListByUser(customer.ID)
+	require.NoError(t, err)
+	require.Len(t, fees, 2)
+	assert.Equal(t, models.FeeLateCancellation, fees[0].Reason)
+	assert.Equal(t, 40.0, fees[1].Amount)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepository(db *gorm.DB) IdentityRepository {
+	return &sqlIdentityRepository{db: db}
+}
+
+func (r *sqlIdentityRepository) Create(identity models.UserIdentity) (models.UserIdentity, error) {
+	if err := r.db.Create(&identity).Error; err != nil {
+		return models.UserIdentity{}, err
+	}
+	return identity, nil
+}
+
+func (r *sqlIdentityRepository) FindBySubject(provider, subject string) (models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	return identity, err
+}
+
+func (r *sqlIdentityRepository) ListByUser(userID uint) ([]models.UserIdentity, error) {
+	var list []models.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("provider").Find(&list).Error
+	return list, err
+}
+
+func (r *sqlIdentityRepository) CreateState(state models.LoginState) error {
+	return r.db.Create(&state).Error
+}
+
+// ConsumeState only returns the state when this call deleted it, so concurrent callbacks with the
+// same state cannot both log in
+func (r *sqlIdentityRepository) ConsumeState(hash string) (models.LoginState, error) {
+	var state models.LoginState
+	if err := r.db.Where("state_hash = ?", hash).First(&state).Error; err != nil {
+		return models.LoginState{}, err
+	}
+	result := r.db.Where("id = ?", state.ID).Delete(&models.LoginState{})
+	if result.Error != nil {
+		return models.LoginState{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.LoginState{}, gorm.ErrRecordNotFound
+	}
+	return state, nil
+}
+
+func (r *sqlIdentityRepository) DeleteExpiredStates(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.LoginState{}).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestIdentityRepository_CreateAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewIdentityRepository(db)
+	user := createTestUser(t, db, "maria@example.com")
+
+	created, err := repo.Create(models.UserIdentity{UserID: user.ID, Provider: "google", Subject: "123", Email: user.Email})
+	require.NoError(t, err)
+
+	found, err := repo.FindBySubject("google", "123")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.Equal(t, user.ID, found.UserID)
+
+	_, err = repo.FindBySubject("keycloak", "123")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// The same provider account cannot be linked twice
+	_, err = repo.Create(models.UserIdentity{UserID: user.ID, Provider: "google", Subject: "123"})
+	assert.Error(t, err)
+
+	list, err := repo.ListByUser(user.ID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestIdentityRepository_ConsumeState(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewIdentityRepository(db)
+
+	require.NoError(t, repo.CreateState(models.LoginState{StateHash: "hash", Provider: "google", Nonce: "nonce", ExpiresAt: time.Now().Add(time.Minute)}))
+
+	state, err := repo.ConsumeState("hash")
+	require.NoError(t, err)
+	assert.Equal(t, "nonce", state.Nonce)
+
+	_, err = repo.ConsumeState("hash")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestIdentityRepository_DeleteExpiredStates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewIdentityRepository(db)
+	now := time.Now()
+
+	require.NoError(t, repo.CreateState(models.LoginState{StateHash: "old", ExpiresAt: now.Add(-time.Minute)}))
+	require.NoError(t, repo.CreateState(models.LoginState{StateHash: "current", ExpiresAt: now.Add(time.Minute)}))
+	require.NoError(t, repo.DeleteExpiredStates(now))
+
+	_, err := repo.ConsumeState("old")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.ConsumeState("current")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlJobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &sqlJobRepository{db: db}
+}
+
+// Create stores the job in UTC so that run times compare correctly whatever the zone they were planned in
+func (r *sqlJobRepository) Create(job models.Job) (models.Job, error) {
+	job.RunAt = job.RunAt.UTC()
+	if job.Status == "" {
+		job.Status = models.JobPending
+	}
+	if err := r.db.Create(&job).Error; err != nil {
+		return models.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *sqlJobRepository) ListDue(now time.Time, limit int) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("status = ? AND run_at <= ?", models.JobPending, now.UTC()).Order("run_at, id").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *sqlJobRepository) Update(job models.Job) error {
+	job.RunAt = job.RunAt.UTC()
+	return r.db.Save(&job).Error
+}
+
+func (r *sqlJobRepository) CancelPending(kind string, appointmentID uint) error {
+	return r.db.Model(&models.Job{}).
+		Where("kind = ? AND appointment_id = ? AND status = ?", kind, appointmentID, models.JobPending).
+		Update("status", models.JobCanceled).Error
+}
+
+func (r *sqlJobRepository) ListByAppointment(appointmentID uint) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("appointment_id = ?", appointmentID).Order("run_at, id").Find(&jobs).Error
+	return jobs, err
+}
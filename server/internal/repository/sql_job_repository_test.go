@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRepository_ListDue(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewJobRepository(db)
+	now := time.Now()
+
+	late, err := repo.Create(models.Job{Kind: "test", RunAt: now.Add(-2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobPending, late.Status)
+	// Jobs planned in another zone are compared by instant
+	due, err := repo.Create(models.Job{Kind: "test", RunAt: now.Add(-time.Minute).In(time.FixedZone("BRT", -3*3600))})
+	require.NoError(t, err)
+	_, err = repo.Create(models.Job{Kind: "test", RunAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = repo.Create(models.Job{Kind: "test", RunAt: now.Add(-time.Hour), Status: models.JobDone})
+	require.NoError(t, err)
+
+	jobs, err := repo.ListDue(now, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, late.ID, jobs[0].ID)
+	assert.Equal(t, due.ID, jobs[1].ID)
+
+	jobs, err = repo.ListDue(now, 1)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+func TestJobRepository_Update(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewJobRepository(db)
+
+	job, err := repo.Create(models.Job{Kind: "test", RunAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	job.Status = models.JobDone
+	job.Attempts = 1
+	require.NoError(t, repo.Update(job))
+
+	jobs, err := repo.ListDue(time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestJobRepository_CancelPending(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewJobRepository(db)
+	first, second := uint(1), uint(2)
+
+	_, err := repo.Create(models.Job{Kind: models.JobAppointmentReminder, AppointmentID: &first, RunAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = repo.Create(models.Job{Kind: models.JobAppointmentReminder, AppointmentID: &first, RunAt: time.Now().Add(-time.Hour), Status: models.JobDone})
+	require.NoError(t, err)
+	_, err = repo.Create(models.Job{Kind: models.JobAppointmentReminder, AppointmentID: &second, RunAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CancelPending(models.JobAppointmentReminder, first))
+
+	jobs, err := repo.ListByAppointment(first)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, models.JobDone, jobs[0].Status, "jobs already run are kept as they were")
+	assert.Equal(t, models.JobCanceled, jobs[1].Status)
+
+	jobs, err = repo.ListByAppointment(second)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, models.JobPending, jobs[0].Status)
+}
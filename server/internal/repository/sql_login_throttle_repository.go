@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlLoginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &sqlLoginThrottleRepository{db: db}
+}
+
+// Find and Modify read with Find rather than First, which would log every key that never failed as an error
+func (r *sqlLoginThrottleRepository) Find(key string) (models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	result := r.db.Where("key = ?", key).Limit(1).Find(&throttle)
+	if result.Error != nil {
+		return models.LoginThrottle{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.LoginThrottle{Key: key}, nil
+	}
+	return throttle, nil
+}
+
+func (r *sqlLoginThrottleRepository) Save(throttle models.LoginThrottle) (models.LoginThrottle, error) {
+	if err := r.db.Save(&throttle).Error; err != nil {
+		return models.LoginThrottle{}, err
+	}
+	return throttle, nil
+}
+
+func (r *sqlLoginThrottleRepository) Modify(key string, change func(throttle *models.LoginThrottle)) (models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Inserting the missing row first gives every caller a row to lock. In SQLite the insert also takes
+		// the write lock, which is held until the transaction ends.
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(&models.LoginThrottle{Key: key}).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).Limit(1).Find(&throttle)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		change(&throttle)
+		return tx.Save(&throttle).Error
+	})
+	if err != nil {
+		return models.LoginThrottle{}, err
+	}
+	return throttle, nil
+}
+
+func (r *sqlLoginThrottleRepository) Delete(key string) error {
+	return r.db.Where("key = ?", key).Delete(&models.LoginThrottle{}).Error
+}
+
+func (r *sqlLoginThrottleRepository) DeleteStale(before time.Time) error {
+	return r.db.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, before).
+		Delete(&models.LoginThrottle{}).Error
+}
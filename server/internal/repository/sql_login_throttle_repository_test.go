@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottleRepository_SaveAndFind(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLoginThrottleRepository(db)
+
+	throttle, err := repo.Find("account:maria@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, models.LoginThrottle{Key: "account:maria@example.com"}, throttle, "keys without failures start empty")
+
+	throttle.Failures = 1
+	throttle.LastFailureAt = time.Now()
+	throttle, err = repo.Save(throttle)
+	require.NoError(t, err)
+
+	throttle.Failures = 2
+	_, err = repo.Save(throttle)
+	require.NoError(t, err)
+
+	found, err := repo.Find("account:maria@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, throttle.ID, found.ID)
+	assert.Equal(t, 2, found.Failures)
+
+	require.NoError(t, repo.Delete("account:maria@example.com"))
+	found, err = repo.Find("account:maria@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, found.ID)
+}
+
+// TestLoginThrottleRepository_Modify_Concurrent checks that parallel failures are all counted
+func TestLoginThrottleRepository_Modify_Concurrent(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLoginThrottleRepository(db)
+
+	const attempts = 10
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		go func() {
+			_, err := repo.Modify("account:maria@example.com", func(throttle *models.LoginThrottle) {
+				throttle.Failures++
+				throttle.LastFailureAt = time.Now()
+			})
+			errs <- err
+		}()
+	}
+	for i := 0; i < attempts; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	found, err := repo.Find("account:maria@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, attempts, found.Failures)
+}
+
+func TestLoginThrottleRepository_DeleteStale(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLoginThrottleRepository(db)
+	now := time.Now()
+	lockedUntil := now.Add(time.Hour)
+
+	_, err := repo.Save(models.LoginThrottle{Key: "ip:old", Failures: 3, LastFailureAt: now.Add(-48 * time.Hour)})
+	require.NoError(t, err)
+	_, err = repo.Save(models.LoginThrottle{Key: "ip:recent", Failures: 3, LastFailureAt: now})
+	require.NoError(t, err)
+	_, err = repo.Save(models.LoginThrottle{Key: "ip:locked", Failures: 100, LastFailureAt: now.Add(-48 * time.Hour), LockedUntil: &lockedUntil})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteStale(now.Add(-24*time.Hour)))
+
+	for key, kept := range map[string]bool{"ip:old": false, "ip:recent": true, "ip:locked": true} {
+		found, err := repo.Find(key)
+		require.NoError(t, err)
+		assert.Equal(t, kept, found.ID != 0, key)
+	}
+}
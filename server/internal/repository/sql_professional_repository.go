@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlProfessionalRepository struct {
+	db *gorm.DB
+}
+
+func NewProfessionalRepository(db *gorm.DB) ProfessionalRepository {
+	return &sqlProfessionalRepository{db: db}
+}
+
+func (r *sqlProfessionalRepository) Create(professional models.Professional) (models.Professional, error) {
+	if err := r.db.Create(&professional).Error; err != nil {
+		return models.Professional{}, err
+	}
+	return professional, nil
+}
+
+func (r *sqlProfessionalRepository) FindByID(id uint) (models.Professional, error) {
+	var professional models.Professional
+	if err := r.db.Preload("Services").First(&professional, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Professional{}, models.ErrUnknownProfessional
+		}
+		return models.Professional{}, err
+	}
+	return professional, nil
+}
+
+func (r *sqlProfessionalRepository) FindAll() ([]models.Professional, error) {
+	var professionals []models.Professional
+	if err := r.db.Preload("Services").Order("name").Find(&professionals).Error; err != nil {
+		return nil, err
+	}
+	return professionals, nil
+}
+
+func (r *sqlProfessionalRepository) FindByUserID(userID uint) (models.Professional, error) {
+	var professional models.Professional
+	if err := r.db.Preload("Services").Where("user_id = ?", userID).First(&professional).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Professional{}, models.ErrUnknownProfessional
+		}
+		return models.Professional{}, err
+	}
+	return professional, nil
+}
+
+func (r *sqlProfessionalRepository) FindActive() ([]models.Professional, error) {
+	var professionals []models.Professional
+	if err := r.db.Preload("Services").Where("is_active = ?", true).Order("id").Find(&professionals).Error; err != nil {
+		return nil, err
+	}
+	return professionals, nil
+}
+
+func (r *sqlProfessionalRepository) Update(professional models.Professional) error {
+	if professional.ID == 0 {
+		return errors.New("professional ID is required for update")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&professional).Select("Name", "Phone", "IsActive", "UserID").Updates(professional).Error; err != nil {
+			return err
+		}
+		return tx.Model(&professional).Association("Services").Replace(professional.Services)
+	})
+}
+
+func (r *sqlProfessionalRepository) Delete(id uint) error {
+	if id == 0 {
+		return errors.New("invalid professional ID")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		professional := models.Professional{ID: id}
+		if err := tx.Model(&professional).Association("Services").Clear(); err != nil {
+			return err
+		}
+		// Past appointments stay in the history without a professional
+		if err := tx.Model(&models.Appointment{}).Where("professional_id = ?", id).Update("professional_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Professional{}, id).Error
+	})
+}
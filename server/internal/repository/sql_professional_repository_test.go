@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfessionalRepository_Interface(t *testing.T) {
+	var _ ProfessionalRepository = (*sqlProfessionalRepository)(nil)
+}
+
+func TestProfessionalRepository_CreateAndFindByID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 100.00, 120)
+
+	created, err := repo.Create(models.Professional{
+		Name:     "Leila",
+		IsActive: true,
+		Services: []models.Service{haircut, coloring},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	found, err := repo.FindByID(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Leila", found.Name)
+	assert.Len(t, found.Services, 2)
+	assert.True(t, found.CanPerform([]models.Service{haircut, coloring}))
+}
+
+func TestProfessionalRepository_FindByID_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+
+	_, err := repo.FindByID(999)
+	assert.ErrorIs(t, err, models.ErrUnknownProfessional)
+}
+
+func TestProfessionalRepository_FindByUserID(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+
+	user := createTestUser(t, db, "leila@example.com")
+	created, err := repo.Create(models.Professional{Name: "Leila", IsActive: true})
+	require.NoError(t, err)
+
+	_, err = repo.FindByUserID(user.ID)
+	assert.ErrorIs(t, err, models.ErrUnknownProfessional)
+
+	created.UserID = &user.ID
+	require.NoError(t, repo.Update(created))
+
+	found, err := repo.FindByUserID(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+}
+
+func TestProfessionalRepository_FindActive(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+
+	active, err := repo.Create(models.Professional{Name: "Leila", IsActive: true})
+	require.NoError(t, err)
+	inactive, err := repo.Create(models.Professional{Name: "Ana", IsActive: true})
+	require.NoError(t, err)
+	inactive.IsActive = false
+	require.NoError(t, repo.Update(inactive))
+
+	list, err := repo.FindActive()
+	assert.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, active.ID, list[0].ID)
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestProfessionalRepository_Update_ReplacesServices(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	manicure := createTestService(t, db, "Manicure", 30.00, 30)
+
+	created, err := repo.Create(models.Professional{Name: "Leila", IsActive: true, Services: []models.Service{haircut}})
+	require.NoError(t, err)
+
+	created.Name = "Leila Silva"
+	created.Services = []models.Service{manicure}
+	require.NoError(t, repo.Update(created))
+
+	found, err := repo.FindByID(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Leila Silva", found.Name)
+	require.Len(t, found.Services, 1)
+	assert.Equal(t, manicure.ID, found.Services[0].ID)
+}
+
+func TestProfessionalRepository_Delete_KeepsAppointments(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewProfessionalRepository(db)
+	apRepo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	professional, err := repo.Create(models.Professional{Name: "Leila", IsActive: true, Services: []models.Service{haircut}})
+	require.NoError(t, err)
+
+	ap, err := apRepo.Create(models.Appointment{
+		UserID:         user.ID,
+		Services:       []models.Service{haircut},
+		Date:           time.Now().Add(24 * time.Hour),
+		Status:         models.StatusPending,
+		ProfessionalID: &professional.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ap.Professional)
+
+	require.NoError(t, repo.Delete(professional.ID))
+
+	_, err = repo.FindByID(professional.ID)
+	assert.ErrorIs(t, err, models.ErrUnknownProfessional)
+
+	found, err := apRepo.FindByID(ap.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found.ProfessionalID)
+	assert.Len(t, found.Services, 1)
+}
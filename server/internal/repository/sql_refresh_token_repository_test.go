@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRefreshToken(t *testing.T, repo RefreshTokenRepository, userID uint, family, hash string) models.RefreshToken {
+	token, err := repo.Create(models.RefreshToken{UserID: userID, FamilyID: family, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	return token
+}
+
+func TestRefreshTokenRepository_MarkUsedOnce(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRefreshTokenRepository(db)
+	token := createTestRefreshToken(t, repo, 1, "family", "hash")
+
+	rotated, err := repo.MarkUsed(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	rotated, err = repo.MarkUsed(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, rotated, "a token can only be rotated once")
+
+	found, err := repo.FindByHash("hash")
+	require.NoError(t, err)
+	assert.NotNil(t, found.UsedAt)
+}
+
+func TestRefreshTokenRepository_Revoke(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRefreshTokenRepository(db)
+	createTestRefreshToken(t, repo, 1, "first", "a")
+	createTestRefreshToken(t, repo, 1, "first", "b")
+	createTestRefreshToken(t, repo, 1, "second", "c")
+	createTestRefreshToken(t, repo, 2, "third", "d")
+
+	require.NoError(t, repo.RevokeFamily("first"))
+	for hash, revoked := range map[string]bool{"a": true, "b": true, "c": false, "d": false} {
+		found, err := repo.FindByHash(hash)
+		require.NoError(t, err)
+		assert.Equal(t, revoked, found.RevokedAt != nil, hash)
+	}
+
+	require.NoError(t, repo.RevokeUser(1))
+	found, err := repo.FindByHash("c")
+	require.NoError(t, err)
+	assert.NotNil(t, found.RevokedAt)
+	found, err = repo.FindByHash("d")
+	require.NoError(t, err)
+	assert.Nil(t, found.RevokedAt)
+
+	token, err := repo.FindByHash("a")
+	require.NoError(t, err)
+	rotated, err := repo.MarkUsed(token.ID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, rotated, "revoked tokens cannot be rotated")
+}
+
+func TestRefreshTokenRepository_DeleteExpired(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRefreshTokenRepository(db)
+	_, err := repo.Create(models.RefreshToken{UserID: 1, FamilyID: "old", TokenHash: "old", ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	createTestRefreshToken(t, repo, 1, "new", "new")
+
+	require.NoError(t, repo.DeleteExpired(time.Now()))
+
+	_, err = repo.FindByHash("old")
+	assert.Error(t, err)
+	_, err = repo.FindByHash("new")
+	assert.NoError(t, err)
+}
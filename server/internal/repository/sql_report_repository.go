@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &sqlReportRepository{db: db}
+}
+
+// periodExpr truncates appointments.date to the start of its bucket, formatted as YYYY-MM-DD
+func (r *sqlReportRepository) periodExpr(bucket models.ReportBucket) string {
+	// PostgreSQL truncates in the time zone of the session, the local one, see database.Open
+	if r.db.Dialector.Name() == "postgres" {
+		return fmt.Sprintf("to_char(date_trunc('%s', appointments.date), 'YYYY-MM-DD')", bucket)
+	}
+	// SQLite keeps the wall clock time as text, the date functions would convert it to UTC
+	switch bucket {
+	case models.BucketWeek:
+		return "date(substr(appointments.date, 1, 10), '-6 days', 'weekday 1')"
+	case models.BucketMonth:
+		return "substr(appointments.date, 1, 7) || '-01'"
+	default:
+		return "substr(appointments.date, 1, 10)"
+	}
+}
+
+// hourExpr extracts the hour of the day appointments.date starts at
+func (r *sqlReportRepository) hourExpr() string {
+	if r.db.Dialector.Name() == "postgres" {
+		return "CAST(extract(hour from appointments.date) AS integer)"
+	}
+	return "CAST(substr(appointments.date, 12, 2) AS integer)"
+}
+
+// bookedItems joins every appointment in the range with the services it includes, as they were booked
+func (r *sqlReportRepository) bookedItems(start, end time.Time) *gorm.DB {
+	return r.db.Model(&models.Appointment{}).
+		Joins("JOIN appointment_items ON appointment_items.appointment_id = appointments.id").
+		Where("appointments.date >= ? AND appointments.date < ?", start.In(time.Local), end.In(time.Local))
+}
+
+func (r *sqlReportRepository) CountByStatus(start, end time.Time, bucket models.ReportBucket) ([]models.StatusCount, error) {
+	var rows []models.StatusCount
+	err := r.db.Model(&models.Appointment{}).
+		Select(r.periodExpr(bucket)+" AS period, appointments.status AS status, COUNT(*) AS count").
+		Where("appointments.date >= ? AND appointments.date < ?", start.In(time.Local), end.In(time.Local)).
+		Group("period, appointments.status").
+		Order("period").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// RevenueByPeriod sums the booked price of the services of completed appointments
+func (r *sqlReportRepository) RevenueByPeriod(start, end time.Time, bucket models.ReportBucket) ([]models.PeriodRevenue, error) {
+	var rows []models.PeriodRevenue
+	err := r.bookedItems(start, end).
+		Select(r.periodExpr(bucket)+" AS period, SUM(appointment_items.price) AS revenue").
+		Where("appointments.status = ?", models.StatusDone).
+		Group("period").
+		Order("period").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// TopServices ranks services by bookings, ignoring canceled appointments. Revenue only counts completed ones.
+func (r *sqlReportRepository) TopServices(start, end time.Time, limit int) ([]models.ServiceRanking, error) {
+	var rows []models.ServiceRanking
+	err := r.bookedItems(start, end).
+		Select("appointment_items.service_id AS service_id, MAX(appointment_items.name) AS name, COUNT(*) AS bookings, "+
+			"SUM(CASE WHEN appointments.status = ? THEN appointment_items.price ELSE 0 END) AS revenue", models.StatusDone).
+		Where("appointments.status <> ?", models.StatusCanceled).
+		Group("appointment_items.service_id").
+		Order("bookings DESC, revenue DESC, service_id").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// BusiestHours counts the appointments starting at each hour, ignoring canceled ones
+func (r *sqlReportRepository) BusiestHours(start, end time.Time) ([]models.HourLoad, error) {
+	var rows []models.HourLoad
+	err := r.db.Model(&models.Appointment{}).
+		Select(r.hourExpr()+" AS hour, COUNT(*) AS total").
+		Where("appointments.date >= ? AND appointments.date < ?", start.In(time.Local), end.In(time.Local)).
+		Where("appointments.status <> ?", models.StatusCanceled).
+		Group("hour").
+		Order("total DESC, hour").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedReportAppointments books a week starting on Monday 2030-03-04:
+// Monday 10:00 haircut done, Monday 14:00 haircut + coloring done,
+// Wednesday 10:00 haircut canceled, Monday of the next week 10:00 coloring pending
+func seedReportAppointments(t *testing.T, db *gorm.DB) (time.Time, models.Service, models.Service) {
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 120.00, 90)
+
+	monday := time.Date(2030, 3, 4, 0, 0, 0, 0, time.Local)
+	book := func(services []models.Service, date time.Time, status models.AppointmentStatus) {
+		ap := createTestAppointment(t, db, user.ID, services, date)
+		require.NoError(t, db.Model(&ap).Update("status", status).Error)
+	}
+	book([]models.Service{haircut}, monday.Add(10*time.Hour), models.StatusDone)
+	book([]models.Service{haircut, coloring}, monday.Add(14*time.Hour), models.StatusDone)
+	book([]models.Service{haircut}, monday.AddDate(0, 0, 2).Add(10*time.Hour), models.StatusCanceled)
+	book([]models.Service{coloring}, monday.AddDate(0, 0, 7).Add(10*time.Hour), models.StatusPending)
+	return monday, haircut, coloring
+}
+
+func TestReportRepository_CountByStatus(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReportRepository(db)
+	monday, _, _ := seedReportAppointments(t, db)
+
+	daily, err := repo.CountByStatus(monday, monday.AddDate(0, 0, 14), models.BucketDay)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.StatusCount{
+		{Period: "2030-03-04", Status: models.StatusDone, Count: 2},
+		{Period: "2030-03-06", Status: models.StatusCanceled, Count: 1},
+		{Period: "2030-03-11", Status: models.StatusPending, Count: 1},
+	}, daily)
+
+	weekly, err := repo.CountByStatus(monday, monday.AddDate(0, 0, 14), models.BucketWeek)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.StatusCount{
+		{Period: "2030-03-04", Status: models.StatusDone, Count: 2},
+		{Period: "2030-03-04", Status: models.StatusCanceled, Count: 1},
+		{Period: "2030-03-11", Status: models.StatusPending, Count: 1},
+	}, weekly)
+
+	monthly, err := repo.CountByStatus(monday, monday.AddDate(0, 0, 7), models.BucketMonth)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.StatusCount{
+		{Period: "2030-03-01", Status: models.StatusDone, Count: 2},
+		{Period: "2030-03-01", Status: models.StatusCanceled, Count: 1},
+	}, monthly)
+}
+
+func TestReportRepository_RevenueByPeriod(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReportRepository(db)
+	monday, _, _ := seedReportAppointments(t, db)
+
+	revenue, err := repo.RevenueByPeriod(monday, monday.AddDate(0, 0, 14), models.BucketWeek)
+	require.NoError(t, err)
+	// Only completed appointments earn money
+	assert.Equal(t, []models.PeriodRevenue{{Period: "2030-03-04", Revenue: 220}}, revenue)
+}
+
+func TestReportRepository_TopServices(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReportRepository(db)
+	monday, haircut, coloring := seedReportAppointments(t, db)
+
+	ranking, err := repo.TopServices(monday, monday.AddDate(0, 0, 14), 5)
+	require.NoError(t, err)
+	require.Len(t, ranking, 2)
+	// Ties in bookings are broken by revenue
+	assert.Equal(t, models.ServiceRanking{ServiceID: coloring.ID, Name: "Coloring", Bookings: 2, Revenue: 120}, ranking[0])
+	assert.Equal(t, models.ServiceRanking{ServiceID: haircut.ID, Name: "Haircut", Bookings: 2, Revenue: 100}, ranking[1])
+
+	ranking, err = repo.TopServices(monday, monday.AddDate(0, 0, 14), 1)
+	require.NoError(t, err)
+	assert.Len(t, ranking, 1)
+}
+
+func TestReportRepository_BusiestHours(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReportRepository(db)
+	monday, _, _ := seedReportAppointments(t, db)
+
+	hours, err := repo.BusiestHours(monday, monday.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	assert.Equal(t, []models.HourLoad{{Hour: 10, Total: 2}, {Hour: 14, Total: 1}}, hours)
+}
+
+func TestReportRepository_RevenueUsesBookedPrice(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReportRepository(db)
+	monday, haircut, _ := seedReportAppointments(t, db)
+
+	haircut.Price = 500
+	require.NoError(t, NewServiceRepository(db).Update(haircut))
+
+	revenue, err := repo.RevenueByPeriod(monday, monday.AddDate(0, 0, 7), models.BucketWeek)
+	require.NoError(t, err)
+	assert.Equal(t, []models.PeriodRevenue{{Period: "2030-03-04", Revenue: 220}}, revenue)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &sqlScheduleRepository{db: db}
+}
+
+// ListBusinessHours returns the schedule of the whole week, filling unconfigured days with defaults
+func (r *sqlScheduleRepository) ListBusinessHours() ([]models.BusinessHours, error) {
+	var stored []models.BusinessHours
+	if err := r.db.Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	week := make([]models.BusinessHours, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		week[day] = models.DefaultBusinessHours(day)
+	}
+	for _, hours := range stored {
+		week[hours.Weekday] = hours
+	}
+	return week, nil
+}
+
+func (r *sqlScheduleRepository) FindBusinessHours(weekday time.Weekday) (models.BusinessHours, error) {
+	var hours models.BusinessHours
+	if err := r.db.First(&hours, "weekday = ?", weekday).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DefaultBusinessHours(weekday), nil
+		}
+		return models.BusinessHours{}, err
+	}
+	return hours, nil
+}
+
+func (r *sqlScheduleRepository) SaveBusinessHours(hours []models.BusinessHours) error {
+	if len(hours) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&hours).Error
+}
+
+func (r *sqlScheduleRepository) ListHolidays() ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	if err := r.db.Order("date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *sqlScheduleRepository) CreateHoliday(holiday models.Holiday) (models.Holiday, error) {
+	if err := r.db.Create(&holiday).Error; err != nil {
+		return models.Holiday{}, err
+	}
+	return holiday, nil
+}
+
+func (r *sqlScheduleRepository) DeleteHoliday(id uint) error {
+	result := r.db.Delete(&models.Holiday{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("holiday not found")
+	}
+	return nil
+}
+
+func (r *sqlScheduleRepository) IsHoliday(day time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Holiday{}).Where("date = ?", day.Format(models.DateLayout)).Count(&count).Error
+	return count > 0, err
+}
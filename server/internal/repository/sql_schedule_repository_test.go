@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleRepository_Interface(t *testing.T) {
+	var _ ScheduleRepository = (*sqlScheduleRepository)(nil)
+}
+
+func TestScheduleRepository_ListBusinessHours_Defaults(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScheduleRepository(db)
+
+	week, err := repo.ListBusinessHours()
+	assert.NoError(t, err)
+	require.Len(t, week, 7)
+	assert.False(t, week[time.Sunday].IsOpen)
+	assert.True(t, week[time.Monday].IsOpen)
+	assert.Equal(t, "09:00", week[time.Monday].OpensAt)
+}
+
+func TestScheduleRepository_SaveBusinessHours_Upserts(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScheduleRepository(db)
+
+	require.NoError(t, repo.SaveBusinessHours([]models.BusinessHours{
+		{Weekday: time.Saturday, IsOpen: true, OpensAt: "08:00", ClosesAt: "14:00"},
+	}))
+	require.NoError(t, repo.SaveBusinessHours([]models.BusinessHours{
+		{Weekday: time.Saturday, IsOpen: true, OpensAt: "08:00", ClosesAt: "12:00"},
+	}))
+
+	saturday, err := repo.FindBusinessHours(time.Saturday)
+	assert.NoError(t, err)
+	assert.Equal(t, "12:00", saturday.ClosesAt)
+
+	var count int64
+	db.Model(&models.BusinessHours{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// Days without a row fall back to the default schedule
+	sunday, err := repo.FindBusinessHours(time.Sunday)
+	assert.NoError(t, err)
+	assert.False(t, sunday.IsOpen)
+
+	require.NoError(t, repo.SaveBusinessHours([]models.BusinessHours{
+		{Weekday: time.Sunday, IsOpen: true, OpensAt: "10:00", ClosesAt: "13:00"},
+	}))
+	sunday, err = repo.FindBusinessHours(time.Sunday)
+	assert.NoError(t, err)
+	assert.True(t, sunday.IsOpen)
+	assert.Equal(t, time.Sunday, sunday.Weekday)
+}
+
+func TestScheduleRepository_Holidays(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScheduleRepository(db)
+
+	christmas, err := repo.CreateHoliday(models.Holiday{Date: "2026-12-25", Description: "Natal"})
+	require.NoError(t, err)
+
+	_, err = repo.CreateHoliday(models.Holiday{Date: "2026-12-25", Description: "Duplicado"})
+	assert.Error(t, err, "the same day cannot be registered twice")
+
+	isHoliday, err := repo.IsHoliday(time.Date(2026, 12, 25, 15, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.True(t, isHoliday)
+
+	isHoliday, err = repo.IsHoliday(time.Date(2026, 12, 24, 15, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.False(t, isHoliday)
+
+	require.NoError(t, repo.DeleteHoliday(christmas.ID))
+	assert.Error(t, repo.DeleteHoliday(christmas.ID))
+
+	holidays, err := repo.ListHolidays()
+	assert.NoError(t, err)
+	assert.Empty(t, holidays)
+}
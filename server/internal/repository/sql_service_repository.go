@@ -33,14 +33,33 @@ func (r *sqlServiceRepository) FindByID(id uint) (models.Service, error) {
     return service, nil
 }
 
-func (r *sqlServiceRepository) FindAll() ([]models.Service, error) {
+func (r *sqlServiceRepository) FindByIDs(ids []uint) ([]models.Service, error) {
     var services []models.Service
-    if err := r.db.Find(&services).Error; err != nil {
+    if len(ids) == 0 {
+        return services, nil
+    }
+    if err := r.db.Where("id IN ?", ids).Find(&services).Error; err != nil {
         return nil, err
     }
     return services, nil
 }
 
+func (r *sqlServiceRepository) FindAll(page models.PageRequest) (models.ServicePage, error) {
+    return r.findPage(r.db, page)
+}
+
+// FindAllIncludingInactive also lists the services that were deleted
+func (r *sqlServiceRepository) FindAllIncludingInactive(page models.PageRequest) (models.ServicePage, error) {
+    return r.findPage(r.db.Unscoped(), page)
+}
+
+func (r *sqlServiceRepository) findPage(db *gorm.DB, page models.PageRequest) (models.ServicePage, error) {
+    services, info, err := readPage(db, noDetails, noDetails, "services", "", false, page, func(service models.Service) pageCursor {
+        return byID(service.ID)
+    })
+    return models.ServicePage{Items: services, PageInfo: info}, err
+}
+
 func (r *sqlServiceRepository) Update(service models.Service) error {
     if service.ID == 0 {
         return errors.New("service ID is required for update")
@@ -55,6 +74,18 @@ func (r *sqlServiceRepository) Delete(id uint) error {
     return r.db.Delete(&models.Service{}, id).Error
 }
 
+// Restore makes a deleted service available for booking again
+func (r *sqlServiceRepository) Restore(id uint) (models.Service, error) {
+    result := r.db.Unscoped().Model(&models.Service{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+    if result.Error != nil {
+        return models.Service{}, result.Error
+    }
+    if result.RowsAffected == 0 {
+        return models.Service{}, errors.New("service not found")
+    }
+    return r.FindByID(id)
+}
+
 func (r *sqlServiceRepository) FindByName(name string) (models.Service, error) {
     var service models.Service
     if err := r.db.Where("name = ?", name).First(&service).Error; err != nil {
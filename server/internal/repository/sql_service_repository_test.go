@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRepository_Delete_KeepsRow(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServiceRepository(db)
+
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	createTestService(t, db, "Coloring", 120.00, 90)
+	require.NoError(t, repo.Delete(haircut.ID))
+
+	active, err := repo.FindAll(models.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, active.Items, 1)
+	assert.Equal(t, "Coloring", active.Items[0].Name)
+
+	_, err = repo.FindByID(haircut.ID)
+	assert.Error(t, err)
+
+	all, err := repo.FindAllIncludingInactive(models.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, all.Items, 2)
+	assert.True(t, all.Items[0].DeletedAt.Valid)
+	assert.False(t, all.Items[1].DeletedAt.Valid)
+}
+
+func TestServiceRepository_Restore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServiceRepository(db)
+
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	require.NoError(t, repo.Delete(haircut.ID))
+
+	restored, err := repo.Restore(haircut.ID)
+	require.NoError(t, err)
+	assert.Equal(t, haircut.ID, restored.ID)
+	assert.False(t, restored.DeletedAt.Valid)
+
+	active, err := repo.FindAll(models.PageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, active.Items, 1)
+}
+
+func TestServiceRepository_Restore_NotDeleted(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServiceRepository(db)
+
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+
+	_, err := repo.Restore(haircut.ID)
+	assert.Error(t, err)
+	_, err = repo.Restore(999)
+	assert.Error(t, err)
+}
+
+// TestServiceRepository_Delete_KeepsBookedServices tests that past appointments still show a deleted service
+func TestServiceRepository_Delete_KeepsBookedServices(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewServiceRepository(db)
+	appointmentRepo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	coloring := createTestService(t, db, "Coloring", 120.00, 90)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{haircut, coloring}, time.Now().Add(24*time.Hour))
+	require.NoError(t, repo.Delete(coloring.ID))
+
+	found, err := appointmentRepo.FindByID(ap.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Services, 2)
+	assert.Equal(t, "Coloring", found.Services[1].Name)
+	assert.True(t, found.Services[1].DeletedAt.Valid)
+	assert.Len(t, found.Items, 2)
+
+	var links int64
+	db.Table("appointment_services").Where("appointment_id = ?", ap.ID).Count(&links)
+	assert.Equal(t, int64(2), links)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlSweeperRulesRepository struct {
+	db *gorm.DB
+}
+
+func NewSweeperRulesRepository(db *gorm.DB) SweeperRulesRepository {
+	return &sqlSweeperRulesRepository{db: db}
+}
+
+// Get returns the configured rules, or the default ones while the admin has not saved any
+func (r *sqlSweeperRulesRepository) Get() (models.SweeperRules, error) {
+	var rules models.SweeperRules
+	if err := r.db.First(&rules, 1).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DefaultSweeperRules(), nil
+		}
+		return models.SweeperRules{}, err
+	}
+	return rules, nil
+}
+
+func (r *sqlSweeperRulesRepository) Save(rules models.SweeperRules) (models.SweeperRules, error) {
+	rules.ID = 1
+	if err := r.db.Save(&rules).Error; err != nil {
+		return models.SweeperRules{}, err
+	}
+	return rules, nil
+}
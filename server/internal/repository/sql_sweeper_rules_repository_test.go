@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweeperRulesRepository_GetAndSave(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSweeperRulesRepository(db)
+
+	rules, err := repo.Get()
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultSweeperRules(), rules)
+
+	_, err = repo.Save(models.SweeperRules{ExpirePending: false, AutoComplete: true, CompleteAfterMinutes: 30})
+	require.NoError(t, err)
+
+	rules, err = repo.Get()
+	require.NoError(t, err)
+	assert.False(t, rules.ExpirePending, "disabling a rule must be saved too")
+	assert.True(t, rules.AutoComplete)
+	assert.Equal(t, 30, rules.CompleteAfterMinutes)
+}
@@ -26,7 +26,7 @@ func (r *sqlUserRepository) FindByID(id uint) (models.User, error) {
 	var user models.User
 	if err := r.db.First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, models.ErrUserNotFound
 		}
 		return models.User{}, err
 	}
@@ -37,7 +37,7 @@ func (r *sqlUserRepository) FindByEmail(email string) (models.User, error) {
 	var user models.User
 	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, models.ErrUserNotFound
 		}
 		return models.User{}, err
 	}
@@ -52,12 +52,32 @@ func (r *sqlUserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
 
-func (r *sqlUserRepository) FindAll() ([]models.User, error) {
-	var users []models.User
-	if err := r.db.Find(&users).Error; err != nil {
-		return nil, err
+// Restore reactivates a deleted user
+func (r *sqlUserRepository) Restore(id uint) (models.User, error) {
+	result := r.db.Unscoped().Model(&models.User{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return models.User{}, result.Error
 	}
-	return users, nil
+	if result.RowsAffected == 0 {
+		return models.User{}, models.ErrUserNotFound
+	}
+	return r.FindByID(id)
+}
+
+func (r *sqlUserRepository) FindAll(page models.PageRequest) (models.UserPage, error) {
+	return r.findPage(r.db, page)
+}
+
+// FindAllIncludingInactive also lists the users that were deleted
+func (r *sqlUserRepository) FindAllIncludingInactive(page models.PageRequest) (models.UserPage, error) {
+	return r.findPage(r.db.Unscoped(), page)
+}
+
+func (r *sqlUserRepository) findPage(db *gorm.DB, page models.PageRequest) (models.UserPage, error) {
+	users, info, err := readPage(db, noDetails, noDetails, "users", "", false, page, func(user models.User) pageCursor {
+		return byID(user.ID)
+	})
+	return models.UserPage{Items: users, PageInfo: info}, err
 }
 
 func (r *sqlUserRepository) FindByRole(role models.UserRole) ([]models.User, error) {
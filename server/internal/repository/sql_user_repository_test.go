@@ -2,9 +2,12 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // Note: Full database integration tests require CGO_ENABLED=1
@@ -18,13 +21,13 @@ func TestUserRepository_Interface(t *testing.T) {
 
 // Mock-based tests for UserRepository behavior
 type MockUserRepositoryBehavior struct {
-	users map[uint]models.User
+	users  map[uint]models.User
 	nextID uint
 }
 
 func NewMockUserRepositoryBehavior() *MockUserRepositoryBehavior {
 	return &MockUserRepositoryBehavior{
-		users: make(map[uint]models.User),
+		users:  make(map[uint]models.User),
 		nextID: 1,
 	}
 }
@@ -217,3 +220,93 @@ func TestUserRepository_FindByRole(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, admins, 1)
 }
+
+// TestSQLUserRepository_Delete_KeepsHistory tests that a deleted customer is hidden but their appointments still show them
+func TestSQLUserRepository_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	_, err := repo.FindByEmail("missing@example.com")
+	assert.ErrorIs(t, err, models.ErrUserNotFound)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.FindByID(999)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestSQLUserRepository_Delete_KeepsHistory(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	appointmentRepo := NewAppointmentRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	createTestUser(t, db, "other@example.com")
+	haircut := createTestService(t, db, "Haircut", 50.00, 30)
+	ap := createTestAppointment(t, db, user.ID, []models.Service{haircut}, time.Now().Add(24*time.Hour))
+	require.NoError(t, repo.Delete(user.ID))
+
+	_, err := repo.FindByEmail(user.Email)
+	assert.Error(t, err, "a deleted user must not be able to log in")
+	active, err := repo.FindAll(models.PageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, active.Items, 1)
+	all, err := repo.FindAllIncludingInactive(models.PageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, all.Items, 2)
+
+	found, err := appointmentRepo.FindByID(ap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, found.User.Email)
+	history, err := appointmentRepo.ListByPeriodAndUser(user.ID, time.Now(), time.Now().Add(48*time.Hour), models.PageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, history.Items, 1)
+}
+
+func TestSQLUserRepository_Restore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	user := createTestUser(t, db, "customer@example.com")
+	require.NoError(t, repo.Delete(user.ID))
+
+	restored, err := repo.Restore(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, restored.Email)
+	assert.False(t, restored.DeletedAt.Valid)
+
+	_, err = repo.FindByEmail(user.Email)
+	assert.NoError(t, err)
+	_, err = repo.Restore(user.ID)
+	assert.Error(t, err, "an active user cannot be restored")
+}
+
+func TestSQLUserRepository_FindAll_Pages(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	first := createTestUser(t, db, "first@example.com")
+	second := createTestUser(t, db, "second@example.com")
+	third := createTestUser(t, db, "third@example.com")
+	require.NoError(t, repo.Delete(second.ID))
+
+	page, err := repo.FindAllIncludingInactive(models.PageRequest{Limit: 2, WithTotal: true})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, first.ID, page.Items[0].ID)
+	assert.Equal(t, second.ID, page.Items[1].ID)
+	require.NotNil(t, page.Total)
+	assert.Equal(t, int64(3), *page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = repo.FindAllIncludingInactive(models.PageRequest{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, third.ID, page.Items[0].ID)
+	assert.Empty(t, page.NextCursor)
+	assert.Nil(t, page.Total, "the total is only counted when asked for")
+
+	// The deleted user is neither listed nor counted
+	page, err = repo.FindAll(models.PageRequest{WithTotal: true})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, int64(2), *page.Total)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlWaitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &sqlWaitlistRepository{db: db}
+}
+
+// withOfferDetails preloads the entry of the offer with the customer and the services they wait for
+func withOfferDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Entry.User", unscoped).Preload("Entry.Services", unscoped)
+}
+
+func (r *sqlWaitlistRepository) Create(entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+	if err := r.db.Create(&entry).Error; err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	return entry, nil
+}
+
+func (r *sqlWaitlistRepository) FindByID(id uint) (models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.db.Preload("Services", unscoped).First(&entry, id).Error
+	return entry, err
+}
+
+func (r *sqlWaitlistRepository) ListByUser(userID uint) ([]models.WaitlistEntry, error) {
+	var list []models.WaitlistEntry
+	err := r.db.Preload("Services", unscoped).Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) ListWaiting(day string) ([]models.WaitlistEntry, error) {
+	var list []models.WaitlistEntry
+	err := r.db.Preload("Services").
+		Where("status = ? AND start_date <= ? AND end_date >= ?", models.WaitlistWaiting, day, day).
+		Order("created_at, id").
+		Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) ChangeStatus(id uint, from, to models.WaitlistStatus) (bool, error) {
+	result := r.db.Model(&models.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+// CreateOffer stores the times in the local zone, like the appointment dates they are compared with
+func (r *sqlWaitlistRepository) CreateOffer(offer models.WaitlistOffer) (models.WaitlistOffer, error) {
+	offer.Date = offer.Date.In(time.Local)
+	offer.ExpiresAt = offer.ExpiresAt.In(time.Local)
+	if err := r.db.Omit("Entry").Create(&offer).Error; err != nil {
+		return models.WaitlistOffer{}, err
+	}
+	return offer, nil
+}
+
+func (r *sqlWaitlistRepository) FindOffer(id uint) (models.WaitlistOffer, error) {
+	var offer models.WaitlistOffer
+	err := withOfferDetails(r.db).First(&offer, id).Error
+	return offer, err
+}
+
+func (r *sqlWaitlistRepository) ListPendingOffers(userID uint, now time.Time) ([]models.WaitlistOffer, error) {
+	var list []models.WaitlistOffer
+	err := withOfferDetails(r.db).
+		Where("user_id = ? AND status = ? AND expires_at > ?", userID, models.OfferPending, now.In(time.Local)).
+		Order("date").
+		Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) ListOffersBySource(appointmentID uint) ([]models.WaitlistOffer, error) {
+	var list []models.WaitlistOffer
+	err := r.db.Where("source_appointment_id = ?", appointmentID).Order("id").Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) ListHolds(start, end, now time.Time) ([]models.WaitlistOffer, error) {
+	var list []models.WaitlistOffer
+	err := r.db.Where("status = ? AND expires_at > ? AND date BETWEEN ? AND ?", models.OfferPending, now.In(time.Local), start.In(time.Local), end.In(time.Local)).
+		Order("date").
+		Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) ListExpiredOffers(now time.Time) ([]models.WaitlistOffer, error) {
+	var list []models.WaitlistOffer
+	err := withOfferDetails(r.db).Where("status = ? AND expires_at <= ?", models.OfferPending, now.In(time.Local)).Order("expires_at").Find(&list).Error
+	return list, err
+}
+
+func (r *sqlWaitlistRepository) CloseOffer(id uint, status models.WaitlistOfferStatus) (bool, error) {
+	result := r.db.Model(&models.WaitlistOffer{}).
+		Where("id = ? AND status = ?", id, models.OfferPending).
+		Update("status", status)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *sqlWaitlistRepository) SetOfferAppointment(id, appointmentID uint) error {
+	return r.db.Model(&models.WaitlistOffer{}).Where("id = ?", id).Update("appointment_id", appointmentID).Error
+}
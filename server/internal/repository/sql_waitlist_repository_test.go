@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createTestEntry(t *testing.T, repo WaitlistRepository, userID uint, services []models.Service, start, end string) models.WaitlistEntry {
+	entry, err := repo.Create(models.WaitlistEntry{
+		UserID:      userID,
+		Services:    services,
+		StartDate:   start,
+		EndDate:     end,
+		WindowStart: "09:00",
+		WindowEnd:   "18:00",
+		Status:      models.WaitlistWaiting,
+	})
+	require.NoError(t, err)
+	return entry
+}
+
+func TestWaitlistRepository_ListWaiting(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWaitlistRepository(db)
+	user := createTestUser(t, db, "maria@example.com")
+	service := createTestService(t, db, "Corte", 50, 30)
+
+	first := createTestEntry(t, repo, user.ID, []models.Service{service}, "2030-05-01", "2030-05-10")
+	second := createTestEntry(t, repo, user.ID, []models.Service{service}, "2030-05-05", "2030-05-05")
+	createTestEntry(t, repo, user.ID, []models.Service{service}, "2030-05-06", "2030-05-20")
+	left := createTestEntry(t, repo, user.ID, []models.Service{service}, "2030-05-01", "2030-05-31")
+	changed, err := repo.ChangeStatus(left.ID, models.WaitlistWaiting, models.WaitlistLeft)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	list, err := repo.ListWaiting("2030-05-05")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, first.ID, list[0].ID, "the customers who joined first come first")
+	assert.Equal(t, second.ID, list[1].ID)
+	assert.Equal(t, "Corte", list[0].Services[0].Name)
+
+	// The entry is no longer waiting
+	changed, err = repo.ChangeStatus(left.ID, models.WaitlistWaiting, models.WaitlistOffered)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	mine, err := repo.ListByUser(user.ID)
+	require.NoError(t, err)
+	assert.Len(t, mine, 4)
+}
+
+func TestWaitlistRepository_Offers(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWaitlistRepository(db)
+	user := createTestUser(t, db, "maria@example.com")
+	service := createTestService(t, db, "Corte", 50, 30)
+	entry := createTestEntry(t, repo, user.ID, []models.Service{service}, "2030-05-01", "2030-05-10")
+
+	now := time.Date(2030, 5, 1, 12, 0, 0, 0, time.Local)
+	slot := time.Date(2030, 5, 3, 10, 0, 0, 0, time.Local)
+	offer, err := repo.CreateOffer(models.WaitlistOffer{
+		EntryID:             entry.ID,
+		UserID:              user.ID,
+		SourceAppointmentID: 9,
+		Date:                slot,
+		DurationMinutes:     30,
+		Status:              models.OfferPending,
+		ExpiresAt:           now.Add(2 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindOffer(offer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "maria@example.com", found.Entry.User.Email)
+	assert.Len(t, found.Entry.Services, 1)
+
+	holds, err := repo.ListHolds(slot.Add(-time.Hour), slot.Add(time.Hour), now)
+	require.NoError(t, err)
+	assert.Len(t, holds, 1)
+	holds, err = repo.ListHolds(slot.Add(-time.Hour), slot.Add(time.Hour), now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, holds, "an expired offer no longer holds the slot")
+
+	pending, err := repo.ListPendingOffers(user.ID, now)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+	bySource, err := repo.ListOffersBySource(9)
+	require.NoError(t, err)
+	assert.Len(t, bySource, 1)
+
+	expired, err := repo.ListExpiredOffers(now.Add(3 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+
+	closed, err := repo.CloseOffer(offer.ID, models.OfferExpired)
+	require.NoError(t, err)
+	assert.True(t, closed)
+	// An expired offer cannot be accepted anymore
+	closed, err = repo.CloseOffer(offer.ID, models.OfferAccepted)
+	require.NoError(t, err)
+	assert.False(t, closed)
+
+	require.NoError(t, repo.SetOfferAppointment(offer.ID, 12))
+	found, err = repo.FindOffer(offer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OfferExpired, found.Status)
+	assert.Equal(t, uint(12), *found.AppointmentID)
+
+	_, err = repo.FindOffer(999)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
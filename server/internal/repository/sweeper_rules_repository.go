@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+
+type SweeperRulesRepository interface {
+	Get() (models.SweeperRules, error)
+	Save(rules models.SweeperRules) (models.SweeperRules, error)
+}
@@ -10,6 +10,9 @@ type UserRepository interface {
 	FindByEmail(email string) (models.User, error)
 	Update(user models.User) error
 	Delete(id uint) error
-	FindAll() ([]models.User, error)
+	Restore(id uint) (models.User, error)
+	// FindAll and FindAllIncludingInactive return pages in ID order
+	FindAll(page models.PageRequest) (models.UserPage, error)
+	FindAllIncludingInactive(page models.PageRequest) (models.UserPage, error)
 	FindByRole(role models.UserRole) ([]models.User, error)
 }
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+type WaitlistRepository interface {
+	Create(entry models.WaitlistEntry) (models.WaitlistEntry, error)
+	FindByID(id uint) (models.WaitlistEntry, error)
+	ListByUser(userID uint) ([]models.WaitlistEntry, error)
+	// ListWaiting returns the entries still waiting whose period includes the day, in the order they joined
+	ListWaiting(day string) ([]models.WaitlistEntry, error)
+	// ChangeStatus only updates an entry still in the from status, returning whether it did
+	ChangeStatus(id uint, from, to models.WaitlistStatus) (bool, error)
+
+	CreateOffer(offer models.WaitlistOffer) (models.WaitlistOffer, error)
+	FindOffer(id uint) (models.WaitlistOffer, error)
+	// ListPendingOffers returns the offers of the user that can still be accepted at now
+	ListPendingOffers(userID uint, now time.Time) ([]models.WaitlistOffer, error)
+	// ListOffersBySource returns every offer made for the slot freed by the appointment
+	ListOffersBySource(appointmentID uint) ([]models.WaitlistOffer, error)
+	// ListHolds returns the offers still holding their slot at now, starting within the period
+	ListHolds(start, end, now time.Time) ([]models.WaitlistOffer, error)
+	// ListExpiredOffers returns the pending offers whose time ran out at now
+	ListExpiredOffers(now time.Time) ([]models.WaitlistOffer, error)
+	// CloseOffer only updates a pending offer, so it cannot be both accepted and expired, returning whether it did
+	CloseOffer(id uint, status models.WaitlistOfferStatus) (bool, error)
+	SetOfferAppointment(id, appointmentID uint) error
+}
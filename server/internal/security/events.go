@@ -0,0 +1,73 @@
+// Package security records events that matter when investigating attacks on user accounts
+package security
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// EventType identifies what happened
+type EventType string
+
+const (
+	EventLoginFailed     EventType = "login_failed"
+	EventLoginThrottled  EventType = "login_throttled"
+	EventAccountLocked   EventType = "account_locked"
+	EventIPLocked        EventType = "ip_locked"
+	EventAccountUnlocked EventType = "account_unlocked"
+)
+
+// Event is a security relevant fact. Empty fields are left out of the record.
+type Event struct {
+	Type   EventType
+	Email  string
+	IP     string
+	UserID *uint
+	// ActorID is the admin who performed the action, if any
+	ActorID  *uint
+	Reason   string
+	Failures int
+	Until    time.Time
+}
+
+// Recorder stores security events, for example in a log collected by the monitoring tools
+type Recorder interface {
+	Record(ctx context.Context, event Event)
+}
+
+type logRecorder struct {
+	logger *slog.Logger
+}
+
+// NewLogRecorder writes each event as a JSON line
+func NewLogRecorder(w io.Writer) Recorder {
+	return &logRecorder{logger: slog.New(slog.NewJSONHandler(w, nil))}
+}
+
+func (r *logRecorder) Record(ctx context.Context, event Event) {
+	attrs := []slog.Attr{slog.String("event", string(event.Type))}
+	if event.Email != "" {
+		attrs = append(attrs, slog.String("email", event.Email))
+	}
+	if event.IP != "" {
+		attrs = append(attrs, slog.String("ip", event.IP))
+	}
+	if event.UserID != nil {
+		attrs = append(attrs, slog.Uint64("user_id", uint64(*event.UserID)))
+	}
+	if event.ActorID != nil {
+		attrs = append(attrs, slog.Uint64("actor_id", uint64(*event.ActorID)))
+	}
+	if event.Reason != "" {
+		attrs = append(attrs, slog.String("reason", event.Reason))
+	}
+	if event.Failures > 0 {
+		attrs = append(attrs, slog.Int("failures", event.Failures))
+	}
+	if !event.Until.IsZero() {
+		attrs = append(attrs, slog.Time("until", event.Until))
+	}
+	r.logger.LogAttrs(ctx, slog.LevelWarn, "security event", attrs...)
+}
@@ -0,0 +1,40 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRecorder_Record(t *testing.T) {
+	out := &bytes.Buffer{}
+	recorder := NewLogRecorder(out)
+	userID := uint(7)
+	until := time.Date(2026, 3, 14, 15, 30, 0, 0, time.UTC)
+
+	recorder.Record(context.Background(), Event{Type: EventAccountLocked, Email: "maria@example.com", IP: "10.0.0.1", UserID: &userID, Failures: 10, Until: until})
+	recorder.Record(context.Background(), Event{Type: EventLoginFailed, IP: "10.0.0.1", Reason: "unknown_email"})
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &first))
+	assert.Equal(t, "account_locked", first["event"])
+	assert.Equal(t, "maria@example.com", first["email"])
+	assert.Equal(t, "10.0.0.1", first["ip"])
+	assert.Equal(t, float64(7), first["user_id"])
+	assert.Equal(t, float64(10), first["failures"])
+	assert.Equal(t, "2026-03-14T15:30:00Z", first["until"])
+
+	var second map[string]any
+	require.NoError(t, json.Unmarshal(lines[1], &second))
+	assert.Equal(t, "unknown_email", second["reason"])
+	assert.NotContains(t, second, "email")
+	assert.NotContains(t, second, "user_id")
+}
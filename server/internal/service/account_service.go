@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// PasswordResetTTL is how long a password reset link can be used
+	PasswordResetTTL = time.Hour
+	// EmailVerificationTTL is how long an email verification link can be used
+	EmailVerificationTTL = 48 * time.Hour
+)
+
+var (
+	// DefaultResetEmailThrottle bounds the reset emails sent to a single address, whether it is registered or not
+	DefaultResetEmailThrottle = models.ThrottlePolicy{
+		FreeAttempts: 3,
+		BaseDelay:    15 * time.Minute,
+		MaxDelay:     time.Hour,
+		Window:       time.Hour,
+	}
+	// DefaultResetIPThrottle bounds the reset requests of a single client, whatever the addresses it asks for
+	DefaultResetIPThrottle = models.ThrottlePolicy{
+		FreeAttempts: 10,
+		BaseDelay:    15 * time.Minute,
+		MaxDelay:     time.Hour,
+		Window:       time.Hour,
+	}
+)
+
+// AccountService handles the flows confirmed through a link sent by email
+type AccountService interface {
+	// RequestPasswordReset queues an email with a reset link. Unknown or inactive emails are ignored, so callers
+	// cannot find out which emails are registered. It returns a *models.RequestThrottledError while the email
+	// or the IP address asked too often.
+	RequestPasswordReset(email, ip string) error
+	// ResetPassword sets the new password and ends every session of the user
+	ResetPassword(token, password string) error
+	// SendVerification emails a new verification link, the previous ones stop working
+	SendVerification(userID uint) error
+	VerifyEmail(token string) error
+}
+
+type accountService struct {
+	userRepo     repository.UserRepository
+	tokenRepo    repository.AccountTokenRepository
+	throttleRepo repository.LoginThrottleRepository
+	sessionSvc   SessionService
+	renderer     *notification.Renderer
+	notifier     notification.Notifier
+	// appURL is the address of the web app, where the links sent by email point to
+	appURL        string
+	emailThrottle models.ThrottlePolicy
+	ipThrottle    models.ThrottlePolicy
+	now           func() time.Time
+}
+
+// NewAccountService builds the service. The notifier should queue the messages, as the notification dispatcher
+// does, so that the answer takes as long whether an email is sent or not.
+func NewAccountService(userRepo repository.UserRepository, tokenRepo repository.AccountTokenRepository, throttleRepo repository.LoginThrottleRepository, sessionSvc SessionService, renderer *notification.Renderer, notifier notification.Notifier, appURL string) AccountService {
+	return &accountService{
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		throttleRepo:  throttleRepo,
+		sessionSvc:    sessionSvc,
+		renderer:      renderer,
+		notifier:      notifier,
+		appURL:        strings.TrimSuffix(appURL, "/"),
+		emailThrottle: DefaultResetEmailThrottle,
+		ipThrottle:    DefaultResetIPThrottle,
+		now:           time.Now,
+	}
+}
+
+func (s *accountService) RequestPasswordReset(email, ip string) error {
+	now := s.now()
+	if err := s.throttle(models.PasswordResetIPThrottleKey(ip), s.ipThrottle, now); err != nil {
+		return err
+	}
+	if err := s.throttle(models.PasswordResetThrottleKey(normalizeEmail(email)), s.emailThrottle, now); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+	return s.send(user, models.PurposePasswordReset)
+}
+
+func (s *accountService) ResetPassword(token, password string) error {
+	user, err := s.consume(models.PurposePasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashed)
+	user.MustChangePassword = false
+	// Opening the link proves the user owns the email
+	if !user.EmailVerified() {
+		now := s.now()
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateUser(user.ID, models.PurposePasswordReset, s.now()); err != nil {
+		return err
+	}
+	return s.sessionSvc.LogoutAll(user.ID)
+}
+
+func (s *accountService) SendVerification(userID uint) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return models.ErrEmailAlreadyVerified
+	}
+	return s.send(user, models.PurposeEmailVerification)
+}
+
+func (s *accountService) VerifyEmail(token string) error {
+	user, err := s.consume(models.PurposeEmailVerification, token)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+	now := s.now()
+	user.EmailVerifiedAt = &now
+	return s.userRepo.Update(user)
+}
+
+// send replaces the pending tokens of the user for the purpose by a new one and emails its link
+func (s *accountService) send(user models.User, purpose models.AccountTokenPurpose) error {
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateUser(user.ID, purpose, s.now()); err != nil {
+		return err
+	}
+
+	ttl, event, path := PasswordResetTTL, notification.EventPasswordReset, "/reset-password"
+	if purpose == models.PurposeEmailVerification {
+		ttl, event, path = EmailVerificationTTL, notification.EventEmailVerification, "/verify-email"
+	}
+	_, err = s.tokenRepo.Create(models.AccountToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: s.now().Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.renderer.RenderAccount(event, user, s.appURL+path+"?token="+url.QueryEscape(token), ttl)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Send(context.Background(), msg)
+}
+
+// throttle counts the request against the key, unless the key must still wait
+func (s *accountService) throttle(key string, policy models.ThrottlePolicy, now time.Time) error {
+	var until time.Time
+	_, err := s.throttleRepo.Modify(key, func(throttle *models.LoginThrottle) {
+		if until = policy.BlockedUntil(*throttle, now); until.IsZero() {
+			policy.RecordFailure(throttle, now)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() {
+		return &models.RequestThrottledError{Until: until}
+	}
+	return nil
+}
+
+// consume marks the token as used and returns its user, as long as the token is still valid
+func (s *accountService) consume(purpose models.AccountTokenPurpose, token string) (models.User, error) {
+	if token == "" {
+		return models.User{}, models.ErrInvalidAccountToken
+	}
+	found, err := s.tokenRepo.FindByHash(purpose, hashToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, models.ErrInvalidAccountToken
+	}
+	if err != nil {
+		return models.User{}, err
+	}
+	if found.UsedAt != nil || !s.now().Before(found.ExpiresAt) {
+		return models.User{}, models.ErrInvalidAccountToken
+	}
+
+	user, err := s.userRepo.FindByID(found.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !user.IsActive) {
+		return models.User{}, models.ErrInvalidAccountToken
+	}
+	if err != nil {
+		return models.User{}, err
+	}
+
+	used, err := s.tokenRepo.MarkUsed(found.ID, s.now())
+	if err != nil {
+		return models.User{}, err
+	}
+	if !used {
+		return models.User{}, models.ErrInvalidAccountToken
+	}
+	return user, nil
+}
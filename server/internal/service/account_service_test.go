@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type accountMocks struct {
+	users     *mocks.MockUserRepository
+	tokens    *mocks.MockAccountTokenRepository
+	sessions  *mocks.MockSessionService
+	throttles map[string]models.LoginThrottle
+}
+
+func newTestAccountService(t *testing.T, ctrl *gomock.Controller, out *bytes.Buffer) (AccountService, accountMocks) {
+	renderer, err := notification.NewRenderer(notification.DefaultLocale)
+	require.NoError(t, err)
+	m := accountMocks{
+		users:     mocks.NewMockUserRepository(ctrl),
+		tokens:    mocks.NewMockAccountTokenRepository(ctrl),
+		sessions:  mocks.NewMockSessionService(ctrl),
+		throttles: map[string]models.LoginThrottle{},
+	}
+	throttleRepo := mocks.NewMockLoginThrottleRepository(ctrl)
+	throttleRepo.EXPECT().Modify(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, change func(*models.LoginThrottle)) (models.LoginThrottle, error) {
+		throttle, ok := m.throttles[key]
+		if !ok {
+			throttle = models.LoginThrottle{Key: key}
+		}
+		change(&throttle)
+		m.throttles[key] = throttle
+		return throttle, nil
+	}).AnyTimes()
+	return NewAccountService(m.users, m.tokens, throttleRepo, m.sessions, renderer, notification.NewLogNotifier(out), "http://localhost:5173/"), m
+}
+
+var linkToken = regexp.MustCompile(`\?token=([0-9a-f]+)`)
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	out := &bytes.Buffer{}
+	svc, m := newTestAccountService(t, ctrl, out)
+
+	user := models.User{ID: 1, Name: "Maria", Email: "maria@example.com", IsActive: true}
+	m.users.EXPECT().FindByEmail("maria@example.com").Return(user, nil)
+	m.tokens.EXPECT().InvalidateUser(uint(1), models.PurposePasswordReset, gomock.Any()).Return(nil)
+	var stored models.AccountToken
+	m.tokens.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.AccountToken) (models.AccountToken, error) {
+		stored = token
+		return token, nil
+	})
+
+	require.NoError(t, svc.RequestPasswordReset("maria@example.com", "203.0.113.7"))
+
+	assert.Contains(t, out.String(), "http://localhost:5173/reset-password?token=")
+	match := linkToken.FindStringSubmatch(out.String())
+	require.Len(t, match, 2)
+	assert.Equal(t, hashToken(match[1]), stored.TokenHash, "only the hash of the emailed token is stored")
+	assert.Equal(t, models.PurposePasswordReset, stored.Purpose)
+	assert.WithinDuration(t, time.Now().Add(PasswordResetTTL), stored.ExpiresAt, time.Minute)
+}
+
+func TestAccountService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	out := &bytes.Buffer{}
+	svc, m := newTestAccountService(t, ctrl, out)
+
+	m.users.EXPECT().FindByEmail("ghost@example.com").Return(models.User{}, gorm.ErrRecordNotFound)
+	m.users.EXPECT().FindByEmail("inactive@example.com").Return(models.User{ID: 2, IsActive: false}, nil)
+
+	assert.NoError(t, svc.RequestPasswordReset("ghost@example.com", "203.0.113.7"))
+	assert.NoError(t, svc.RequestPasswordReset("inactive@example.com", "203.0.113.7"))
+	assert.Empty(t, out.String())
+}
+
+func TestAccountService_RequestPasswordReset_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestAccountService(t, ctrl, &bytes.Buffer{})
+	m.users.EXPECT().FindByEmail(gomock.Any()).Return(models.User{}, gorm.ErrRecordNotFound).AnyTimes()
+
+	// The same address, even written another way, gets a few emails an hour
+	for i := 0; i < DefaultResetEmailThrottle.FreeAttempts; i++ {
+		require.NoError(t, svc.RequestPasswordReset("ghost@example.com", "203.0.113.7"))
+	}
+	err := svc.RequestPasswordReset(" Ghost@Example.com", "198.51.100.1")
+	var throttled *models.RequestThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.WithinDuration(t, time.Now().Add(DefaultResetEmailThrottle.BaseDelay), throttled.Until, time.Minute)
+
+	// The same client gets a few more, whatever the addresses
+	for i := DefaultResetEmailThrottle.FreeAttempts; i < DefaultResetIPThrottle.FreeAttempts; i++ {
+		require.NoError(t, svc.RequestPasswordReset(fmt.Sprintf("user%d@example.com", i), "203.0.113.7"))
+	}
+	require.ErrorAs(t, svc.RequestPasswordReset("other@example.com", "203.0.113.7"), &throttled)
+	assert.NotContains(t, m.throttles, models.PasswordResetThrottleKey("other@example.com"), "a blocked client does not count against the address")
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestAccountService(t, ctrl, &bytes.Buffer{})
+
+	m.tokens.EXPECT().FindByHash(models.PurposePasswordReset, hashToken("token")).
+		Return(models.AccountToken{ID: 5, UserID: 1, Purpose: models.PurposePasswordReset, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	m.users.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Password: "old", IsActive: true}, nil)
+	m.tokens.EXPECT().MarkUsed(uint(5), gomock.Any()).Return(true, nil)
+	m.users.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("newpassword")))
+		assert.True(t, user.EmailVerified(), "the reset link proves the email belongs to the user")
+		return nil
+	})
+	m.tokens.EXPECT().InvalidateUser(uint(1), models.PurposePasswordReset, gomock.Any()).Return(nil)
+	m.sessions.EXPECT().LogoutAll(uint(1)).Return(nil)
+
+	assert.NoError(t, svc.ResetPassword("token", "newpassword"))
+}
+
+func TestAccountService_ResetPassword_InvalidToken(t *testing.T) {
+	used := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name  string
+		token models.AccountToken
+		err   error
+	}{
+		{"unknown", models.AccountToken{}, gorm.ErrRecordNotFound},
+		{"expired", models.AccountToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil},
+		{"used", models.AccountToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc, m := newTestAccountService(t, ctrl, &bytes.Buffer{})
+
+			m.tokens.EXPECT().FindByHash(models.PurposePasswordReset, hashToken("token")).Return(tt.token, tt.err)
+
+			assert.ErrorIs(t, svc.ResetPassword("token", "newpassword"), models.ErrInvalidAccountToken)
+		})
+	}
+}
+
+func TestAccountService_ResetPassword_ConcurrentUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestAccountService(t, ctrl, &bytes.Buffer{})
+
+	m.tokens.EXPECT().FindByHash(models.PurposePasswordReset, gomock.Any()).
+		Return(models.AccountToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	m.users.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, IsActive: true}, nil)
+	m.tokens.EXPECT().MarkUsed(uint(5), gomock.Any()).Return(false, nil)
+
+	assert.ErrorIs(t, svc.ResetPassword("token", "newpassword"), models.ErrInvalidAccountToken)
+}
+
+func TestAccountService_SendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	out := &bytes.Buffer{}
+	svc, m := newTestAccountService(t, ctrl, out)
+
+	verified := time.Now()
+	m.users.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Name: "Maria", Email: "maria@example.com"}, nil)
+	m.users.EXPECT().FindByID(uint(2)).Return(models.User{ID: 2, EmailVerifiedAt: &verified}, nil)
+	m.tokens.EXPECT().InvalidateUser(uint(1), models.PurposeEmailVerification, gomock.Any()).Return(nil)
+	m.tokens.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.AccountToken) (models.AccountToken, error) {
+		assert.Equal(t, models.PurposeEmailVerification, token.Purpose)
+		assert.WithinDuration(t, time.Now().Add(EmailVerificationTTL), token.ExpiresAt, time.Minute)
+		return token, nil
+	})
+
+	require.NoError(t, svc.SendVerification(1))
+	assert.Contains(t, out.String(), "http://localhost:5173/verify-email?token=")
+	assert.ErrorIs(t, svc.SendVerification(2), models.ErrEmailAlreadyVerified)
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestAccountService(t, ctrl, &bytes.Buffer{})
+
+	m.tokens.EXPECT().FindByHash(models.PurposeEmailVerification, hashToken("token")).
+		Return(models.AccountToken{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	m.users.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, IsActive: true}, nil)
+	m.tokens.EXPECT().MarkUsed(uint(5), gomock.Any()).Return(true, nil)
+	m.users.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+		assert.True(t, user.EmailVerified())
+		return nil
+	})
+
+	assert.NoError(t, svc.VerifyEmail("token"))
+	assert.ErrorIs(t, svc.VerifyEmail(""), models.ErrInvalidAccountToken)
+}
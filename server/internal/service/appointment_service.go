@@ -1,29 +1,84 @@
 package service
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
 )
 
 type AppointmentService interface {
-	CreateAppointment(userID uint, services []models.Service, date time.Time) (created models.Appointment, suggestion *models.Appointment, res error)
-	UpdateAppointment(id uint, newAp models.Appointment, role models.UserRole) (models.Appointment, error)
-	ListHistory(start, end time.Time) ([]models.Appointment, error)
-	ListUserHistory(userID uint, start, end time.Time) ([]models.Appointment, error)
-	ListAll() ([]models.Appointment, error)
-	ChangeStatus(id uint, status models.AppointmentStatus) (models.Appointment, error)
-	GetWeeklyPerformance() (int, int, error)
-	MergeAppointments(existingID uint, newServices []models.Service) (models.Appointment, error)
+	CreateAppointment(userID uint, services []models.Service, date time.Time, professionalID *uint) (created models.Appointment, suggestion *models.Appointment, res error)
+	// CreateSeries books the services on every date of the recurrence, starting at date. The dates that cannot
+	// be booked are reported as conflicts, models.ErrSeriesUnavailable is returned when none can.
+	CreateSeries(userID uint, services []models.Service, date time.Time, professionalID *uint, rule models.Recurrence) (models.SeriesResult, error)
+	UpdateAppointment(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.Appointment, error)
+	// UpdateFollowing applies the changes to the appointment and to the later ones of its series. They keep
+	// their own dates, moved by as many days as the appointment and to its new time of day.
+	UpdateFollowing(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.SeriesResult, error)
+	ListHistory(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	// SearchAppointments returns a page of the appointments matching the staff search
+	SearchAppointments(query models.AppointmentQuery) (models.AppointmentPage, error)
+	ListUserHistory(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	// ListProfessionalAgenda returns the appointments assigned to the professional linked to the user
+	ListProfessionalAgenda(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error)
+	ListAll(page models.PageRequest) (models.AppointmentPage, error)
+	ChangeStatus(claims *models.CustomClaims, id uint, status models.AppointmentStatus) (models.Appointment, error)
+	CancelAppointment(claims *models.CustomClaims, id uint) (models.Appointment, error)
+	// CancelFollowing cancels the appointment and the later ones of its series
+	CancelFollowing(claims *models.CustomClaims, id uint) (models.SeriesResult, error)
+	// CancelUpcoming cancels the pending and confirmed appointments of the user that have not started yet, as
+	// when the user closes the account. No fee is charged.
+	CancelUpcoming(userID uint) error
+	GetStatusHistory(claims *models.CustomClaims, id uint) ([]models.AppointmentStatusHistory, error)
+	MergeAppointments(claims *models.CustomClaims, existingID uint, newServices []models.Service) (models.Appointment, error)
 }
 
 type appointmentService struct {
-	repo repository.AppointmentRepository
+	repo             repository.AppointmentRepository
+	serviceRepo      repository.ServiceRepository
+	professionalRepo repository.ProfessionalRepository
+	scheduleRepo     repository.ScheduleRepository
+	cancellationRepo repository.CancellationPolicyRepository
+	waitlistRepo     repository.WaitlistRepository
+	notifier         notification.AppointmentNotifier
+	policy           Policy
 }
 
-func NewAppointmentService(repo repository.AppointmentRepository) AppointmentService {
-	return &appointmentService{repo}
+// NewAppointmentService builds the service. The notifier may be nil, in which case customers are not notified,
+// and so may the waitlist repository, in which case no slot is held for waitlisted customers.
+func NewAppointmentService(repo repository.AppointmentRepository, serviceRepo repository.ServiceRepository, professionalRepo repository.ProfessionalRepository, scheduleRepo repository.ScheduleRepository, cancellationRepo repository.CancellationPolicyRepository, waitlistRepo repository.WaitlistRepository, notifier notification.AppointmentNotifier) AppointmentService {
+	return &appointmentService{
+		repo:             repo,
+		serviceRepo:      serviceRepo,
+		professionalRepo: professionalRepo,
+		scheduleRepo:     scheduleRepo,
+		cancellationRepo: cancellationRepo,
+		waitlistRepo:     waitlistRepo,
+		notifier:         notifier,
+		policy:           NewAppointmentPolicy(),
+	}
+}
+
+// snapshotItems books the services at their current catalog values, keeping the values
+// of the ones that were already booked
+func snapshotItems(services []models.Service, booked []models.AppointmentItem) []models.AppointmentItem {
+	previous := make(map[uint][]models.AppointmentItem)
+	for _, item := range booked {
+		previous[item.ServiceID] = append(previous[item.ServiceID], item)
+	}
+	items := make([]models.AppointmentItem, 0, len(services))
+	for _, service := range services {
+		if kept := previous[service.ID]; len(kept) > 0 {
+			items = append(items, kept[0])
+			previous[service.ID] = kept[1:]
+			continue
+		}
+		items = append(items, models.NewAppointmentItem(service))
+	}
+	return items
 }
 
 func getWeekRange(date time.Time) (time.Time, time.Time) {
@@ -36,12 +91,18 @@ func getWeekRange(date time.Time) (time.Time, time.Time) {
 	return start, end
 }
 
-func (s *appointmentService) CreateAppointment(userID uint, services []models.Service, date time.Time) (created models.Appointment, suggestion *models.Appointment, err error) {
+// CreateAppointment books the services with the given professional, or with any available one when professionalID is nil
+func (s *appointmentService) CreateAppointment(userID uint, services []models.Service, date time.Time, professionalID *uint) (created models.Appointment, suggestion *models.Appointment, err error) {
 	// Validate that appointment has at least one service
 	if len(services) == 0 {
 		return models.Appointment{}, nil, models.ErrAppointmentNoServices
 	}
 
+	services, err = resolveServices(s.serviceRepo, serviceIDs(services))
+	if err != nil {
+		return models.Appointment{}, nil, err
+	}
+
 	weekStart, weekEnd := getWeekRange(date)
 
 	// Check for existing appointments in the same week
@@ -54,88 +115,458 @@ func (s *appointmentService) CreateAppointment(userID uint, services []models.Se
 		}
 	}
 
+	if err = checkBusinessHours(s.scheduleRepo, date, models.TotalDuration(services)); err != nil {
+		return
+	}
+
 	ap := models.Appointment{
 		UserID:   userID,
 		Services: services,
 		Date:     date,
 		Status:   models.StatusPending,
+		Items:    snapshotItems(services, nil),
 	}
 
-	created, err = s.repo.Create(ap)
+	created, err = s.repo.CreateIfFree(ap, claimSlot(s.waitlistRepo, s.professionalRepo, 0, professionalID))
+	if err == nil {
+		s.notify(notification.EventBooked, created)
+	}
 	return
 }
 
-func (s *appointmentService) UpdateAppointment(id uint, newAp models.Appointment, role models.UserRole) (models.Appointment, error) {
+func (s *appointmentService) CreateSeries(userID uint, services []models.Service, date time.Time, professionalID *uint, rule models.Recurrence) (models.SeriesResult, error) {
+	result := models.SeriesResult{Appointments: []models.Appointment{}, Conflicts: []models.SeriesConflict{}}
+	if len(services) == 0 {
+		return result, models.ErrAppointmentNoServices
+	}
+	dates, err := rule.Occurrences(date)
+	if err != nil {
+		return result, err
+	}
+	services, err = resolveServices(s.serviceRepo, serviceIDs(services))
+	if err != nil {
+		return result, err
+	}
+
+	// Every date is checked before booking any, so a series is only created when it has appointments
+	var bookable []models.Appointment
+	for _, occurrence := range dates {
+		assigned, err := s.checkSlot(services, occurrence, professionalID)
+		if isUnbookable(err) {
+			result.Conflicts = append(result.Conflicts, models.SeriesConflict{Date: occurrence, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		bookable = append(bookable, models.Appointment{
+			UserID:         userID,
+			Services:       services,
+			Date:           occurrence,
+			Status:         models.StatusPending,
+			ProfessionalID: assigned,
+			Items:          snapshotItems(services, nil),
+		})
+	}
+	if len(bookable) == 0 {
+		return result, models.ErrSeriesUnavailable
+	}
+
+	series, err := s.repo.CreateSeries(models.AppointmentSeries{UserID: userID, Recurrence: rule})
+	if err != nil {
+		return result, err
+	}
+	result.Series = &series
+	for _, ap := range bookable {
+		ap.SeriesID = &series.ID
+		// The slot may have been taken since it was checked
+		created, err := s.repo.CreateIfFree(ap, claimSlot(s.waitlistRepo, s.professionalRepo, 0, professionalID))
+		if isUnbookable(err) {
+			result.Conflicts = append(result.Conflicts, models.SeriesConflict{Date: ap.Date, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		s.notify(notification.EventBooked, created)
+		result.Appointments = append(result.Appointments, created)
+	}
+	return result, nil
+}
+
+// checkSlot returns the agenda that can receive the services at the date, preferring the requested one
+func (s *appointmentService) checkSlot(services []models.Service, date time.Time, professionalID *uint) (*uint, error) {
+	if err := checkBusinessHours(s.scheduleRepo, date, models.TotalDuration(services)); err != nil {
+		return nil, err
+	}
+	return assignProfessional(s.repo, s.waitlistRepo, s.professionalRepo, services, date, 0, professionalID)
+}
+
+// claimSlot returns the check assigning the agenda of an appointment, run by the repository in the same
+// transaction as the write so that concurrent bookings cannot take the same slot. ignoreID is the appointment
+// being changed, 0 for a new one.
+func claimSlot(waitlistRepo repository.WaitlistRepository, professionalRepo repository.ProfessionalRepository, ignoreID uint, professionalID *uint) func(repository.AppointmentRepository, *models.Appointment) error {
+	return func(repo repository.AppointmentRepository, ap *models.Appointment) error {
+		assigned, err := assignProfessional(repo, waitlistRepo, professionalRepo, ap.Services, ap.Date, ignoreID, professionalID)
+		if err != nil {
+			return err
+		}
+		ap.ProfessionalID = assigned
+		return nil
+	}
+}
+
+func (s *appointmentService) UpdateAppointment(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.Appointment, error) {
 	newAp.ID = id
 
 	ap, err := s.repo.FindByID(id)
 	if err != nil {
 		return ap, err
 	}
-	if role != models.RoleAdmin {
-		diff := time.Until(ap.Date)
-		if diff < (48 * time.Hour) {
-			return ap, models.ErrCannotUpdateWithingTwoDays
+	if err := s.policy.Authorize(claims, ActionUpdateAppointment, ap); err != nil {
+		return ap, err
+	}
+	if ap.Status.IsFinal() {
+		return ap, models.ErrAppointmentClosed
+	}
+	nextStatus := newAp.Status
+	if nextStatus == "" {
+		nextStatus = ap.Status
+	}
+	if nextStatus != ap.Status {
+		action := ActionChangeStatus
+		if nextStatus == models.StatusCanceled {
+			action = ActionCancelAppointment
+		}
+		if err := s.policy.Authorize(claims, action, ap); err != nil {
+			return ap, err
+		}
+		if err := ap.Status.ValidateTransition(nextStatus); err != nil {
+			return ap, err
+		}
+	}
+	// The status is only changed through ChangeStatus so that the transition is recorded
+	newAp.Status = ap.Status
+	// Only staff who manage appointments may hand an appointment over to another customer
+	if !claims.Role.Can(models.PermManageAppointments) || newAp.UserID == 0 {
+		newAp.UserID = ap.UserID
+	}
+	rescheduled := !newAp.Date.Equal(ap.Date)
+	newAp.RescheduleCount = ap.RescheduleCount
+	newAp.SeriesID = ap.SeriesID
+	if rescheduled {
+		newAp.RescheduleCount++
+	}
+	fee, err := s.applyCancellationPolicy(claims, ap, nextStatus, rescheduled)
+	if err != nil {
+		return ap, err
+	}
+
+	if err := newAp.Validate(); err != nil {
+		return ap, err
+	}
+	newAp.Services, err = resolveServices(s.serviceRepo, serviceIDs(newAp.Services))
+	if err != nil {
+		return ap, err
+	}
+	newAp.Items = snapshotItems(newAp.Services, ap.Items)
+	var claim func(repository.AppointmentRepository, *models.Appointment) error
+	if !nextStatus.IsFinal() {
+		if err := checkBusinessHours(s.scheduleRepo, newAp.Date, newAp.Duration()); err != nil {
+			return ap, err
+		}
+		claim = claimSlot(s.waitlistRepo, s.professionalRepo, id, newAp.ProfessionalID)
+	}
+	// The foreign key is the source of truth, a stale association would override it on save
+	newAp.Professional = nil
+
+	// The status change and its fee are written in the transaction of the update, so that none is kept without the others
+	newAp, err = s.repo.UpdateIfFree(newAp, func(repo repository.AppointmentRepository, updated *models.Appointment) error {
+		if claim != nil {
+			if err := claim(repo, updated); err != nil {
+				return err
+			}
+		}
+		if nextStatus == ap.Status {
+			return nil
+		}
+		if err := repo.ChangeStatus(id, ap.Status, nextStatus, &claims.UserID, fee); err != nil {
+			return err
+		}
+		updated.Status = nextStatus
+		return nil
+	})
+	if err != nil {
+		return newAp, err
+	}
+	if newAp.UserID == ap.UserID {
+		newAp.User = ap.User
+	}
+	if event, ok := statusEvent(nextStatus); ok && nextStatus != ap.Status {
+		s.notify(event, newAp)
+	} else if rescheduled {
+		s.notify(notification.EventRescheduled, newAp)
+	}
+	return newAp, nil
+}
+
+func (s *appointmentService) UpdateFollowing(claims *models.CustomClaims, id uint, newAp models.Appointment) (models.SeriesResult, error) {
+	result := models.SeriesResult{Appointments: []models.Appointment{}, Conflicts: []models.SeriesConflict{}}
+	following, err := s.listFollowing(id)
+	if err != nil {
+		return result, err
+	}
+
+	anchor := following[0]
+	moved := newAp.Date.In(anchor.Date.Location())
+	days := calendarDays(anchor.Date, moved)
+	for i, ap := range following {
+		if i > 0 && ap.Status.IsFinal() {
+			continue
+		}
+		change := newAp
+		change.Date = time.Date(ap.Date.Year(), ap.Date.Month(), ap.Date.Day()+days, moved.Hour(), moved.Minute(), moved.Second(), 0, ap.Date.Location())
+		// Only the appointment itself may change status, the later ones are canceled through CancelFollowing
+		if i > 0 {
+			change.Status = ""
+		}
+		updated, err := s.UpdateAppointment(claims, ap.ID, change)
+		if err != nil {
+			if i == 0 {
+				return result, err
+			}
+			result.Conflicts = append(result.Conflicts, models.SeriesConflict{AppointmentID: ap.ID, Date: change.Date, Error: err.Error()})
+			continue
+		}
+		result.Appointments = append(result.Appointments, updated)
+	}
+	return result, nil
+}
+
+func (s *appointmentService) CancelFollowing(claims *models.CustomClaims, id uint) (models.SeriesResult, error) {
+	result := models.SeriesResult{Appointments: []models.Appointment{}, Conflicts: []models.SeriesConflict{}}
+	following, err := s.listFollowing(id)
+	if err != nil {
+		return result, err
+	}
+
+	for i, ap := range following {
+		if i > 0 && ap.Status.IsFinal() {
+			continue
+		}
+		canceled, err := s.CancelAppointment(claims, ap.ID)
+		if err != nil {
+			if i == 0 {
+				return result, err
+			}
+			result.Conflicts = append(result.Conflicts, models.SeriesConflict{AppointmentID: ap.ID, Date: ap.Date, Error: err.Error()})
+			continue
 		}
+		result.Appointments = append(result.Appointments, canceled)
 	}
+	return result, nil
+}
 
-	err = s.repo.Update(newAp)
-	return newAp, err
+// listFollowing returns the appointment followed by the later ones of its series
+func (s *appointmentService) listFollowing(id uint) ([]models.Appointment, error) {
+	ap, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if ap.SeriesID == nil {
+		return nil, models.ErrNotInSeries
+	}
+	list, err := s.repo.ListSeries(*ap.SeriesID, ap.Date)
+	if err != nil {
+		return nil, err
+	}
+	following := []models.Appointment{ap}
+	for _, other := range list {
+		if other.ID != ap.ID {
+			following = append(following, other)
+		}
+	}
+	return following, nil
 }
 
-func (s *appointmentService) ListHistory(start, end time.Time) ([]models.Appointment, error) {
-	return s.repo.ListByPeriod(start, end)
+// calendarDays counts the days between the dates, ignoring the time of day
+func calendarDays(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
 }
-func (s *appointmentService) ListUserHistory(userID uint, start, end time.Time) ([]models.Appointment, error) {
-	return s.repo.ListByPeriodAndUser(userID, start, end)
+
+func (s *appointmentService) ListHistory(start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	return s.repo.ListByPeriod(start, end, page)
 }
 
-func (s *appointmentService) ListAll() ([]models.Appointment, error) {
-	return s.repo.ListAll()
+func (s *appointmentService) SearchAppointments(query models.AppointmentQuery) (models.AppointmentPage, error) {
+	return s.repo.Search(query)
+}
+
+func (s *appointmentService) ListProfessionalAgenda(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	professional, err := s.professionalRepo.FindByUserID(userID)
+	if errors.Is(err, models.ErrUnknownProfessional) {
+		return models.AppointmentPage{}, models.ErrNoLinkedProfessional
+	}
+	if err != nil {
+		return models.AppointmentPage{}, err
+	}
+	return s.repo.ListByProfessionalAndPeriod(professional.ID, start, end, page)
+}
+
+func (s *appointmentService) ListUserHistory(userID uint, start, end time.Time, page models.PageRequest) (models.AppointmentPage, error) {
+	return s.repo.ListByPeriodAndUser(userID, start, end, page)
+}
+
+func (s *appointmentService) ListAll(page models.PageRequest) (models.AppointmentPage, error) {
+	return s.repo.ListAll(page)
+}
+
+func (s *appointmentService) ChangeStatus(claims *models.CustomClaims, id uint, status models.AppointmentStatus) (models.Appointment, error) {
+	return s.transition(claims, ActionChangeStatus, id, status)
+}
+
+func (s *appointmentService) CancelAppointment(claims *models.CustomClaims, id uint) (models.Appointment, error) {
+	return s.transition(claims, ActionCancelAppointment, id, models.StatusCanceled)
+}
+
+func (s *appointmentService) CancelUpcoming(userID uint) error {
+	upcoming, err := s.repo.ListUpcomingByUser(userID, []models.AppointmentStatus{models.StatusPending, models.StatusConfirmed}, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, ap := range upcoming {
+		err := s.repo.ChangeStatus(ap.ID, ap.Status, models.StatusCanceled, &userID, nil)
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			// Changed in the meantime
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ap.Status = models.StatusCanceled
+		s.notify(notification.EventCanceled, ap)
+	}
+	return nil
 }
 
-func (s *appointmentService) ChangeStatus(id uint, status models.AppointmentStatus) (models.Appointment, error) {
+// transition authorizes the action and moves the appointment to the given status, recording who changed it
+func (s *appointmentService) transition(claims *models.CustomClaims, action AppointmentAction, id uint, status models.AppointmentStatus) (models.Appointment, error) {
 	ap, err := s.repo.FindByID(id)
 	if err != nil {
 		return ap, err
 	}
+	if err := s.policy.Authorize(claims, action, ap); err != nil {
+		return ap, err
+	}
+	if err := ap.Status.ValidateTransition(status); err != nil {
+		return ap, err
+	}
+	fee, err := s.applyCancellationPolicy(claims, ap, status, false)
+	if err != nil {
+		return ap, err
+	}
 
+	if err := s.repo.ChangeStatus(id, ap.Status, status, &claims.UserID, fee); err != nil {
+		return ap, err
+	}
 	ap.Status = status
-	return ap, s.repo.Update(ap)
+	if event, ok := statusEvent(status); ok {
+		s.notify(event, ap)
+	}
+	return ap, nil
 }
 
-func (s *appointmentService) GetWeeklyPerformance() (int, int, error) {
-	now := time.Now()
-	start, end := getWeekRange(now)
+// statusEvent returns the message customers get when their appointment moves to the status, if any
+func statusEvent(status models.AppointmentStatus) (notification.Event, bool) {
+	switch status {
+	case models.StatusConfirmed:
+		return notification.EventConfirmed, true
+	case models.StatusCanceled:
+		return notification.EventCanceled, true
+	}
+	return "", false
+}
 
-	list, err := s.repo.ListByPeriod(start, end)
+// notify hands the message over to the notifier, which delivers it in the background
+func (s *appointmentService) notify(event notification.Event, ap models.Appointment) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.Notify(event, ap)
+}
+
+// applyCancellationPolicy evaluates the configured rules for moving the appointment to the next status and
+// returns the fee it incurs, if any. Staff who manage appointments are not bound by notice periods or
+// reschedule limits, but a no-show is charged no matter who records it.
+func (s *appointmentService) applyCancellationPolicy(claims *models.CustomClaims, ap models.Appointment, next models.AppointmentStatus, rescheduled bool) (*models.CustomerFee, error) {
+	if claims.Role.Can(models.PermManageAppointments) && next != models.StatusNoShow {
+		return nil, nil
+	}
+	rules, err := s.cancellationRepo.Get()
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 
-	completed := 0
-	for _, ap := range list {
-		if ap.Status == models.StatusDone {
-			completed++
-		}
+	now := time.Now()
+	switch {
+	case next == models.StatusNoShow:
+		return rules.NoShowFee(ap), nil
+	case next == models.StatusCanceled:
+		return rules.LateCancellationFee(ap, now)
+	case rescheduled:
+		return nil, rules.CheckReschedule(ap, now)
+	default:
+		return nil, rules.CheckChange(ap, now)
 	}
+}
 
-	return len(list), completed, nil
+func (s *appointmentService) GetStatusHistory(claims *models.CustomClaims, id uint) ([]models.AppointmentStatusHistory, error) {
+	ap, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.Authorize(claims, ActionViewAppointment, ap); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStatusHistory(id)
 }
 
-func (s *appointmentService) MergeAppointments(existingID uint, newServices []models.Service) (models.Appointment, error) {
+func (s *appointmentService) MergeAppointments(claims *models.CustomClaims, existingID uint, newServices []models.Service) (models.Appointment, error) {
 	// Get the existing appointment
 	existing, err := s.repo.FindByID(existingID)
 	if err != nil {
 		return models.Appointment{}, err
 	}
+	if err := s.policy.Authorize(claims, ActionMergeAppointment, existing); err != nil {
+		return models.Appointment{}, err
+	}
+	if existing.Status.IsFinal() {
+		return models.Appointment{}, models.ErrAppointmentClosed
+	}
+	if _, err := s.applyCancellationPolicy(claims, existing, existing.Status, false); err != nil {
+		return models.Appointment{}, err
+	}
+
+	newServices, err = resolveServices(s.serviceRepo, serviceIDs(newServices))
+	if err != nil {
+		return models.Appointment{}, err
+	}
 
 	// Append new services to existing services
 	existing.Services = append(existing.Services, newServices...)
+	existing.Items = snapshotItems(existing.Services, existing.Items)
 	existing.UpdatedAt = time.Now()
 
-	// Update the appointment
-	err = s.repo.Update(existing)
+	// The extra services make the appointment longer, so it must still fit the agenda
+	if err := checkBusinessHours(s.scheduleRepo, existing.Date, existing.Duration()); err != nil {
+		return models.Appointment{}, err
+	}
+	existing.Professional = nil
+
+	// Update the appointment once its agenda is still free
+	_, err = s.repo.UpdateIfFree(existing, claimSlot(s.waitlistRepo, s.professionalRepo, existing.ID, existing.ProfessionalID))
 	if err != nil {
 		return models.Appointment{}, err
 	}
@@ -7,20 +7,74 @@ import (
 
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// futureDate returns 10:00 of a day ahead, comfortably inside business hours
+func futureDate(days int) time.Time {
+	d := time.Now().AddDate(0, 0, days)
+	return time.Date(d.Year(), d.Month(), d.Day(), 10, 0, 0, 0, time.Local)
+}
+
+// adminClaims and customerClaims build the token claims the handlers pass to the service
+func adminClaims(userID uint) *models.CustomClaims {
+	return &models.CustomClaims{UserID: userID, Role: models.RoleAdmin}
+}
+
+func customerClaims(userID uint) *models.CustomClaims {
+	return &models.CustomClaims{UserID: userID, Role: models.RoleCustomer}
+}
+
+// expectSalonOpen makes the schedule repository report the salon open every day
+func expectSalonOpen(repo *mocks.MockScheduleRepository) {
+	repo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil).AnyTimes()
+	repo.EXPECT().FindBusinessHours(gomock.Any()).DoAndReturn(func(weekday time.Weekday) (models.BusinessHours, error) {
+		return models.BusinessHours{Weekday: weekday, IsOpen: true, OpensAt: "08:00", ClosesAt: "20:00"}, nil
+	}).AnyTimes()
+}
+
+// expectBooking runs the slot check given to CreateIfFree against the mock before creating the appointment,
+// as the repository does within its transaction
+func expectBooking(repo *mocks.MockAppointmentRepository) {
+	repo.EXPECT().CreateIfFree(gomock.Any(), gomock.Any()).DoAndReturn(func(ap models.Appointment, check func(repository.AppointmentRepository, *models.Appointment) error) (models.Appointment, error) {
+		if err := check(repo, &ap); err != nil {
+			return ap, err
+		}
+		return repo.Create(ap)
+	}).AnyTimes()
+}
+
+// expectSaving lets UpdateIfFree run its check against the mock and delegate the write to Update
+func expectSaving(repo *mocks.MockAppointmentRepository) {
+	repo.EXPECT().UpdateIfFree(gomock.Any(), gomock.Any()).DoAndReturn(func(ap models.Appointment, check func(repository.AppointmentRepository, *models.Appointment) error) (models.Appointment, error) {
+		if check != nil {
+			if err := check(repo, &ap); err != nil {
+				return ap, err
+			}
+		}
+		return ap, repo.Update(ap)
+	}).AnyTimes()
+}
+
 func TestCreateService_WithSuggestion(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
-	existentAp := models.Appointment{ID: 2, Date: time.Now().AddDate(0, 0, 2)}
-
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	existentAp := models.Appointment{ID: 2, Date: futureDate(2), Status: models.StatusPending}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, Name: "Corte", DurationMinutes: 30}}, nil)
 	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{existentAp}, nil)
 
-	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1, Name: "Corte"}}, time.Now().AddDate(0, 0, 3))
+	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1, Name: "Corte"}}, futureDate(3), nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, suggestion)
 	assert.Equal(t, uint(0), ap.ID) // No appointment should be created when suggestion exists
@@ -32,12 +86,20 @@ func TestCreateService_NoSuggestion(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
-
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, Name: "Corte", DurationMinutes: 30}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 	mockRepo.EXPECT().Create(gomock.Any()).Return(models.Appointment{ID: 5}, nil)
 	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 
-	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1, Name: "Corte"}}, time.Now().AddDate(0, 0, 3))
+	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1, Name: "Corte"}}, futureDate(3), nil)
 	assert.NoError(t, err)
 	assert.Nil(t, suggestion)
 	assert.Equal(t, uint(5), ap.ID)
@@ -48,9 +110,13 @@ func TestCreateAppointment_NoServices(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
-	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{}, time.Now().AddDate(0, 0, 3))
+	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{}, futureDate(3), nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, suggestion)
@@ -63,7 +129,12 @@ func TestCreateAppointment_WithServices(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
 
 	services := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -75,14 +146,17 @@ func TestCreateAppointment_WithServices(t *testing.T) {
 		ID:       5,
 		UserID:   1,
 		Services: services,
-		Date:     time.Now().AddDate(0, 0, 3),
+		Date:     futureDate(3),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 	mockRepo.EXPECT().Create(gomock.Any()).Return(expectedAp, nil)
 	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 
-	ap, suggestion, err := apSrv.CreateAppointment(1, services, time.Now().AddDate(0, 0, 3))
+	ap, suggestion, err := apSrv.CreateAppointment(1, services, futureDate(3), nil)
 
 	assert.NoError(t, err)
 	assert.Nil(t, suggestion)
@@ -96,7 +170,12 @@ func TestCreateAppointment_UserLoaded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
 
 	user := models.User{
 		ID:       1,
@@ -114,14 +193,17 @@ func TestCreateAppointment_UserLoaded(t *testing.T) {
 		User:     user,
 		UserID:   1,
 		Services: services,
-		Date:     time.Now().AddDate(0, 0, 3),
+		Date:     futureDate(3),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 	mockRepo.EXPECT().Create(gomock.Any()).Return(expectedAp, nil)
 	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 
-	ap, _, err := apSrv.CreateAppointment(1, services, time.Now().AddDate(0, 0, 3))
+	ap, _, err := apSrv.CreateAppointment(1, services, futureDate(3), nil)
 
 	assert.NoError(t, err)
 	assert.NotEqual(t, uint(0), ap.User.ID)
@@ -134,7 +216,12 @@ func TestCreateAppointment_ServicesNotNil(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
 
 	services := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -145,14 +232,17 @@ func TestCreateAppointment_ServicesNotNil(t *testing.T) {
 		ID:       5,
 		UserID:   1,
 		Services: services,
-		Date:     time.Now().AddDate(0, 0, 3),
+		Date:     futureDate(3),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 	mockRepo.EXPECT().Create(gomock.Any()).Return(expectedAp, nil)
 	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
 
-	ap, _, err := apSrv.CreateAppointment(1, services, time.Now().AddDate(0, 0, 3))
+	ap, _, err := apSrv.CreateAppointment(1, services, futureDate(3), nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, ap.Services)
@@ -166,7 +256,12 @@ func TestMergeAppointments_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -181,7 +276,7 @@ func TestMergeAppointments_Success(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: existingServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
@@ -190,17 +285,20 @@ func TestMergeAppointments_Success(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: mergedServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(newServices, nil)
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(nil),
 		mockRepo.EXPECT().FindByID(uint(10)).Return(mergedAp, nil),
 	)
 
-	result, err := apSrv.MergeAppointments(10, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(10), result.ID)
@@ -215,7 +313,11 @@ func TestMergeAppointments_AppointmentNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	newServices := []models.Service{
 		{ID: 3, Name: "Hidratação", Price: 60.0},
@@ -223,7 +325,7 @@ func TestMergeAppointments_AppointmentNotFound(t *testing.T) {
 
 	mockRepo.EXPECT().FindByID(uint(999)).Return(models.Appointment{}, errors.New("appointment not found"))
 
-	result, err := apSrv.MergeAppointments(999, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 999, newServices)
 
 	assert.Error(t, err)
 	assert.Equal(t, "appointment not found", err.Error())
@@ -235,7 +337,12 @@ func TestMergeAppointments_UpdateFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -249,16 +356,19 @@ func TestMergeAppointments_UpdateFails(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: existingServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(newServices, nil)
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New("database error")),
 	)
 
-	result, err := apSrv.MergeAppointments(10, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
 
 	assert.Error(t, err)
 	assert.Equal(t, "database error", err.Error())
@@ -270,7 +380,12 @@ func TestMergeAppointments_WithMultipleNewServices(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -286,7 +401,7 @@ func TestMergeAppointments_WithMultipleNewServices(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: existingServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
@@ -295,17 +410,20 @@ func TestMergeAppointments_WithMultipleNewServices(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: mergedServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(newServices, nil)
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(nil),
 		mockRepo.EXPECT().FindByID(uint(10)).Return(mergedAp, nil),
 	)
 
-	result, err := apSrv.MergeAppointments(10, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(10), result.ID)
@@ -319,7 +437,12 @@ func TestMergeAppointments_PreservesAppointmentData(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -329,7 +452,7 @@ func TestMergeAppointments_PreservesAppointmentData(t *testing.T) {
 		{ID: 2, Name: "Escova", Price: 40.0},
 	}
 
-	appointmentDate := time.Now().AddDate(0, 0, 2)
+	appointmentDate := futureDate(2)
 	existingAp := models.Appointment{
 		ID:       10,
 		UserID:   5,
@@ -347,13 +470,16 @@ func TestMergeAppointments_PreservesAppointmentData(t *testing.T) {
 		Status:   models.StatusConfirmed,
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(newServices, nil)
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(nil),
 		mockRepo.EXPECT().FindByID(uint(10)).Return(mergedAp, nil),
 	)
 
-	result, err := apSrv.MergeAppointments(10, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(10), result.ID)
@@ -368,7 +494,12 @@ func TestMergeAppointments_EmptyNewServices(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -378,7 +509,7 @@ func TestMergeAppointments_EmptyNewServices(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: existingServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
@@ -386,17 +517,19 @@ func TestMergeAppointments_EmptyNewServices(t *testing.T) {
 		ID:       10,
 		UserID:   1,
 		Services: existingServices,
-		Date:     time.Now().AddDate(0, 0, 2),
+		Date:     futureDate(2),
 		Status:   models.StatusPending,
 	}
 
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(nil),
 		mockRepo.EXPECT().FindByID(uint(10)).Return(mergedAp, nil),
 	)
 
-	result, err := apSrv.MergeAppointments(10, []models.Service{})
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, []models.Service{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(10), result.ID)
@@ -409,7 +542,12 @@ func TestMergeAppointments_UpdatedAtTimestamp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
-	apSrv := NewAppointmentService(mockRepo)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
 
 	existingServices := []models.Service{
 		{ID: 1, Name: "Corte", Price: 50.0},
@@ -424,7 +562,7 @@ func TestMergeAppointments_UpdatedAtTimestamp(t *testing.T) {
 		ID:        10,
 		UserID:    1,
 		Services:  existingServices,
-		Date:      time.Now().AddDate(0, 0, 2),
+		Date:      futureDate(2),
 		Status:    models.StatusPending,
 		UpdatedAt: oldTime,
 	}
@@ -434,20 +572,1000 @@ func TestMergeAppointments_UpdatedAtTimestamp(t *testing.T) {
 		ID:        10,
 		UserID:    1,
 		Services:  mergedServices,
-		Date:      time.Now().AddDate(0, 0, 2),
+		Date:      futureDate(2),
 		Status:    models.StatusPending,
 		UpdatedAt: time.Now(),
 	}
 
+	mockServiceRepo.EXPECT().FindByIDs(gomock.Any()).Return(newServices, nil)
 	gomock.InOrder(
 		mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil),
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil),
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp}, nil),
 		mockRepo.EXPECT().Update(gomock.Any()).Return(nil),
 		mockRepo.EXPECT().FindByID(uint(10)).Return(mergedAp, nil),
 	)
 
-	result, err := apSrv.MergeAppointments(10, newServices)
+	result, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
 
 	assert.NoError(t, err)
 	assert.Equal(t, uint(10), result.ID)
 	assert.True(t, result.UpdatedAt.After(oldTime))
 }
+
+// TestCreateAppointment_Conflict tests that overlapping bookings are rejected
+func TestCreateAppointment_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	date := futureDate(3)
+	coloring := models.Service{ID: 3, Name: "Coloração", Price: 100.0, DurationMinutes: 120}
+	booked := models.Appointment{ID: 7, UserID: 2, Services: []models.Service{coloring}, Date: date.Add(-time.Hour), Status: models.StatusConfirmed}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, Name: "Corte", DurationMinutes: 30}}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{booked}, nil)
+
+	ap, suggestion, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1}}, date, nil)
+
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+	assert.Nil(t, suggestion)
+	assert.Equal(t, uint(0), ap.ID)
+}
+
+// TestCreateAppointment_UsesCatalogDuration tests that durations sent by the client are ignored
+func TestCreateAppointment_UsesCatalogDuration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	date := futureDate(3)
+	booked := models.Appointment{ID: 7, UserID: 2, Services: []models.Service{{ID: 1, DurationMinutes: 30}}, Date: date.Add(90 * time.Minute), Status: models.StatusPending}
+
+	// The client claims 10 minutes, but Coloração takes 120 and reaches the booked slot
+	mockServiceRepo.EXPECT().FindByIDs([]uint{3}).Return([]models.Service{{ID: 3, Name: "Coloração", DurationMinutes: 120}}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{booked}, nil)
+
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 3, DurationMinutes: 10}}, date, nil)
+
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+}
+
+// TestCreateAppointment_UnknownService tests that services missing from the catalog are rejected
+func TestCreateAppointment_UnknownService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1, 99}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1}, {ID: 99}}, futureDate(3), nil)
+
+	assert.ErrorIs(t, err, models.ErrUnknownService)
+}
+
+// TestMergeAppointments_Conflict tests that a merge cannot stretch into the next booking
+func TestMergeAppointments_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+	date := futureDate(3)
+	existingAp := models.Appointment{ID: 10, UserID: 1, Services: []models.Service{{ID: 1, DurationMinutes: 30}}, Date: date, Status: models.StatusPending}
+	nextAp := models.Appointment{ID: 11, UserID: 2, Services: []models.Service{{ID: 1, DurationMinutes: 30}}, Date: date.Add(time.Hour), Status: models.StatusPending}
+	newServices := []models.Service{{ID: 4, Name: "Hidratação", DurationMinutes: 60}}
+
+	mockRepo.EXPECT().FindByID(uint(10)).Return(existingAp, nil)
+	mockServiceRepo.EXPECT().FindByIDs([]uint{4}).Return(newServices, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existingAp, nextAp}, nil)
+
+	_, err := apSrv.MergeAppointments(adminClaims(1), 10, newServices)
+
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+}
+
+// TestCreateAppointment_AnyAvailableProfessional tests that a free professional is assigned when none is requested
+func TestCreateAppointment_AnyAvailableProfessional(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	date := futureDate(3)
+	haircut := models.Service{ID: 1, Name: "Corte", DurationMinutes: 30}
+	busyID := uint(1)
+	professionals := []models.Professional{
+		{ID: 1, Name: "Leila", IsActive: true, Services: []models.Service{haircut}},
+		{ID: 2, Name: "Ana", IsActive: true, Services: []models.Service{haircut}},
+	}
+	booked := models.Appointment{ID: 7, Services: []models.Service{haircut}, Date: date, Status: models.StatusConfirmed, ProfessionalID: &busyID}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(professionals, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{booked}, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(ap models.Appointment) (models.Appointment, error) {
+		ap.ID = 8
+		return ap, nil
+	})
+
+	ap, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1}}, date, nil)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, ap.ProfessionalID) {
+		assert.Equal(t, uint(2), *ap.ProfessionalID)
+	}
+}
+
+// TestCreateAppointment_LegacyUnassignedBooking tests that a booking made before the salon registered
+// professionals keeps its slot once they exist
+func TestCreateAppointment_LegacyUnassignedBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	date := futureDate(3)
+	haircut := models.Service{ID: 1, Name: "Corte", DurationMinutes: 30}
+	professionals := []models.Professional{{ID: 1, Name: "Leila", IsActive: true, Services: []models.Service{haircut}}}
+	legacy := models.Appointment{ID: 7, Services: []models.Service{haircut}, Date: date, Status: models.StatusConfirmed}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(professionals, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{legacy}, nil)
+
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1}}, date, nil)
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+}
+
+// TestCreateAppointment_RequestedProfessionalBusy tests that a busy professional is not double booked
+func TestCreateAppointment_RequestedProfessionalBusy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	date := futureDate(3)
+	haircut := models.Service{ID: 1, Name: "Corte", DurationMinutes: 30}
+	professionalID := uint(1)
+	booked := models.Appointment{ID: 7, Services: []models.Service{haircut}, Date: date.Add(15 * time.Minute), Status: models.StatusPending, ProfessionalID: &professionalID}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindByID(uint(1)).Return(models.Professional{ID: 1, IsActive: true, Services: []models.Service{haircut}}, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{booked}, nil)
+
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1}}, date, &professionalID)
+
+	assert.ErrorIs(t, err, models.ErrTimeSlotUnavailable)
+}
+
+// TestCreateAppointment_ProfessionalCannotPerform tests that professionals only receive services they perform
+func TestCreateAppointment_ProfessionalCannotPerform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	professionalID := uint(1)
+	coloring := models.Service{ID: 3, Name: "Coloração", DurationMinutes: 120}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{3}).Return([]models.Service{coloring}, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockProfessionalRepo.EXPECT().FindByID(uint(1)).Return(models.Professional{ID: 1, IsActive: true, Services: []models.Service{{ID: 5}}}, nil)
+
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 3}}, futureDate(3), &professionalID)
+
+	assert.ErrorIs(t, err, models.ErrProfessionalCannotPerform)
+}
+
+// TestCreateAppointment_BusinessHours tests the opening hours and closure calendar rules
+func TestCreateAppointment_BusinessHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		hour    int
+		holiday bool
+		hours   models.BusinessHours
+		wantErr error
+	}{
+		{"closed weekday", 10, false, models.BusinessHours{IsOpen: false}, models.ErrSalonClosed},
+		{"holiday", 10, true, models.BusinessHours{IsOpen: true, OpensAt: "09:00", ClosesAt: "18:00"}, models.ErrHolidayClosure},
+		{"before opening", 3, false, models.BusinessHours{IsOpen: true, OpensAt: "09:00", ClosesAt: "18:00"}, models.ErrOutsideBusinessHours},
+		{"ends after closing", 17, false, models.BusinessHours{IsOpen: true, OpensAt: "09:00", ClosesAt: "18:00"}, models.ErrOutsideBusinessHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+			mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+			mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+			apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+			date := futureDate(3)
+			date = time.Date(date.Year(), date.Month(), date.Day(), tt.hour, 0, 0, 0, time.Local)
+
+			mockServiceRepo.EXPECT().FindByIDs([]uint{3}).Return([]models.Service{{ID: 3, Name: "Coloração", DurationMinutes: 120}}, nil)
+			mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+			mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(tt.holiday, nil)
+			if !tt.holiday {
+				mockScheduleRepo.EXPECT().FindBusinessHours(date.Weekday()).Return(tt.hours, nil)
+			}
+
+			_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 3}}, date, nil)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestChangeStatus_Transitions tests that only the allowed status transitions reach the repository
+func TestChangeStatus_Transitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    models.AppointmentStatus
+		to      models.AppointmentStatus
+		wantErr error
+	}{
+		{"pending to confirmed", models.StatusPending, models.StatusConfirmed, nil},
+		{"confirmed to done", models.StatusConfirmed, models.StatusDone, nil},
+		{"confirmed to no show", models.StatusConfirmed, models.StatusNoShow, nil},
+		{"pending to expired", models.StatusPending, models.StatusExpired, nil},
+		{"confirmed to expired", models.StatusConfirmed, models.StatusExpired, models.ErrInvalidStatusTransition},
+		{"pending to done", models.StatusPending, models.StatusDone, models.ErrInvalidStatusTransition},
+		{"canceled to done", models.StatusCanceled, models.StatusDone, models.ErrInvalidStatusTransition},
+		{"done to canceled", models.StatusDone, models.StatusCanceled, models.ErrInvalidStatusTransition},
+		{"unknown status", models.StatusPending, models.AppointmentStatus("FOO"), models.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+			// The default policy charges no fees
+			mockCancellationRepo.EXPECT().Get().Return(models.DefaultCancellationPolicy(), nil).AnyTimes()
+			apSrv := NewAppointmentService(mockRepo, nil, nil, nil, mockCancellationRepo, nil, nil)
+
+			mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Status: tt.from}, nil)
+			if tt.wantErr == nil {
+				changedBy := uint(99)
+				mockRepo.EXPECT().ChangeStatus(uint(7), tt.from, tt.to, &changedBy, gomock.Any()).Return(nil)
+			}
+
+			ap, err := apSrv.ChangeStatus(adminClaims(99), 7, tt.to)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.from, ap.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, ap.Status)
+		})
+	}
+}
+
+// TestCancelAppointment_RecordsTransition tests that canceling goes through the status history
+func TestCancelAppointment_RecordsTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, nil)
+
+	changedBy := uint(1)
+	mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Status: models.StatusConfirmed}, nil)
+	mockRepo.EXPECT().ChangeStatus(uint(7), models.StatusConfirmed, models.StatusCanceled, &changedBy, gomock.Any()).Return(nil)
+
+	ap, err := apSrv.CancelAppointment(adminClaims(1), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCanceled, ap.Status)
+}
+
+// TestUpdateAppointment_StatusChangeIsRecorded tests that a status sent in an update is recorded as a transition
+func TestUpdateAppointment_StatusChangeIsRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+	services := []models.Service{{ID: 1, Name: "Corte", DurationMinutes: 30}}
+	existing := models.Appointment{ID: 7, UserID: 1, Services: services, Date: futureDate(5), Status: models.StatusPending}
+
+	changedBy := uint(42)
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{existing}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByID(uint(7)).Return(existing, nil),
+		// The transition is recorded in the transaction of the update, before the row is saved with it
+		mockRepo.EXPECT().ChangeStatus(uint(7), models.StatusPending, models.StatusConfirmed, &changedBy, nil).Return(nil),
+		mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(ap models.Appointment) error {
+			assert.Equal(t, models.StatusConfirmed, ap.Status)
+			return nil
+		}),
+	)
+
+	updated, err := apSrv.UpdateAppointment(adminClaims(42), 7, models.Appointment{UserID: 1, Services: services, Date: existing.Date, Status: models.StatusConfirmed})
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusConfirmed, updated.Status)
+}
+
+// TestUpdateAppointment_ClosedAppointment tests that finished appointments cannot be edited
+func TestUpdateAppointment_ClosedAppointment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Status: models.StatusCanceled}, nil)
+
+	_, err := apSrv.UpdateAppointment(adminClaims(1), 7, models.Appointment{Status: models.StatusPending})
+	assert.ErrorIs(t, err, models.ErrAppointmentClosed)
+}
+
+// TestGetStatusHistory_Ownership tests that customers only see the history of their own appointments
+func TestGetStatusHistory_Ownership(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  *models.CustomClaims
+		wantErr error
+	}{
+		{"owner", customerClaims(1), nil},
+		{"admin", adminClaims(2), nil},
+		{"other customer", customerClaims(2), models.ErrAppointmentNotOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, nil)
+
+			mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Status: models.StatusConfirmed}, nil)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().ListStatusHistory(uint(7)).Return([]models.AppointmentStatusHistory{
+					{AppointmentID: 7, FromStatus: models.StatusPending, ToStatus: models.StatusConfirmed},
+				}, nil)
+			}
+
+			history, err := apSrv.GetStatusHistory(tt.claims, 7)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, history, 1)
+		})
+	}
+}
+
+// TestAppointmentMutations_Policy tests that every mutation is checked against the caller before touching the data
+func TestAppointmentMutations_Policy(t *testing.T) {
+	otherCustomer := customerClaims(2)
+	owner := customerClaims(1)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		call    func(AppointmentService) error
+		wantErr error
+	}{
+		{"cancel someone else's appointment", futureDate(5), func(s AppointmentService) error {
+			_, err := s.CancelAppointment(otherCustomer, 7)
+			return err
+		}, models.ErrAppointmentNotOwned},
+		{"cancel own appointment within two days", time.Now().Add(24 * time.Hour), func(s AppointmentService) error {
+			_, err := s.CancelAppointment(owner, 7)
+			return err
+		}, models.ErrCancelTooLate},
+		{"merge into someone else's appointment", futureDate(5), func(s AppointmentService) error {
+			_, err := s.MergeAppointments(otherCustomer, 7, []models.Service{{ID: 2}})
+			return err
+		}, models.ErrAppointmentNotOwned},
+		{"merge into own appointment within two days", time.Now().Add(24 * time.Hour), func(s AppointmentService) error {
+			_, err := s.MergeAppointments(owner, 7, []models.Service{{ID: 2}})
+			return err
+		}, models.ErrRescheduleTooLate},
+		{"update someone else's appointment", futureDate(5), func(s AppointmentService) error {
+			_, err := s.UpdateAppointment(otherCustomer, 7, models.Appointment{UserID: 2, Services: []models.Service{{ID: 1}}, Date: futureDate(6)})
+			return err
+		}, models.ErrAppointmentNotOwned},
+		{"customer confirms own appointment", futureDate(5), func(s AppointmentService) error {
+			_, err := s.UpdateAppointment(owner, 7, models.Appointment{Services: []models.Service{{ID: 1}}, Date: futureDate(5), Status: models.StatusConfirmed})
+			return err
+		}, models.ErrAdminOnly},
+		{"customer changes status", futureDate(5), func(s AppointmentService) error {
+			_, err := s.ChangeStatus(owner, 7, models.StatusConfirmed)
+			return err
+		}, models.ErrAdminOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+			mockCancellationRepo.EXPECT().Get().Return(models.DefaultCancellationPolicy(), nil).AnyTimes()
+			apSrv := NewAppointmentService(mockRepo, nil, nil, nil, mockCancellationRepo, nil, nil)
+
+			// Only the lookup is expected, any write would fail the test
+			mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{
+				ID:       7,
+				UserID:   1,
+				Services: []models.Service{{ID: 1, DurationMinutes: 30}},
+				Date:     tt.date,
+				Status:   models.StatusPending,
+			}, nil)
+
+			assert.ErrorIs(t, tt.call(apSrv), tt.wantErr)
+		})
+	}
+}
+
+// TestCancelAppointment_Owner tests that customers can cancel their own appointments ahead of time
+func TestCancelAppointment_Owner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, mockCancellationRepo, nil, nil)
+
+	changedBy := uint(1)
+	mockCancellationRepo.EXPECT().Get().Return(models.DefaultCancellationPolicy(), nil)
+	mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Date: futureDate(5), Status: models.StatusPending}, nil)
+	mockRepo.EXPECT().ChangeStatus(uint(7), models.StatusPending, models.StatusCanceled, &changedBy, gomock.Any()).Return(nil)
+
+	ap, err := apSrv.CancelAppointment(customerClaims(1), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCanceled, ap.Status)
+}
+
+// TestListProfessionalAgenda tests that professionals only see the appointments assigned to them
+func TestListProfessionalAgenda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, mockProfessionalRepo, nil, nil, nil, nil)
+
+	start, end := futureDate(1), futureDate(8)
+	userID := uint(4)
+	mockProfessionalRepo.EXPECT().FindByUserID(userID).Return(models.Professional{ID: 2, UserID: &userID}, nil)
+	page := models.PageRequest{Limit: 20}
+	mockRepo.EXPECT().ListByProfessionalAndPeriod(uint(2), start, end, page).Return(models.AppointmentPage{Items: []models.Appointment{{ID: 10}}}, nil)
+
+	list, err := apSrv.ListProfessionalAgenda(userID, start, end, page)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+
+	mockProfessionalRepo.EXPECT().FindByUserID(uint(5)).Return(models.Professional{}, models.ErrUnknownProfessional)
+	_, err = apSrv.ListProfessionalAgenda(5, start, end, page)
+	assert.ErrorIs(t, err, models.ErrNoLinkedProfessional)
+}
+
+// TestCancellationPolicy_Fees tests that late cancellations and no-shows are charged to the customer
+func TestCancellationPolicy_Fees(t *testing.T) {
+	rules := models.CancellationPolicy{
+		RescheduleNoticeHours: 48,
+		CancelNoticeHours:     24,
+		LateCancelFeePercent:  50,
+		NoShowFeePercent:      100,
+	}
+	services := []models.Service{{ID: 1, Price: 50}, {ID: 2, Price: 30}}
+
+	tests := []struct {
+		name       string
+		claims     *models.CustomClaims
+		from       models.AppointmentStatus
+		to         models.AppointmentStatus
+		date       time.Time
+		wantReason models.FeeReason
+		wantAmount float64
+	}{
+		{"customer cancels late", customerClaims(1), models.StatusPending, models.StatusCanceled, time.Now().Add(3 * time.Hour), models.FeeLateCancellation, 40},
+		{"customer cancels ahead of time", customerClaims(1), models.StatusPending, models.StatusCanceled, futureDate(5), "", 0},
+		{"admin cancels late", adminClaims(9), models.StatusPending, models.StatusCanceled, time.Now().Add(3 * time.Hour), "", 0},
+		{"customer does not show up", adminClaims(9), models.StatusConfirmed, models.StatusNoShow, time.Now().Add(-time.Hour), models.FeeNoShow, 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+			apSrv := NewAppointmentService(mockRepo, nil, nil, nil, mockCancellationRepo, nil, nil)
+
+			mockCancellationRepo.EXPECT().Get().Return(rules, nil).AnyTimes()
+			mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Services: services, Date: tt.date, Status: tt.from}, nil)
+			mockRepo.EXPECT().ChangeStatus(uint(7), tt.from, tt.to, gomock.Any(), gomock.Any()).DoAndReturn(func(id uint, from, to models.AppointmentStatus, changedBy *uint, fee *models.CustomerFee) error {
+				if tt.wantReason == "" {
+					assert.Nil(t, fee)
+					return nil
+				}
+				require.NotNil(t, fee)
+				assert.Equal(t, uint(1), fee.UserID)
+				assert.Equal(t, uint(7), fee.AppointmentID)
+				assert.Equal(t, tt.wantReason, fee.Reason)
+				assert.InDelta(t, tt.wantAmount, fee.Amount, 0.001)
+				return nil
+			})
+
+			var err error
+			if tt.to == models.StatusCanceled {
+				_, err = apSrv.CancelAppointment(tt.claims, 7)
+			} else {
+				_, err = apSrv.ChangeStatus(tt.claims, 7, tt.to)
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestUpdateAppointment_RescheduleLimit tests that customers cannot move an appointment more times than allowed
+func TestUpdateAppointment_RescheduleLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, mockCancellationRepo, nil, nil)
+
+	services := []models.Service{{ID: 1, DurationMinutes: 30}}
+	mockCancellationRepo.EXPECT().Get().Return(models.CancellationPolicy{RescheduleNoticeHours: 24, MaxReschedules: 2}, nil)
+	mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Services: services, Date: futureDate(5), Status: models.StatusPending, RescheduleCount: 2}, nil)
+
+	_, err := apSrv.UpdateAppointment(customerClaims(1), 7, models.Appointment{Services: services, Date: futureDate(6)})
+	assert.ErrorIs(t, err, models.ErrRescheduleLimitReached)
+}
+
+// TestUpdateAppointment_CountsReschedules tests that moving the appointment increments its reschedule counter
+func TestUpdateAppointment_CountsReschedules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	mockCancellationRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, mockCancellationRepo, nil, nil)
+
+	services := []models.Service{{ID: 1, DurationMinutes: 30}}
+	mockCancellationRepo.EXPECT().Get().Return(models.CancellationPolicy{RescheduleNoticeHours: 24, MaxReschedules: 2}, nil)
+	mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, Services: services, Date: futureDate(5), Status: models.StatusPending, RescheduleCount: 1}, nil)
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(ap models.Appointment) error {
+		assert.Equal(t, 2, ap.RescheduleCount)
+		assert.Equal(t, uint(1), ap.UserID)
+		return nil
+	})
+
+	// The counter sent by the client is ignored
+	_, err := apSrv.UpdateAppointment(customerClaims(1), 7, models.Appointment{Services: services, Date: futureDate(6), RescheduleCount: 0})
+	assert.NoError(t, err)
+}
+
+// TestUpdateAppointment_KeepsBookedPrices tests that services already booked keep their price while new ones use the catalog
+func TestUpdateAppointment_KeepsBookedPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+	// The haircut got more expensive after it was booked
+	catalog := []models.Service{{ID: 1, Name: "Corte", Price: 80, DurationMinutes: 30}, {ID: 2, Name: "Escova", Price: 40, DurationMinutes: 45}}
+	existing := models.Appointment{
+		ID:       7,
+		UserID:   1,
+		Services: catalog[:1],
+		Items:    []models.AppointmentItem{{ID: 11, AppointmentID: 7, ServiceID: 1, Name: "Corte", Price: 50, DurationMinutes: 30}},
+		Date:     futureDate(5),
+		Status:   models.StatusPending,
+	}
+
+	mockRepo.EXPECT().FindByID(uint(7)).Return(existing, nil)
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1, 2}).Return(catalog, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(ap models.Appointment) error {
+		require.Len(t, ap.Items, 2)
+		assert.Equal(t, uint(11), ap.Items[0].ID)
+		assert.Equal(t, 50.0, ap.Items[0].Price)
+		assert.Equal(t, 40.0, ap.Items[1].Price)
+		assert.Equal(t, 90.0, ap.TotalPrice())
+		return nil
+	})
+
+	_, err := apSrv.UpdateAppointment(adminClaims(9), 7, models.Appointment{Services: []models.Service{{ID: 1}, {ID: 2}}, Date: existing.Date})
+	assert.NoError(t, err)
+}
+
+// TestCreateAppointment_SnapshotsServices tests that new appointments book the catalog values
+func TestCreateAppointment_SnapshotsServices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+	expectBooking(mockRepo)
+
+	catalog := []models.Service{{ID: 1, Name: "Corte", Price: 50, DurationMinutes: 30}}
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(catalog, nil)
+	mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(ap models.Appointment) (models.Appointment, error) {
+		assert.Equal(t, []models.AppointmentItem{{ServiceID: 1, Name: "Corte", Price: 50, DurationMinutes: 30}}, ap.Items)
+		return ap, nil
+	})
+
+	// Prices sent by the client are ignored
+	_, _, err := apSrv.CreateAppointment(1, []models.Service{{ID: 1, Price: 1}}, futureDate(3), nil)
+	assert.NoError(t, err)
+}
+
+// TestAppointmentNotifications tests which changes make the customer receive a message
+func TestAppointmentNotifications(t *testing.T) {
+	customer := models.User{ID: 1, Name: "Maria", Email: "maria@example.com"}
+	services := []models.Service{{ID: 1, Name: "Corte", Price: 50, DurationMinutes: 30}}
+
+	t.Run("booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+		mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+		mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+		mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+		mockNotifier := mocks.NewMockAppointmentNotifier(ctrl)
+		expectSalonOpen(mockScheduleRepo)
+		apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, mockNotifier)
+		expectBooking(mockRepo)
+
+		created := models.Appointment{ID: 5, UserID: 1, User: customer, Services: services, Date: futureDate(3), Status: models.StatusPending}
+		mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil)
+		mockRepo.EXPECT().FindUserAppointmentsInWeek(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any()).Return(created, nil)
+		mockNotifier.EXPECT().Notify(notification.EventBooked, created)
+
+		_, _, err := apSrv.CreateAppointment(1, services, futureDate(3), nil)
+		assert.NoError(t, err)
+	})
+
+	statusTests := []struct {
+		name  string
+		to    models.AppointmentStatus
+		event notification.Event
+	}{
+		{"confirmation", models.StatusConfirmed, notification.EventConfirmed},
+		{"cancellation", models.StatusCanceled, notification.EventCanceled},
+		{"completion", models.StatusDone, ""},
+	}
+	for _, tt := range statusTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			mockNotifier := mocks.NewMockAppointmentNotifier(ctrl)
+			apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, mockNotifier)
+
+			from := models.StatusConfirmed
+			if tt.to == models.StatusConfirmed {
+				from = models.StatusPending
+			}
+			mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, User: customer, Status: from}, nil)
+			mockRepo.EXPECT().ChangeStatus(uint(7), from, tt.to, gomock.Any(), gomock.Any()).Return(nil)
+			if tt.event != "" {
+				mockNotifier.EXPECT().Notify(tt.event, gomock.Any()).Do(func(_ notification.Event, ap models.Appointment) {
+					assert.Equal(t, tt.to, ap.Status)
+					assert.Equal(t, customer.Email, ap.User.Email)
+				})
+			}
+
+			_, err := apSrv.ChangeStatus(adminClaims(9), 7, tt.to)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("reschedule", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+		expectSaving(mockRepo)
+		mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+		mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+		mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+		mockNotifier := mocks.NewMockAppointmentNotifier(ctrl)
+		expectSalonOpen(mockScheduleRepo)
+		apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, mockNotifier)
+
+		mockRepo.EXPECT().FindByID(uint(7)).Return(models.Appointment{ID: 7, UserID: 1, User: customer, Services: services, Date: futureDate(5), Status: models.StatusConfirmed}, nil)
+		mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil)
+		mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+		mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+		mockNotifier.EXPECT().Notify(notification.EventRescheduled, gomock.Any()).Do(func(_ notification.Event, ap models.Appointment) {
+			assert.Equal(t, futureDate(6), ap.Date)
+			assert.Equal(t, customer.Email, ap.User.Email)
+		})
+
+		_, err := apSrv.UpdateAppointment(adminClaims(9), 7, models.Appointment{Services: services, Date: futureDate(6)})
+		assert.NoError(t, err)
+	})
+}
+
+// TestCreateSeries_ReportsConflicts tests that the free dates of a series are booked and the busy ones reported
+func TestCreateSeries_ReportsConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	mockNotifier := mocks.NewMockAppointmentNotifier(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, mockNotifier)
+	expectBooking(mockRepo)
+
+	start := futureDate(3)
+	services := []models.Service{{ID: 1, Name: "Corte", DurationMinutes: 30}}
+	// The second week is already taken
+	busy := models.Appointment{ID: 9, UserID: 2, Services: services, Date: start.AddDate(0, 0, 7), Status: models.StatusConfirmed}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).DoAndReturn(func(from, to time.Time) ([]models.Appointment, error) {
+		if !busy.Date.Before(from) && busy.Date.Before(to) {
+			return []models.Appointment{busy}, nil
+		}
+		return nil, nil
+	}).Times(5)
+	mockRepo.EXPECT().CreateSeries(gomock.Any()).DoAndReturn(func(series models.AppointmentSeries) (models.AppointmentSeries, error) {
+		assert.Equal(t, uint(1), series.UserID)
+		series.ID = 4
+		return series, nil
+	})
+	var booked []time.Time
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(ap models.Appointment) (models.Appointment, error) {
+		require.NotNil(t, ap.SeriesID)
+		assert.Equal(t, uint(4), *ap.SeriesID)
+		assert.Equal(t, models.StatusPending, ap.Status)
+		booked = append(booked, ap.Date)
+		ap.ID = uint(len(booked))
+		return ap, nil
+	}).Times(2)
+	mockNotifier.EXPECT().Notify(notification.EventBooked, gomock.Any()).Times(2)
+
+	result, err := apSrv.CreateSeries(1, []models.Service{{ID: 1}}, start, nil, models.Recurrence{Frequency: models.FrequencyWeekly, Count: 3})
+	require.NoError(t, err)
+	require.NotNil(t, result.Series)
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 14)}, booked)
+	assert.Len(t, result.Appointments, 2)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, busy.Date, result.Conflicts[0].Date)
+	assert.Equal(t, models.ErrTimeSlotUnavailable.Error(), result.Conflicts[0].Error)
+}
+
+// TestCreateSeries_Unavailable tests that no series is created when none of its dates can be booked
+func TestCreateSeries_Unavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	apSrv := NewAppointmentService(nil, mockServiceRepo, nil, mockScheduleRepo, nil, nil, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(true, nil).Times(2)
+
+	result, err := apSrv.CreateSeries(1, []models.Service{{ID: 1}}, futureDate(3), nil, models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 2, Count: 2})
+	assert.ErrorIs(t, err, models.ErrSeriesUnavailable)
+	assert.Nil(t, result.Series)
+	assert.Len(t, result.Conflicts, 2)
+}
+
+// TestRecurrence_Occurrences tests the dates generated by each recurrence rule
+func TestRecurrence_Occurrences(t *testing.T) {
+	// The second Tuesday of March 2030
+	start := time.Date(2030, 3, 12, 10, 0, 0, 0, time.Local)
+
+	dates, err := models.Recurrence{Frequency: models.FrequencyWeekly, Interval: 2, Until: "2030-04-09"}.Occurrences(start)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 14), start.AddDate(0, 0, 28)}, dates)
+
+	dates, err = models.Recurrence{Frequency: models.FrequencyMonthlyWeekday, Count: 3}.Occurrences(start)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		start,
+		time.Date(2030, 4, 9, 10, 0, 0, 0, time.Local),
+		time.Date(2030, 5, 14, 10, 0, 0, 0, time.Local),
+	}, dates)
+
+	// The fifth Friday of March 2030 only comes back in May
+	fifth := time.Date(2030, 3, 29, 10, 0, 0, 0, time.Local)
+	dates, err = models.Recurrence{Frequency: models.FrequencyMonthlyWeekday, Count: 2}.Occurrences(fifth)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{fifth, time.Date(2030, 5, 31, 10, 0, 0, 0, time.Local)}, dates)
+
+	invalid := []models.Recurrence{
+		{Frequency: "DAILY", Count: 2},
+		{Frequency: models.FrequencyWeekly},
+		{Frequency: models.FrequencyWeekly, Count: 2, Until: "2030-04-09"},
+		{Frequency: models.FrequencyWeekly, Count: models.MaxSeriesOccurrences + 1},
+		{Frequency: models.FrequencyWeekly, Until: "2032-01-01"},
+	}
+	for _, rule := range invalid {
+		_, err := rule.Occurrences(start)
+		assert.ErrorIs(t, err, models.ErrInvalidRecurrence, "%+v", rule)
+	}
+}
+
+// TestUpdateFollowing_ShiftsSeries tests that the later appointments move by the same days to the new time
+func TestUpdateFollowing_ShiftsSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	expectSaving(mockRepo)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	expectSalonOpen(mockScheduleRepo)
+	apSrv := NewAppointmentService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil, nil, nil)
+
+	seriesID := uint(4)
+	services := []models.Service{{ID: 1, DurationMinutes: 30}}
+	first := models.Appointment{ID: 7, UserID: 1, Services: services, Date: futureDate(3), Status: models.StatusPending, SeriesID: &seriesID}
+	second := models.Appointment{ID: 8, UserID: 1, Services: services, Date: futureDate(10), Status: models.StatusConfirmed, SeriesID: &seriesID}
+	done := models.Appointment{ID: 9, UserID: 1, Services: services, Date: futureDate(17), Status: models.StatusCanceled, SeriesID: &seriesID}
+	stored := map[uint]models.Appointment{7: first, 8: second, 9: done}
+
+	mockRepo.EXPECT().ListSeries(seriesID, first.Date).Return([]models.Appointment{first, second, done}, nil)
+	mockRepo.EXPECT().FindByID(gomock.Any()).DoAndReturn(func(id uint) (models.Appointment, error) {
+		return stored[id], nil
+	}).AnyTimes()
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return(services, nil).Times(2)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil).Times(2)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	var moved []models.Appointment
+	mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(ap models.Appointment) error {
+		moved = append(moved, ap)
+		return nil
+	}).Times(2)
+
+	// One day later, at 15:30
+	newDate := first.Date.AddDate(0, 0, 1).Add(5*time.Hour + 30*time.Minute)
+	result, err := apSrv.UpdateFollowing(adminClaims(9), 7, models.Appointment{Services: services, Date: newDate})
+	require.NoError(t, err)
+	assert.Len(t, result.Appointments, 2)
+	assert.Empty(t, result.Conflicts)
+	require.Len(t, moved, 2)
+	assert.Equal(t, newDate, moved[0].Date)
+	assert.Equal(t, second.Date.AddDate(0, 0, 1).Add(5*time.Hour+30*time.Minute), moved[1].Date)
+	assert.Equal(t, models.StatusConfirmed, moved[1].Status, "the later appointments keep their status")
+	require.NotNil(t, moved[1].SeriesID)
+	assert.Equal(t, seriesID, *moved[1].SeriesID)
+}
+
+// TestCancelFollowing tests that the later appointments are canceled and the ones that cannot be are reported
+func TestCancelFollowing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, nil)
+
+	seriesID := uint(4)
+	first := models.Appointment{ID: 7, UserID: 1, Date: futureDate(3), Status: models.StatusPending, SeriesID: &seriesID}
+	second := models.Appointment{ID: 8, UserID: 1, Date: futureDate(10), Status: models.StatusConfirmed, SeriesID: &seriesID}
+	third := models.Appointment{ID: 9, UserID: 1, Date: futureDate(17), Status: models.StatusPending, SeriesID: &seriesID}
+	stored := map[uint]models.Appointment{7: first, 8: second, 9: third}
+
+	mockRepo.EXPECT().FindByID(gomock.Any()).DoAndReturn(func(id uint) (models.Appointment, error) {
+		return stored[id], nil
+	}).AnyTimes()
+	mockRepo.EXPECT().ListSeries(seriesID, first.Date).Return([]models.Appointment{first, second, third}, nil)
+	mockRepo.EXPECT().ChangeStatus(uint(7), models.StatusPending, models.StatusCanceled, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().ChangeStatus(uint(8), models.StatusConfirmed, models.StatusCanceled, gomock.Any(), gomock.Any()).Return(models.ErrInvalidStatusTransition)
+	mockRepo.EXPECT().ChangeStatus(uint(9), models.StatusPending, models.StatusCanceled, gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := apSrv.CancelFollowing(adminClaims(9), 7)
+	require.NoError(t, err)
+	assert.Len(t, result.Appointments, 2)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, uint(8), result.Conflicts[0].AppointmentID)
+
+	// Appointments outside a series have no following ones
+	stored[10] = models.Appointment{ID: 10, UserID: 1, Date: futureDate(3), Status: models.StatusPending}
+	_, err = apSrv.CancelFollowing(adminClaims(9), 10)
+	assert.ErrorIs(t, err, models.ErrNotInSeries)
+}
+
+// TestCancelUpcoming_Notifies checks that closing an account cancels like any other cancellation, so the reminders
+// are dropped and the waitlist is offered the slots
+func TestCancelUpcoming_Notifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockNotifier := mocks.NewMockAppointmentNotifier(ctrl)
+	apSrv := NewAppointmentService(mockRepo, nil, nil, nil, nil, nil, mockNotifier)
+
+	mockRepo.EXPECT().ListUpcomingByUser(uint(1), []models.AppointmentStatus{models.StatusPending, models.StatusConfirmed}, gomock.Any()).
+		Return([]models.Appointment{{ID: 7, UserID: 1, Status: models.StatusConfirmed}, {ID: 8, UserID: 1, Status: models.StatusPending}}, nil)
+	mockRepo.EXPECT().ChangeStatus(uint(7), models.StatusConfirmed, models.StatusCanceled, gomock.Any(), gomock.Any()).Return(nil)
+	// Canceled by staff in the meantime
+	mockRepo.EXPECT().ChangeStatus(uint(8), models.StatusPending, models.StatusCanceled, gomock.Any(), gomock.Any()).Return(models.ErrInvalidStatusTransition)
+	mockNotifier.EXPECT().Notify(notification.EventCanceled, gomock.Any()).Do(func(_ notification.Event, ap models.Appointment) {
+		assert.Equal(t, uint(7), ap.ID)
+		assert.Equal(t, models.StatusCanceled, ap.Status)
+	})
+
+	assert.NoError(t, apSrv.CancelUpcoming(1))
+}
@@ -1,33 +1,33 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthService interface {
 	GenerateToken(user models.User) (string, error)
-	ValidateToken(tokenString string) (*CustomClaims, error)
-	ValidateTokenWithRole(tokenString string, allowedRoles ...models.UserRole) (*CustomClaims, error)
-	RefreshToken(tokenString string) (string, error)
+	ValidateToken(tokenString string) (*models.CustomClaims, error)
+	ValidateTokenWithRole(tokenString string, allowedRoles ...models.UserRole) (*models.CustomClaims, error)
 }
 
-type authService struct {
-	secret string
-}
+// AccessTokenTTL is kept short because access tokens cannot be revoked, sessions are extended with refresh tokens
+const AccessTokenTTL = 15 * time.Minute
 
-type CustomClaims struct {
-	UserID uint            `json:"user_id"`
-	Email  string          `json:"email"`
-	Role   models.UserRole `json:"role"`
-	jwt.RegisteredClaims
+type authService struct {
+	keys *keys.Set
 }
 
-func NewAuthService(secret string) AuthService {
-	return &authService{secret: secret}
+// NewAuthService signs the tokens with the active key of the set. Tokens name their key in the
+// "kid" header, so the ones signed by older keys stay valid while those keys are in the set.
+func NewAuthService(keySet *keys.Set) AuthService {
+	return &authService{keys: keySet}
 }
 
 func (s *authService) GenerateToken(user models.User) (string, error) {
@@ -35,28 +35,45 @@ func (s *authService) GenerateToken(user models.User) (string, error) {
 		return "", errors.New("invalid user: missing ID")
 	}
 
-	claims := CustomClaims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+	id, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	claims := models.CustomClaims{
+		UserID:                 user.ID,
+		Email:                  user.Email,
+		Role:                   user.Role,
+		PasswordChangeRequired: user.MustChangePassword,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        id,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.secret))
+	key, err := s.keys.Signing()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
 }
 
-func (s *authService) ValidateToken(tokenString string) (*CustomClaims, error) {
-	claims := &CustomClaims{}
+func (s *authService) ValidateToken(tokenString string) (*models.CustomClaims, error) {
+	claims := &models.CustomClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys.Lookup(kid)
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		// The algorithm comes from the token, it must be the one of the key
+		if token.Method.Alg() != key.Method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return []byte(s.secret), nil
-	})
+		return key.Public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -69,7 +86,7 @@ func (s *authService) ValidateToken(tokenString string) (*CustomClaims, error) {
 	return claims, nil
 }
 
-func (s *authService) ValidateTokenWithRole(tokenString string, allowedRoles ...models.UserRole) (*CustomClaims, error) {
+func (s *authService) ValidateTokenWithRole(tokenString string, allowedRoles ...models.UserRole) (*models.CustomClaims, error) {
 	claims, err := s.ValidateToken(tokenString)
 	if err != nil {
 		return nil, err
@@ -89,47 +106,11 @@ func (s *authService) ValidateTokenWithRole(tokenString string, allowedRoles ...
 	return nil, errors.New("user role not authorized for this action")
 }
 
-func (s *authService) RefreshToken(tokenString string) (string, error) {
-	// Parse token without validating expiration
-	claims := &CustomClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.secret), nil
-	})
-
-	// Check for errors other than expiration
-	if err != nil {
-		if !errors.Is(err, jwt.ErrTokenExpired) {
-			return "", err
-		}
-	}
-
-	// Verify token signature is valid (even if expired)
-	if token == nil || !token.Valid && !errors.Is(err, jwt.ErrTokenExpired) {
-		return "", errors.New("invalid token")
-	}
-
-	// Check if token is too old to refresh (e.g., expired more than 7 days ago)
-	if claims.ExpiresAt != nil {
-		expirationTime := claims.ExpiresAt.Time
-		if time.Since(expirationTime) > 7*24*time.Hour {
-			return "", errors.New("token too old to refresh")
-		}
+// randomToken returns n random bytes, hex encoded
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-
-	// Generate new token with same user data
-	newClaims := CustomClaims{
-		UserID: claims.UserID,
-		Email:  claims.Email,
-		Role:   claims.Role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	newToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims)
-	return newToken.SignedString([]byte(s.secret))
+	return hex.EncodeToString(b), nil
 }
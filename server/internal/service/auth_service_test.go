@@ -1,15 +1,29 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// testKeySet returns a set with a single new Ed25519 key, all of them named "test"
+func testKeySet(t *testing.T) *keys.Set {
+	key, err := keys.GenerateEd25519("test")
+	require.NoError(t, err)
+	set, err := keys.NewSet("", key)
+	require.NoError(t, err)
+	return set
+}
+
 func TestGenerateToken(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "test@example.com",
@@ -23,7 +37,7 @@ func TestGenerateToken(t *testing.T) {
 }
 
 func TestGenerateToken_InvalidUser(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    0, // Invalid: no ID
 		Email: "test@example.com",
@@ -37,7 +51,7 @@ func TestGenerateToken_InvalidUser(t *testing.T) {
 }
 
 func TestValidateToken_Success(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "test@example.com",
@@ -57,16 +71,16 @@ func TestValidateToken_Success(t *testing.T) {
 }
 
 func TestValidateToken_InvalidToken(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 
 	claims, err := authSvc.ValidateToken("invalid.token.here")
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
 
-func TestValidateToken_WrongSecret(t *testing.T) {
-	authSvc1 := NewAuthService("secret-1")
-	authSvc2 := NewAuthService("secret-2")
+func TestValidateToken_WrongKey(t *testing.T) {
+	authSvc1 := NewAuthService(testKeySet(t))
+	authSvc2 := NewAuthService(testKeySet(t))
 
 	user := models.User{
 		ID:    1,
@@ -83,7 +97,7 @@ func TestValidateToken_WrongSecret(t *testing.T) {
 }
 
 func TestValidateTokenWithRole_Success(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "admin@example.com",
@@ -100,7 +114,7 @@ func TestValidateTokenWithRole_Success(t *testing.T) {
 }
 
 func TestValidateTokenWithRole_Unauthorized(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "customer@example.com",
@@ -117,7 +131,7 @@ func TestValidateTokenWithRole_Unauthorized(t *testing.T) {
 }
 
 func TestValidateTokenWithRole_MultipleRoles(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "customer@example.com",
@@ -134,7 +148,7 @@ func TestValidateTokenWithRole_MultipleRoles(t *testing.T) {
 }
 
 func TestValidateTokenWithRole_NoRoleRestriction(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "test@example.com",
@@ -151,7 +165,7 @@ func TestValidateTokenWithRole_NoRoleRestriction(t *testing.T) {
 }
 
 func TestValidateToken_ExpiredToken(t *testing.T) {
-	authSvc := NewAuthService("test-secret-key")
+	authSvc := NewAuthService(testKeySet(t))
 	user := models.User{
 		ID:    1,
 		Email: "test@example.com",
@@ -168,8 +182,81 @@ func TestValidateToken_ExpiredToken(t *testing.T) {
 }
 
 func TestValidateToken_InvalidSigningMethod(t *testing.T) {
-	// This test would require creating a token with a different signing method
-	// which is complex with the jwt library. We test it implicitly through other tests.
-	authSvc := NewAuthService("test-secret-key")
-	assert.NotNil(t, authSvc)
+	authSvc := NewAuthService(testKeySet(t))
+	claims := models.CustomClaims{
+		UserID: 1,
+		Role:   models.RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	// A token signed with a shared secret must not pass, even naming a known key
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmac.Header["kid"] = "test"
+	token, err := hmac.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = authSvc.ValidateToken(token)
+	assert.Error(t, err)
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	unsigned.Header["kid"] = "test"
+	token, err = unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = authSvc.ValidateToken(token)
+	assert.Error(t, err)
+}
+
+func TestValidateToken_UnknownKey(t *testing.T) {
+	key, err := keys.GenerateEd25519("other")
+	require.NoError(t, err)
+	other, err := keys.NewSet("", key)
+	require.NoError(t, err)
+
+	token, err := NewAuthService(other).GenerateToken(models.User{ID: 1, Role: models.RoleCustomer})
+	require.NoError(t, err)
+
+	_, err = NewAuthService(testKeySet(t)).ValidateToken(token)
+	assert.Error(t, err)
+}
+
+// TestAuthService_KeyRotation tests that tokens signed by the previous key stay valid until the key is retired
+func TestAuthService_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	old, err := keys.GenerateRSA("2026-01-01", keys.MinRSABits)
+	require.NoError(t, err)
+	require.NoError(t, keys.Write(dir, old))
+	set, err := keys.LoadDir(dir, "")
+	require.NoError(t, err)
+	authSvc := NewAuthService(set)
+	user := models.User{ID: 1, Email: "test@example.com", Role: models.RoleCustomer}
+
+	oldToken, err := authSvc.GenerateToken(user)
+	require.NoError(t, err)
+
+	// A newer key becomes the signing key
+	current, err := keys.GenerateEd25519("2026-02-01")
+	require.NoError(t, err)
+	require.NoError(t, keys.Write(dir, current))
+	require.NoError(t, set.Reload())
+
+	newToken, err := authSvc.GenerateToken(user)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &models.CustomClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02-01", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	_, err = authSvc.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	_, err = authSvc.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// Retiring the old key ends its tokens
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-01-01.pem")))
+	require.NoError(t, set.Reload())
+	_, err = authSvc.ValidateToken(oldToken)
+	assert.Error(t, err)
+	_, err = authSvc.ValidateToken(newToken)
+	assert.NoError(t, err)
 }
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+const (
+	// SlotInterval is the granularity of the start times offered to customers
+	SlotInterval = 15 * time.Minute
+
+	// maxAppointmentLength bounds how far back we look for appointments that may still be running
+	maxAppointmentLength = 24 * time.Hour
+)
+
+type AvailabilityService interface {
+	// GetAvailableSlots returns the free start times of the day. A nil professionalID means any available professional.
+	GetAvailableSlots(day time.Time, serviceIDs []uint, professionalID *uint) ([]time.Time, error)
+}
+
+type availabilityService struct {
+	repo             repository.AppointmentRepository
+	serviceRepo      repository.ServiceRepository
+	professionalRepo repository.ProfessionalRepository
+	scheduleRepo     repository.ScheduleRepository
+	waitlistRepo     repository.WaitlistRepository
+}
+
+// NewAvailabilityService builds the service. The waitlist repository may be nil, in which case no slot is held for waitlisted customers.
+func NewAvailabilityService(repo repository.AppointmentRepository, serviceRepo repository.ServiceRepository, professionalRepo repository.ProfessionalRepository, scheduleRepo repository.ScheduleRepository, waitlistRepo repository.WaitlistRepository) AvailabilityService {
+	return &availabilityService{repo: repo, serviceRepo: serviceRepo, professionalRepo: professionalRepo, scheduleRepo: scheduleRepo, waitlistRepo: waitlistRepo}
+}
+
+func (s *availabilityService) GetAvailableSlots(day time.Time, serviceIDs []uint, professionalID *uint) ([]time.Time, error) {
+	if len(serviceIDs) == 0 {
+		return nil, models.ErrAppointmentNoServices
+	}
+	services, err := resolveServices(s.serviceRepo, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	duration := models.TotalDuration(services)
+
+	candidates, err := loadCandidates(s.professionalRepo, services, professionalID)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, closing, err := workingWindow(s.scheduleRepo, day)
+	if errors.Is(err, models.ErrSalonClosed) || errors.Is(err, models.ErrHolidayClosure) {
+		return []time.Time{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := listBooked(s.repo, s.waitlistRepo, opening, closing)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	slots := []time.Time{}
+	for start := opening; !start.Add(duration).After(closing); start = start.Add(SlotInterval) {
+		if start.Before(now) {
+			continue
+		}
+		if _, ok := freeCandidate(booked, candidates, start, start.Add(duration), 0); ok {
+			slots = append(slots, start)
+		}
+	}
+	return slots, nil
+}
+
+// resolveServices loads the given services from the catalog so that price and
+// duration come from the database instead of the request body.
+func resolveServices(serviceRepo repository.ServiceRepository, ids []uint) ([]models.Service, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	found, err := serviceRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Service, len(found))
+	for _, srv := range found {
+		byID[srv.ID] = srv
+	}
+
+	services := make([]models.Service, 0, len(ids))
+	for _, id := range ids {
+		srv, ok := byID[id]
+		if !ok {
+			return nil, models.ErrUnknownService
+		}
+		services = append(services, srv)
+	}
+	return services, nil
+}
+
+func serviceIDs(services []models.Service) []uint {
+	ids := make([]uint, len(services))
+	for i, srv := range services {
+		ids[i] = srv.ID
+	}
+	return ids
+}
+
+// loadCandidates returns the agendas that may receive the services. A nil entry stands
+// for the shared agenda used while the salon has no professionals registered.
+func loadCandidates(professionalRepo repository.ProfessionalRepository, services []models.Service, requested *uint) ([]*uint, error) {
+	if requested != nil {
+		professional, err := professionalRepo.FindByID(*requested)
+		if err != nil {
+			return nil, err
+		}
+		if !professional.IsActive {
+			return nil, models.ErrUnknownProfessional
+		}
+		if !professional.CanPerform(services) {
+			return nil, models.ErrProfessionalCannotPerform
+		}
+		return []*uint{&professional.ID}, nil
+	}
+
+	professionals, err := professionalRepo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+	if len(professionals) == 0 {
+		return []*uint{nil}, nil
+	}
+
+	var candidates []*uint
+	for _, p := range professionals {
+		if p.CanPerform(services) {
+			id := p.ID
+			candidates = append(candidates, &id)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, models.ErrNoProfessionalForServices
+	}
+	return candidates, nil
+}
+
+// freeCandidate returns the first candidate agenda with no booking overlapping [start, end)
+func freeCandidate(booked []models.Appointment, candidates []*uint, start, end time.Time, ignoreID uint) (*uint, bool) {
+	for _, candidate := range candidates {
+		if !overlapsAny(booked, start, end, ignoreID, candidate) {
+			return candidate, true
+		}
+	}
+	return nil, false
+}
+
+// listBooked returns the appointments that may occupy time within [start, end), along with the
+// slots held for waitlisted customers
+func listBooked(repo repository.AppointmentRepository, waitlistRepo repository.WaitlistRepository, start, end time.Time) ([]models.Appointment, error) {
+	booked, err := repo.ListActiveByPeriod(start.Add(-maxAppointmentLength), end)
+	if err != nil || waitlistRepo == nil {
+		return booked, err
+	}
+	holds, err := waitlistRepo.ListHolds(start.Add(-maxAppointmentLength), end, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range holds {
+		booked = append(booked, offer.Hold())
+	}
+	return booked, nil
+}
+
+// assignProfessional picks the agenda that will receive the appointment, returning
+// models.ErrTimeSlotUnavailable when no candidate is free during the whole appointment.
+func assignProfessional(repo repository.AppointmentRepository, waitlistRepo repository.WaitlistRepository, professionalRepo repository.ProfessionalRepository, services []models.Service, start time.Time, ignoreID uint, requested *uint) (*uint, error) {
+	candidates, err := loadCandidates(professionalRepo, services, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	end := start.Add(models.TotalDuration(services))
+	booked, err := listBooked(repo, waitlistRepo, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	professionalID, ok := freeCandidate(booked, candidates, start, end, ignoreID)
+	if !ok {
+		return nil, models.ErrTimeSlotUnavailable
+	}
+	return professionalID, nil
+}
+
+// isUnbookable reports whether the error means the services cannot take the slot, rather than a failure
+func isUnbookable(err error) bool {
+	return errors.Is(err, models.ErrTimeSlotUnavailable) || errors.Is(err, models.ErrProfessionalCannotPerform) ||
+		errors.Is(err, models.ErrUnknownProfessional) || errors.Is(err, models.ErrNoProfessionalForServices) ||
+		errors.Is(err, models.ErrSalonClosed) || errors.Is(err, models.ErrHolidayClosure) ||
+		errors.Is(err, models.ErrOutsideBusinessHours)
+}
+
+func overlapsAny(booked []models.Appointment, start, end time.Time, ignoreID uint, professionalID *uint) bool {
+	for _, ap := range booked {
+		// Held slots have no ID, they are never the appointment being moved
+		if (ignoreID != 0 && ap.ID == ignoreID) || !ap.AssignedTo(professionalID) {
+			continue
+		}
+		if ap.Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
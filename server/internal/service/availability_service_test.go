@@ -0,0 +1,259 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func nextWeekDay(hour, min int) time.Time {
+	d := time.Now().AddDate(0, 0, 7)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, min, 0, 0, time.Local)
+}
+
+func TestAvailabilityService_GetAvailableSlots_EmptyDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	// 09:00 until 17:30, every 15 minutes
+	assert.Len(t, slots, 35)
+	assert.Equal(t, nextWeekDay(9, 0), slots[0])
+	assert.Equal(t, nextWeekDay(17, 30), slots[len(slots)-1])
+}
+
+func TestAvailabilityService_GetAvailableSlots_SkipsBookedTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	booked := models.Appointment{
+		ID:       1,
+		Services: []models.Service{{ID: 3, DurationMinutes: 120}},
+		Date:     nextWeekDay(10, 0),
+		Status:   models.StatusConfirmed,
+	}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{booked}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, slots, nextWeekDay(9, 30))
+	assert.NotContains(t, slots, nextWeekDay(9, 45))
+	assert.NotContains(t, slots, nextWeekDay(10, 0))
+	assert.NotContains(t, slots, nextWeekDay(11, 45))
+	assert.Contains(t, slots, nextWeekDay(12, 0))
+}
+
+func TestAvailabilityService_GetAvailableSlots_NoServices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	_, err := svc.GetAvailableSlots(nextWeekDay(0, 0), nil, nil)
+	assert.ErrorIs(t, err, models.ErrAppointmentNoServices)
+}
+
+func TestAvailabilityService_GetAvailableSlots_TooLongForDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 600}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, slots)
+}
+
+func TestAvailabilityService_GetAvailableSlots_AnyProfessional(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	haircut := models.Service{ID: 1, DurationMinutes: 30}
+	leila, ana := uint(1), uint(2)
+	professionals := []models.Professional{
+		{ID: leila, IsActive: true, Services: []models.Service{haircut}},
+		{ID: ana, IsActive: true, Services: []models.Service{haircut}},
+	}
+	booked := []models.Appointment{
+		{ID: 1, Services: []models.Service{haircut}, Date: nextWeekDay(9, 0), ProfessionalID: &leila},
+		{ID: 2, Services: []models.Service{haircut}, Date: nextWeekDay(10, 0), ProfessionalID: &leila},
+		{ID: 3, Services: []models.Service{haircut}, Date: nextWeekDay(10, 0), ProfessionalID: &ana},
+	}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(professionals, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(booked, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	// Ana is free at 09:00, but nobody is free at 10:00
+	assert.Contains(t, slots, nextWeekDay(9, 0))
+	assert.NotContains(t, slots, nextWeekDay(10, 0))
+}
+
+func TestAvailabilityService_GetAvailableSlots_UnassignedBlocksEveryone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	haircut := models.Service{ID: 1, DurationMinutes: 30}
+	leila := uint(1)
+	professionals := []models.Professional{{ID: leila, IsActive: true, Services: []models.Service{haircut}}}
+	// Booked before the salon registered professionals
+	legacy := models.Appointment{ID: 1, Services: []models.Service{haircut}, Date: nextWeekDay(10, 0), Status: models.StatusConfirmed}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(professionals, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{legacy}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, slots, nextWeekDay(9, 30))
+	assert.NotContains(t, slots, nextWeekDay(10, 0))
+	assert.Contains(t, slots, nextWeekDay(10, 30))
+}
+
+func TestAvailabilityService_GetAvailableSlots_NoProfessionalForServices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{3}).Return([]models.Service{{ID: 3, DurationMinutes: 120}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return([]models.Professional{{ID: 1, IsActive: true}}, nil)
+
+	_, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{3}, nil)
+	assert.ErrorIs(t, err, models.ErrNoProfessionalForServices)
+}
+
+func TestAvailabilityService_GetAvailableSlots_UsesBusinessHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 60}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.BusinessHours{IsOpen: true, OpensAt: "13:00", ClosesAt: "15:00"}, nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		nextWeekDay(13, 0), nextWeekDay(13, 15), nextWeekDay(13, 30), nextWeekDay(13, 45), nextWeekDay(14, 0),
+	}, slots)
+}
+
+func TestAvailabilityService_GetAvailableSlots_Holiday(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, nil)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(true, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, slots)
+}
+
+// TestAvailabilityService_GetAvailableSlots_SkipsHeldTime tests that a slot held for a waitlisted customer is not offered to others
+func TestAvailabilityService_GetAvailableSlots_SkipsHeldTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockProfessionalRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockScheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	mockWaitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+	svc := NewAvailabilityService(mockRepo, mockServiceRepo, mockProfessionalRepo, mockScheduleRepo, mockWaitlistRepo)
+
+	held := models.WaitlistOffer{ID: 1, Date: nextWeekDay(10, 0), DurationMinutes: 60, Status: models.OfferPending}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{{ID: 1, DurationMinutes: 30}}, nil)
+	mockProfessionalRepo.EXPECT().FindActive().Return(nil, nil)
+	mockScheduleRepo.EXPECT().IsHoliday(gomock.Any()).Return(false, nil)
+	mockScheduleRepo.EXPECT().FindBusinessHours(gomock.Any()).Return(models.DefaultBusinessHours(time.Monday), nil)
+	mockRepo.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return([]models.Appointment{}, nil)
+	mockWaitlistRepo.EXPECT().ListHolds(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.WaitlistOffer{held}, nil)
+
+	slots, err := svc.GetAvailableSlots(nextWeekDay(0, 0), []uint{1}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, slots, nextWeekDay(9, 30))
+	assert.NotContains(t, slots, nextWeekDay(10, 0))
+	assert.NotContains(t, slots, nextWeekDay(10, 45))
+	assert.Contains(t, slots, nextWeekDay(11, 0))
+}
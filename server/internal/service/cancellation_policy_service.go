@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+type CancellationPolicyService interface {
+	GetPolicy() (models.CancellationPolicy, error)
+	UpdatePolicy(policy models.CancellationPolicy) (models.CancellationPolicy, error)
+	ListUserFees(userID uint) ([]models.CustomerFee, error)
+}
+
+type cancellationPolicyService struct {
+	repo    repository.CancellationPolicyRepository
+	feeRepo repository.FeeRepository
+}
+
+func NewCancellationPolicyService(repo repository.CancellationPolicyRepository, feeRepo repository.FeeRepository) CancellationPolicyService {
+	return &cancellationPolicyService{repo: repo, feeRepo: feeRepo}
+}
+
+func (s *cancellationPolicyService) GetPolicy() (models.CancellationPolicy, error) {
+	return s.repo.Get()
+}
+
+func (s *cancellationPolicyService) UpdatePolicy(policy models.CancellationPolicy) (models.CancellationPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return models.CancellationPolicy{}, err
+	}
+	return s.repo.Save(policy)
+}
+
+func (s *cancellationPolicyService) ListUserFees(userID uint) ([]models.CustomerFee, error) {
+	return s.feeRepo.ListByUser(userID)
+}
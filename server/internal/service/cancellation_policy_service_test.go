@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellationPolicyService_UpdatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  models.CancellationPolicy
+		wantErr bool
+	}{
+		{"default", models.DefaultCancellationPolicy(), false},
+		{"with fees and limit", models.CancellationPolicy{RescheduleNoticeHours: 24, CancelNoticeHours: 12, LateCancelFeePercent: 50, NoShowFeePercent: 100, MaxReschedules: 3}, false},
+		{"negative notice", models.CancellationPolicy{CancelNoticeHours: -1}, true},
+		{"negative limit", models.CancellationPolicy{MaxReschedules: -1}, true},
+		{"fee above 100%", models.CancellationPolicy{LateCancelFeePercent: 120}, true},
+		{"negative no-show fee", models.CancellationPolicy{NoShowFeePercent: -5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+			svc := NewCancellationPolicyService(mockRepo, nil)
+
+			if !tt.wantErr {
+				mockRepo.EXPECT().Save(tt.policy).Return(tt.policy, nil)
+			}
+
+			_, err := svc.UpdatePolicy(tt.policy)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, models.ErrInvalidCancellationPolicy)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// LoginStateTTL is how long the user has to log in at the provider
+	LoginStateTTL = 10 * time.Minute
+	// LoginCodeTTL is how long the web app has to trade the login code for the tokens
+	LoginCodeTTL = time.Minute
+)
+
+// IdentityService logs users in through OpenID Connect providers
+type IdentityService interface {
+	Providers() []string
+	// Begin returns the address of the provider login page and the state the provider sends back.
+	// The state must also be bound to the browser, so a callback started elsewhere is refused.
+	Begin(ctx context.Context, provider string) (authURL, state string, err error)
+	// Complete verifies the provider response, finds, links or creates the user, and returns a
+	// single-use code the web app trades for the tokens
+	Complete(ctx context.Context, provider, state, code string) (string, error)
+	Exchange(loginCode string) (models.User, models.TokenPair, error)
+}
+
+type identityService struct {
+	providers  map[string]*oidc.Provider
+	repo       repository.IdentityRepository
+	userRepo   repository.UserRepository
+	tokenRepo  repository.AccountTokenRepository
+	sessionSvc SessionService
+	now        func() time.Time
+}
+
+func NewIdentityService(providers []*oidc.Provider, repo repository.IdentityRepository, userRepo repository.UserRepository, tokenRepo repository.AccountTokenRepository, sessionSvc SessionService) IdentityService {
+	byName := make(map[string]*oidc.Provider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &identityService{
+		providers:  byName,
+		repo:       repo,
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		sessionSvc: sessionSvc,
+		now:        time.Now,
+	}
+}
+
+func (s *identityService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *identityService) Begin(ctx context.Context, provider string) (string, string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", "", models.ErrUnknownIdentityProvider
+	}
+
+	var values [3]string
+	for i := range values {
+		value, err := oidc.NewVerifier()
+		if err != nil {
+			return "", "", err
+		}
+		values[i] = value
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+	err = s.repo.CreateState(models.LoginState{
+		StateHash:    hashToken(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    s.now().Add(LoginStateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+func (s *identityService) Complete(ctx context.Context, provider, state, code string) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", models.ErrUnknownIdentityProvider
+	}
+	if state == "" || code == "" {
+		return "", models.ErrInvalidLoginState
+	}
+	loginState, err := s.repo.ConsumeState(hashToken(state))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", models.ErrInvalidLoginState
+	}
+	if err != nil {
+		return "", err
+	}
+	if loginState.Provider != provider || !s.now().Before(loginState.ExpiresAt) {
+		return "", models.ErrInvalidLoginState
+	}
+
+	claims, err := p.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return "", err
+	}
+	user, err := s.findOrCreateUser(provider, claims)
+	if err != nil {
+		return "", err
+	}
+
+	loginCode, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	_, err = s.tokenRepo.Create(models.AccountToken{
+		UserID:    user.ID,
+		Purpose:   models.PurposeOIDCLogin,
+		TokenHash: hashToken(loginCode),
+		ExpiresAt: s.now().Add(LoginCodeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return loginCode, nil
+}
+
+func (s *identityService) Exchange(loginCode string) (models.User, models.TokenPair, error) {
+	if loginCode == "" {
+		return models.User{}, models.TokenPair{}, models.ErrInvalidAccountToken
+	}
+	found, err := s.tokenRepo.FindByHash(models.PurposeOIDCLogin, hashToken(loginCode))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, models.TokenPair{}, models.ErrInvalidAccountToken
+	}
+	if err != nil {
+		return models.User{}, models.TokenPair{}, err
+	}
+	if found.UsedAt != nil || !s.now().Before(found.ExpiresAt) {
+		return models.User{}, models.TokenPair{}, models.ErrInvalidAccountToken
+	}
+	used, err := s.tokenRepo.MarkUsed(found.ID, s.now())
+	if err != nil {
+		return models.User{}, models.TokenPair{}, err
+	}
+	if !used {
+		return models.User{}, models.TokenPair{}, models.ErrInvalidAccountToken
+	}
+
+	user, err := s.userRepo.FindByID(found.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !user.IsActive) {
+		return models.User{}, models.TokenPair{}, models.ErrUserInactive
+	}
+	if err != nil {
+		return models.User{}, models.TokenPair{}, err
+	}
+	tokens, err := s.sessionSvc.StartSession(user)
+	if err != nil {
+		return models.User{}, models.TokenPair{}, err
+	}
+	return user, tokens, nil
+}
+
+// findOrCreateUser returns the user linked to the provider account. An account seen for the first time
+// is linked to the user with the same email, or to a new customer, as long as the provider verified the email.
+func (s *identityService) findOrCreateUser(provider string, claims oidc.Claims) (models.User, error) {
+	identity, err := s.repo.FindBySubject(provider, claims.Subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(identity.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !user.IsActive) {
+			return models.User{}, models.ErrUserInactive
+		}
+		return user, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return models.User{}, models.ErrIdentityEmailNotVerified
+	}
+	now := s.now()
+	user, err := s.userRepo.FindByEmail(claims.Email)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		name := claims.Name
+		if name == "" {
+			name, _, _ = strings.Cut(claims.Email, "@")
+		}
+		// Without a password, the user logs in through the provider or sets one with a password reset
+		user, err = s.userRepo.Create(models.User{
+			Email:           claims.Email,
+			Name:            name,
+			Role:            models.RoleCustomer,
+			IsActive:        true,
+			EmailVerifiedAt: &now,
+		})
+		if err != nil {
+			return models.User{}, err
+		}
+	case err != nil:
+		return models.User{}, err
+	case !user.IsActive:
+		return models.User{}, models.ErrUserInactive
+	case !user.EmailVerified():
+		// The provider proved the user owns the email
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return models.User{}, err
+		}
+	}
+
+	_, err = s.repo.Create(models.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
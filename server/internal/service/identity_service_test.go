@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc/oidctest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type identityMocks struct {
+	identities *mocks.MockIdentityRepository
+	users      *mocks.MockUserRepository
+	tokens     *mocks.MockAccountTokenRepository
+	sessions   *mocks.MockSessionService
+}
+
+// newTestIdentityService logs in through a local provider registered as "mock"
+func newTestIdentityService(t *testing.T, ctrl *gomock.Controller) (IdentityService, identityMocks, *oidctest.Server) {
+	server, err := oidctest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	server.SetUser(oidctest.User{Subject: "sub-1", Email: "maria@example.com", EmailVerified: true, Name: "Maria"})
+
+	m := identityMocks{
+		identities: mocks.NewMockIdentityRepository(ctrl),
+		users:      mocks.NewMockUserRepository(ctrl),
+		tokens:     mocks.NewMockAccountTokenRepository(ctrl),
+		sessions:   mocks.NewMockSessionService(ctrl),
+	}
+	provider := oidc.NewProvider(server.Config("mock", "http://localhost:8080/api/auth/oidc/mock/callback"), nil)
+	return NewIdentityService([]*oidc.Provider{provider}, m.identities, m.users, m.tokens, m.sessions), m, server
+}
+
+// loginAtProvider starts a login and follows the provider login page, returning the state and the code it sent back
+func loginAtProvider(t *testing.T, svc IdentityService, m identityMocks) (string, string) {
+	var stored models.LoginState
+	m.identities.EXPECT().CreateState(gomock.Any()).DoAndReturn(func(state models.LoginState) error {
+		stored = state
+		return nil
+	})
+	authURL, state, err := svc.Begin(context.Background(), "mock")
+	require.NoError(t, err)
+	assert.Equal(t, hashToken(state), stored.StateHash, "only the hash of the state is stored")
+	assert.Equal(t, "mock", stored.Provider)
+	m.identities.EXPECT().ConsumeState(hashToken(state)).Return(stored, nil).MaxTimes(1)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, state, location.Query().Get("state"))
+	return state, location.Query().Get("code")
+}
+
+// expectLoginCode stores the login code issued to the user and returns it as found by its hash
+func expectLoginCode(t *testing.T, m identityMocks, userID uint) *models.AccountToken {
+	stored := &models.AccountToken{}
+	m.tokens.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.AccountToken) (models.AccountToken, error) {
+		assert.Equal(t, userID, token.UserID)
+		token.ID = 1
+		*stored = token
+		return token, nil
+	})
+	return stored
+}
+
+func TestIdentityService_Providers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, _, _ := newTestIdentityService(t, ctrl)
+
+	assert.Equal(t, []string{"mock"}, svc.Providers())
+	_, _, err := svc.Begin(context.Background(), "unknown")
+	assert.ErrorIs(t, err, models.ErrUnknownIdentityProvider)
+}
+
+// TestIdentityService_NewUser tests that a provider account seen for the first time becomes a customer
+func TestIdentityService_NewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m, _ := newTestIdentityService(t, ctrl)
+
+	state, code := loginAtProvider(t, svc, m)
+	m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{}, gorm.ErrRecordNotFound)
+	m.users.EXPECT().FindByEmail("maria@example.com").Return(models.User{}, models.ErrUserNotFound)
+	var created models.User
+	m.users.EXPECT().Create(gomock.Any()).DoAndReturn(func(user models.User) (models.User, error) {
+		user.ID = 7
+		created = user
+		return user, nil
+	})
+	m.identities.EXPECT().Create(models.UserIdentity{UserID: 7, Provider: "mock", Subject: "sub-1", Email: "maria@example.com"}).Return(models.UserIdentity{ID: 1}, nil)
+	stored := expectLoginCode(t, m, 7)
+
+	loginCode, err := svc.Complete(context.Background(), "mock", state, code)
+	require.NoError(t, err)
+	assert.Equal(t, "Maria", created.Name)
+	assert.Equal(t, models.RoleCustomer, created.Role)
+	assert.True(t, created.EmailVerified())
+	assert.Empty(t, created.Password)
+	assert.Equal(t, hashToken(loginCode), stored.TokenHash)
+	assert.Equal(t, models.PurposeOIDCLogin, stored.Purpose)
+	assert.WithinDuration(t, time.Now().Add(LoginCodeTTL), stored.ExpiresAt, 5*time.Second)
+
+	// The web app trades the code for the session, once
+	m.tokens.EXPECT().FindByHash(models.PurposeOIDCLogin, hashToken(loginCode)).Return(*stored, nil)
+	m.tokens.EXPECT().MarkUsed(uint(1), gomock.Any()).Return(true, nil)
+	m.users.EXPECT().FindByID(uint(7)).Return(created, nil)
+	m.sessions.EXPECT().StartSession(created).Return(models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+
+	user, tokens, err := svc.Exchange(loginCode)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), user.ID)
+	assert.Equal(t, "access", tokens.AccessToken)
+
+	m.tokens.EXPECT().FindByHash(models.PurposeOIDCLogin, hashToken(loginCode)).Return(*stored, nil)
+	m.tokens.EXPECT().MarkUsed(uint(1), gomock.Any()).Return(false, nil)
+	_, _, err = svc.Exchange(loginCode)
+	assert.ErrorIs(t, err, models.ErrInvalidAccountToken)
+}
+
+// TestIdentityService_LinksByVerifiedEmail tests that an existing user is linked to the provider account with the same email
+func TestIdentityService_LinksByVerifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m, _ := newTestIdentityService(t, ctrl)
+
+	existing := models.User{ID: 3, Email: "maria@example.com", Role: models.RoleCustomer, IsActive: true}
+	state, code := loginAtProvider(t, svc, m)
+	m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{}, gorm.ErrRecordNotFound)
+	m.users.EXPECT().FindByEmail("maria@example.com").Return(existing, nil)
+	m.users.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+		assert.True(t, user.EmailVerified(), "the provider verified the email")
+		return nil
+	})
+	m.identities.EXPECT().Create(gomock.Any()).DoAndReturn(func(identity models.UserIdentity) (models.UserIdentity, error) {
+		assert.Equal(t, uint(3), identity.UserID)
+		return identity, nil
+	})
+	expectLoginCode(t, m, 3)
+
+	_, err := svc.Complete(context.Background(), "mock", state, code)
+	assert.NoError(t, err)
+}
+
+func TestIdentityService_KnownIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m, server := newTestIdentityService(t, ctrl)
+	// The email at the provider changed since the account was linked
+	server.SetUser(oidctest.User{Subject: "sub-1", Email: "new@example.com", EmailVerified: false})
+
+	state, code := loginAtProvider(t, svc, m)
+	m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{UserID: 3}, nil)
+	m.users.EXPECT().FindByID(uint(3)).Return(models.User{ID: 3, IsActive: true}, nil)
+	expectLoginCode(t, m, 3)
+
+	_, err := svc.Complete(context.Background(), "mock", state, code)
+	assert.NoError(t, err)
+}
+
+func TestIdentityService_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    oidctest.User
+		setup   func(m identityMocks)
+		wantErr error
+	}{
+		{
+			name: "unverified email",
+			user: oidctest.User{Subject: "sub-1", Email: "maria@example.com", EmailVerified: false},
+			setup: func(m identityMocks) {
+				m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{}, gorm.ErrRecordNotFound)
+			},
+			wantErr: models.ErrIdentityEmailNotVerified,
+		},
+		{
+			name: "inactive user",
+			user: oidctest.User{Subject: "sub-1", Email: "maria@example.com", EmailVerified: true},
+			setup: func(m identityMocks) {
+				m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{}, gorm.ErrRecordNotFound)
+				m.users.EXPECT().FindByEmail("maria@example.com").Return(models.User{ID: 3, IsActive: false}, nil)
+			},
+			wantErr: models.ErrUserInactive,
+		},
+		{
+			name: "linked user deleted",
+			user: oidctest.User{Subject: "sub-1", Email: "maria@example.com", EmailVerified: true},
+			setup: func(m identityMocks) {
+				m.identities.EXPECT().FindBySubject("mock", "sub-1").Return(models.UserIdentity{UserID: 3}, nil)
+				m.users.EXPECT().FindByID(uint(3)).Return(models.User{}, models.ErrUserNotFound)
+			},
+			wantErr: models.ErrUserInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc, m, server := newTestIdentityService(t, ctrl)
+			server.SetUser(tt.user)
+
+			state, code := loginAtProvider(t, svc, m)
+			tt.setup(m)
+			_, err := svc.Complete(context.Background(), "mock", state, code)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIdentityService_InvalidState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m, _ := newTestIdentityService(t, ctrl)
+
+	m.identities.EXPECT().ConsumeState(hashToken("unknown")).Return(models.LoginState{}, gorm.ErrRecordNotFound)
+	_, err := svc.Complete(context.Background(), "mock", "unknown", "code")
+	assert.ErrorIs(t, err, models.ErrInvalidLoginState)
+
+	m.identities.EXPECT().ConsumeState(hashToken("expired")).Return(models.LoginState{Provider: "mock", ExpiresAt: time.Now().Add(-time.Second)}, nil)
+	_, err = svc.Complete(context.Background(), "mock", "expired", "code")
+	assert.ErrorIs(t, err, models.ErrInvalidLoginState)
+
+	m.identities.EXPECT().ConsumeState(hashToken("other")).Return(models.LoginState{Provider: "google", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	_, err = svc.Complete(context.Background(), "mock", "other", "code")
+	assert.ErrorIs(t, err, models.ErrInvalidLoginState)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/security"
+)
+
+var (
+	// DefaultAccountThrottle slows down password guessing on a single account
+	DefaultAccountThrottle = models.ThrottlePolicy{
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockAfter:    10,
+		LockDuration: 15 * time.Minute,
+		Window:       24 * time.Hour,
+	}
+	// DefaultIPThrottle is looser, since many customers may share the same address
+	DefaultIPThrottle = models.ThrottlePolicy{
+		FreeAttempts: 10,
+		BaseDelay:    time.Second,
+		MaxDelay:     5 * time.Minute,
+		LockAfter:    100,
+		LockDuration: time.Hour,
+		Window:       24 * time.Hour,
+	}
+)
+
+// Reasons of failed logins, as recorded in the security events
+const (
+	ReasonUnknownEmail  = "unknown_email"
+	ReasonWrongPassword = "wrong_password"
+	// ReasonInactiveAccount counts guesses against a deactivated account like any other failure
+	ReasonInactiveAccount = "inactive_account"
+)
+
+// LoginGuard tracks failed logins per account and per IP address and tells when they must wait
+type LoginGuard interface {
+	// Check returns a *models.LoginThrottledError while the email or the IP must wait before trying again
+	Check(email, ip string) error
+	Failed(email, ip string, userID *uint, reason string) error
+	// Succeeded forgets the failures of the account. The failures of the IP are kept, otherwise an
+	// attacker could reset them by logging into an account of their own.
+	Succeeded(email string) error
+	Unlock(email string, by uint) error
+}
+
+type loginGuard struct {
+	repo     repository.LoginThrottleRepository
+	recorder security.Recorder
+	account  models.ThrottlePolicy
+	ip       models.ThrottlePolicy
+	now      func() time.Time
+}
+
+func NewLoginGuard(repo repository.LoginThrottleRepository, recorder security.Recorder, account, ip models.ThrottlePolicy) LoginGuard {
+	return &loginGuard{repo: repo, recorder: recorder, account: account, ip: ip, now: time.Now}
+}
+
+func (g *loginGuard) Check(email, ip string) error {
+	email = normalizeEmail(email)
+	now := g.now()
+	for _, check := range []struct {
+		key    string
+		policy models.ThrottlePolicy
+	}{
+		{models.AccountThrottleKey(email), g.account},
+		{models.IPThrottleKey(ip), g.ip},
+	} {
+		throttle, err := g.repo.Find(check.key)
+		if err != nil {
+			return err
+		}
+		until := check.policy.BlockedUntil(throttle, now)
+		if until.IsZero() {
+			continue
+		}
+		g.recorder.Record(context.Background(), security.Event{
+			Type:     security.EventLoginThrottled,
+			Email:    email,
+			IP:       ip,
+			Failures: throttle.Failures,
+			Until:    until,
+		})
+		return &models.LoginThrottledError{Until: until, Locked: throttle.LockedUntil != nil}
+	}
+	return nil
+}
+
+func (g *loginGuard) Failed(email, ip string, userID *uint, reason string) error {
+	email = normalizeEmail(email)
+	now := g.now()
+	event := security.Event{Type: security.EventLoginFailed, Email: email, IP: ip, UserID: userID, Reason: reason}
+
+	account, locked, err := g.recordFailure(models.AccountThrottleKey(email), g.account, now)
+	if err != nil {
+		return err
+	}
+	event.Failures = account.Failures
+	g.recorder.Record(context.Background(), event)
+	if locked {
+		g.recorder.Record(context.Background(), security.Event{
+			Type: security.EventAccountLocked, Email: email, IP: ip, UserID: userID, Failures: account.Failures, Until: *account.LockedUntil,
+		})
+	}
+
+	address, locked, err := g.recordFailure(models.IPThrottleKey(ip), g.ip, now)
+	if err != nil {
+		return err
+	}
+	if locked {
+		g.recorder.Record(context.Background(), security.Event{
+			Type: security.EventIPLocked, IP: ip, Failures: address.Failures, Until: *address.LockedUntil,
+		})
+	}
+	return nil
+}
+
+func (g *loginGuard) Succeeded(email string) error {
+	return g.repo.Delete(models.AccountThrottleKey(normalizeEmail(email)))
+}
+
+func (g *loginGuard) Unlock(email string, by uint) error {
+	email = normalizeEmail(email)
+	if err := g.repo.Delete(models.AccountThrottleKey(email)); err != nil {
+		return err
+	}
+	g.recorder.Record(context.Background(), security.Event{Type: security.EventAccountUnlocked, Email: email, ActorID: &by})
+	return nil
+}
+
+// recordFailure counts the failure in a single change of the stored throttle, so failures of parallel
+// attempts are all counted
+func (g *loginGuard) recordFailure(key string, policy models.ThrottlePolicy, now time.Time) (models.LoginThrottle, bool, error) {
+	locked := false
+	throttle, err := g.repo.Modify(key, func(throttle *models.LoginThrottle) {
+		locked = policy.RecordFailure(throttle, now)
+	})
+	return throttle, locked, err
+}
+
+// normalizeEmail makes "Maria@Example.com " and "maria@example.com" share the same attempts
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
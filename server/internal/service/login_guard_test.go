@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/security"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedEvents []security.Event
+
+func (r *recordedEvents) Record(_ context.Context, event security.Event) {
+	*r = append(*r, event)
+}
+
+func (r recordedEvents) types() []security.EventType {
+	var types []security.EventType
+	for _, event := range r {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// newTestLoginGuard keeps the throttles in a map, so the tests follow a sequence of attempts
+func newTestLoginGuard(ctrl *gomock.Controller, now *time.Time) (*loginGuard, map[string]models.LoginThrottle, *recordedEvents) {
+	store := map[string]models.LoginThrottle{}
+	repo := mocks.NewMockLoginThrottleRepository(ctrl)
+	repo.EXPECT().Find(gomock.Any()).DoAndReturn(func(key string) (models.LoginThrottle, error) {
+		if throttle, ok := store[key]; ok {
+			return throttle, nil
+		}
+		return models.LoginThrottle{Key: key}, nil
+	}).AnyTimes()
+	repo.EXPECT().Modify(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, change func(*models.LoginThrottle)) (models.LoginThrottle, error) {
+		throttle, ok := store[key]
+		if !ok {
+			throttle = models.LoginThrottle{Key: key}
+		}
+		change(&throttle)
+		store[key] = throttle
+		return throttle, nil
+	}).AnyTimes()
+	repo.EXPECT().Delete(gomock.Any()).DoAndReturn(func(key string) error {
+		delete(store, key)
+		return nil
+	}).AnyTimes()
+
+	events := &recordedEvents{}
+	guard := NewLoginGuard(repo, events, DefaultAccountThrottle, DefaultIPThrottle).(*loginGuard)
+	guard.now = func() time.Time { return *now }
+	return guard, store, events
+}
+
+func TestThrottlePolicy_Delay(t *testing.T) {
+	policy := DefaultAccountThrottle
+	assert.Equal(t, time.Duration(0), policy.Delay(2))
+	assert.Equal(t, time.Second, policy.Delay(3))
+	assert.Equal(t, 2*time.Second, policy.Delay(4))
+	assert.Equal(t, 32*time.Second, policy.Delay(8))
+	assert.Equal(t, time.Minute, policy.Delay(9), "the wait is capped")
+}
+
+func TestLoginGuard_BackoffAfterFreeAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	guard, _, events := newTestLoginGuard(ctrl, &now)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, guard.Failed("Maria@Example.com", "10.0.0.1", nil, ReasonWrongPassword))
+		assert.NoError(t, guard.Check("maria@example.com", "10.0.0.1"), "the first failures cost nothing")
+	}
+
+	require.NoError(t, guard.Failed("maria@example.com", "10.0.0.1", nil, ReasonWrongPassword))
+	err := guard.Check("maria@example.com", "10.0.0.2")
+	var throttled *models.LoginThrottledError
+	require.ErrorAs(t, err, &throttled, "the account is throttled from any address")
+	assert.False(t, throttled.Locked)
+	assert.Equal(t, now.Add(time.Second), throttled.Until)
+	assert.NoError(t, guard.Check("other@example.com", "10.0.0.1"), "the address is below its own limit")
+
+	now = now.Add(time.Second)
+	assert.NoError(t, guard.Check("maria@example.com", "10.0.0.1"))
+	assert.Equal(t, security.EventLoginThrottled, events.types()[3])
+	assert.Equal(t, 3, (*events)[2].Failures)
+}
+
+func TestLoginGuard_LocksAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	guard, store, events := newTestLoginGuard(ctrl, &now)
+	userID := uint(7)
+
+	for i := 0; i < DefaultAccountThrottle.LockAfter; i++ {
+		require.NoError(t, guard.Failed("maria@example.com", "10.0.0.1", &userID, ReasonWrongPassword))
+		now = now.Add(2 * time.Minute)
+	}
+	lockedAt := now.Add(-2 * time.Minute)
+
+	var throttled *models.LoginThrottledError
+	require.ErrorAs(t, guard.Check("maria@example.com", "10.0.0.1"), &throttled)
+	assert.True(t, throttled.Locked)
+	assert.Equal(t, lockedAt.Add(DefaultAccountThrottle.LockDuration), throttled.Until)
+	assert.Contains(t, events.types(), security.EventAccountLocked)
+
+	// After the lock the account starts over
+	now = lockedAt.Add(DefaultAccountThrottle.LockDuration)
+	assert.NoError(t, guard.Check("maria@example.com", "10.0.0.2"))
+	require.NoError(t, guard.Failed("maria@example.com", "10.0.0.2", &userID, ReasonWrongPassword))
+	assert.Equal(t, 1, store[models.AccountThrottleKey("maria@example.com")].Failures)
+	assert.NoError(t, guard.Check("maria@example.com", "10.0.0.2"))
+}
+
+func TestLoginGuard_SucceededKeepsIPFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	guard, store, _ := newTestLoginGuard(ctrl, &now)
+
+	require.NoError(t, guard.Failed("maria@example.com", "10.0.0.1", nil, ReasonWrongPassword))
+	require.NoError(t, guard.Succeeded("maria@example.com"))
+
+	assert.NotContains(t, store, models.AccountThrottleKey("maria@example.com"))
+	assert.Equal(t, 1, store[models.IPThrottleKey("10.0.0.1")].Failures)
+}
+
+func TestLoginGuard_Unlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	guard, _, events := newTestLoginGuard(ctrl, &now)
+
+	for i := 0; i < DefaultAccountThrottle.LockAfter; i++ {
+		require.NoError(t, guard.Failed("maria@example.com", "10.0.0.1", nil, ReasonWrongPassword))
+	}
+	require.Error(t, guard.Check("maria@example.com", "10.0.0.2"))
+
+	require.NoError(t, guard.Unlock("maria@example.com", 1))
+	assert.NoError(t, guard.Check("maria@example.com", "10.0.0.2"))
+	last := (*events)[len(*events)-1]
+	assert.Equal(t, security.EventAccountUnlocked, last.Type)
+	assert.Equal(t, uint(1), *last.ActorID)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+)
+
+// AppointmentAction is an operation performed on an existing appointment
+type AppointmentAction string
+
+const (
+	ActionViewAppointment   AppointmentAction = "view"
+	ActionUpdateAppointment AppointmentAction = "update"
+	ActionCancelAppointment AppointmentAction = "cancel"
+	ActionMergeAppointment  AppointmentAction = "merge"
+	ActionChangeStatus      AppointmentAction = "change_status"
+)
+
+// Policy decides whether the caller identified by the token claims may perform an action on an appointment.
+// How much notice customers must give is up to the configured models.CancellationPolicy.
+type Policy interface {
+	Authorize(claims *models.CustomClaims, action AppointmentAction, ap models.Appointment) error
+}
+
+type appointmentPolicy struct{}
+
+func NewAppointmentPolicy() Policy {
+	return &appointmentPolicy{}
+}
+
+// Authorize lets staff who manage appointments do anything and those who view them see any of them,
+// while customers may only touch their own appointments and cannot drive the operational status
+func (p *appointmentPolicy) Authorize(claims *models.CustomClaims, action AppointmentAction, ap models.Appointment) error {
+	if claims == nil {
+		return models.ErrAppointmentNotOwned
+	}
+	if claims.Role.Can(models.PermManageAppointments) {
+		return nil
+	}
+	if action == ActionViewAppointment && claims.Role.Can(models.PermViewAppointments) {
+		return nil
+	}
+	if action == ActionChangeStatus {
+		return models.ErrAdminOnly
+	}
+	if ap.UserID != claims.UserID {
+		return models.ErrAppointmentNotOwned
+	}
+	return nil
+}
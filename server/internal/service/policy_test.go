@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentPolicy_Authorize(t *testing.T) {
+	policy := NewAppointmentPolicy()
+
+	// Notice periods are up to the cancellation policy, so the date must not matter here
+	farAway := models.Appointment{ID: 1, UserID: 1, Date: time.Now().Add(72 * time.Hour)}
+	tomorrow := models.Appointment{ID: 2, UserID: 1, Date: time.Now().Add(24 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		claims  *models.CustomClaims
+		action  AppointmentAction
+		ap      models.Appointment
+		wantErr error
+	}{
+		{"missing claims", nil, ActionViewAppointment, farAway, models.ErrAppointmentNotOwned},
+
+		{"owner views", customerClaims(1), ActionViewAppointment, tomorrow, nil},
+		{"owner updates", customerClaims(1), ActionUpdateAppointment, farAway, nil},
+		{"owner cancels", customerClaims(1), ActionCancelAppointment, farAway, nil},
+		{"owner merges", customerClaims(1), ActionMergeAppointment, farAway, nil},
+		{"owner cancels tomorrow", customerClaims(1), ActionCancelAppointment, tomorrow, nil},
+		{"owner changes status", customerClaims(1), ActionChangeStatus, farAway, models.ErrAdminOnly},
+
+		{"other customer views", customerClaims(2), ActionViewAppointment, farAway, models.ErrAppointmentNotOwned},
+		{"other customer updates", customerClaims(2), ActionUpdateAppointment, farAway, models.ErrAppointmentNotOwned},
+		{"other customer cancels", customerClaims(2), ActionCancelAppointment, farAway, models.ErrAppointmentNotOwned},
+		{"other customer merges", customerClaims(2), ActionMergeAppointment, farAway, models.ErrAppointmentNotOwned},
+
+		{"admin views", adminClaims(9), ActionViewAppointment, tomorrow, nil},
+		{"admin updates tomorrow", adminClaims(9), ActionUpdateAppointment, tomorrow, nil},
+		{"admin cancels tomorrow", adminClaims(9), ActionCancelAppointment, tomorrow, nil},
+		{"admin merges tomorrow", adminClaims(9), ActionMergeAppointment, tomorrow, nil},
+		{"admin changes status", adminClaims(9), ActionChangeStatus, tomorrow, nil},
+
+		{"receptionist updates tomorrow", staffClaims(8, models.RoleReceptionist), ActionUpdateAppointment, tomorrow, nil},
+		{"receptionist changes status", staffClaims(8, models.RoleReceptionist), ActionChangeStatus, tomorrow, nil},
+		{"manager views", staffClaims(7, models.RoleManager), ActionViewAppointment, farAway, nil},
+		{"manager cancels", staffClaims(7, models.RoleManager), ActionCancelAppointment, farAway, models.ErrAppointmentNotOwned},
+		{"manager changes status", staffClaims(7, models.RoleManager), ActionChangeStatus, farAway, models.ErrAdminOnly},
+		{"professional views", staffClaims(6, models.RoleProfessional), ActionViewAppointment, farAway, models.ErrAppointmentNotOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.claims, tt.action, tt.ap)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func staffClaims(userID uint, role models.UserRole) *models.CustomClaims {
+	return &models.CustomClaims{UserID: userID, Role: role}
+}
+
+// TestRoles_Permissions tests the permission sets that define each role
+func TestRoles_Permissions(t *testing.T) {
+	for _, role := range models.Roles() {
+		assert.True(t, role.IsValid(), role)
+	}
+	assert.False(t, models.UserRole("owner").IsValid())
+
+	assert.True(t, models.RoleReceptionist.Can(models.PermManageAppointments))
+	assert.False(t, models.RoleReceptionist.Can(models.PermManageUsers))
+	assert.False(t, models.RoleReceptionist.Can(models.PermManageCatalog))
+	assert.True(t, models.RoleManager.Can(models.PermViewReports))
+	assert.False(t, models.RoleManager.Can(models.PermManageAppointments))
+	assert.Equal(t, []models.Permission{models.PermViewOwnAgenda}, models.RoleProfessional.Permissions())
+	assert.Empty(t, models.RoleCustomer.Permissions())
+	for _, permission := range models.RoleManager.Permissions() {
+		assert.True(t, models.RoleAdmin.Can(permission), permission)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+type ProfessionalService interface {
+	CreateProfessional(professional models.Professional) (models.Professional, error)
+	GetProfessional(id uint) (models.Professional, error)
+	ListProfessionals() ([]models.Professional, error)
+	ListActiveProfessionals() ([]models.Professional, error)
+	UpdateProfessional(professional models.Professional) (models.Professional, error)
+	DeleteProfessional(id uint) error
+}
+
+type professionalService struct {
+	repo        repository.ProfessionalRepository
+	serviceRepo repository.ServiceRepository
+}
+
+func NewProfessionalService(repo repository.ProfessionalRepository, serviceRepo repository.ServiceRepository) ProfessionalService {
+	return &professionalService{repo: repo, serviceRepo: serviceRepo}
+}
+
+func (s *professionalService) CreateProfessional(professional models.Professional) (models.Professional, error) {
+	if professional.Name == "" {
+		return models.Professional{}, errors.New("professional name is required")
+	}
+	services, err := resolveServices(s.serviceRepo, serviceIDs(professional.Services))
+	if err != nil {
+		return models.Professional{}, err
+	}
+	professional.Services = services
+	return s.repo.Create(professional)
+}
+
+func (s *professionalService) GetProfessional(id uint) (models.Professional, error) {
+	if id == 0 {
+		return models.Professional{}, errors.New("invalid professional ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *professionalService) ListProfessionals() ([]models.Professional, error) {
+	return s.repo.FindAll()
+}
+
+func (s *professionalService) ListActiveProfessionals() ([]models.Professional, error) {
+	return s.repo.FindActive()
+}
+
+func (s *professionalService) UpdateProfessional(professional models.Professional) (models.Professional, error) {
+	if professional.ID == 0 {
+		return models.Professional{}, errors.New("professional ID is required")
+	}
+	if professional.Name == "" {
+		return models.Professional{}, errors.New("professional name is required")
+	}
+	if _, err := s.repo.FindByID(professional.ID); err != nil {
+		return models.Professional{}, err
+	}
+	services, err := resolveServices(s.serviceRepo, serviceIDs(professional.Services))
+	if err != nil {
+		return models.Professional{}, err
+	}
+	professional.Services = services
+
+	if err := s.repo.Update(professional); err != nil {
+		return models.Professional{}, err
+	}
+	return s.repo.FindByID(professional.ID)
+}
+
+func (s *professionalService) DeleteProfessional(id uint) error {
+	if id == 0 {
+		return errors.New("invalid professional ID")
+	}
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfessionalService_CreateProfessional_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewProfessionalService(mockRepo, mockServiceRepo)
+
+	haircut := models.Service{ID: 1, Name: "Corte de Cabelo", Price: 50.0, DurationMinutes: 30}
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{1}).Return([]models.Service{haircut}, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(p models.Professional) (models.Professional, error) {
+		p.ID = 1
+		return p, nil
+	})
+
+	result, err := svc.CreateProfessional(models.Professional{Name: "Leila", Services: []models.Service{{ID: 1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, []models.Service{haircut}, result.Services)
+}
+
+func TestProfessionalService_CreateProfessional_MissingName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewProfessionalService(mockRepo, mockServiceRepo)
+
+	_, err := svc.CreateProfessional(models.Professional{})
+	assert.Error(t, err)
+	assert.Equal(t, "professional name is required", err.Error())
+}
+
+func TestProfessionalService_CreateProfessional_UnknownService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewProfessionalService(mockRepo, mockServiceRepo)
+
+	mockServiceRepo.EXPECT().FindByIDs([]uint{42}).Return([]models.Service{}, nil)
+
+	_, err := svc.CreateProfessional(models.Professional{Name: "Leila", Services: []models.Service{{ID: 42}}})
+	assert.ErrorIs(t, err, models.ErrUnknownService)
+}
+
+func TestProfessionalService_UpdateProfessional_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewProfessionalService(mockRepo, mockServiceRepo)
+
+	mockRepo.EXPECT().FindByID(uint(9)).Return(models.Professional{}, models.ErrUnknownProfessional)
+
+	_, err := svc.UpdateProfessional(models.Professional{ID: 9, Name: "Leila"})
+	assert.ErrorIs(t, err, models.ErrUnknownProfessional)
+}
+
+func TestProfessionalService_DeleteProfessional_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfessionalRepository(ctrl)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewProfessionalService(mockRepo, mockServiceRepo)
+
+	mockRepo.EXPECT().FindByID(uint(1)).Return(models.Professional{ID: 1, Name: "Leila"}, nil)
+	mockRepo.EXPECT().Delete(uint(1)).Return(nil)
+
+	assert.NoError(t, svc.DeleteProfessional(1))
+}
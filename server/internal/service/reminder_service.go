@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DefaultReminderOffsets are how long before a confirmed appointment the customer is reminded of it
+var DefaultReminderOffsets = []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+// ReminderService plans reminders as appointments are confirmed, moved or canceled, and sends them when their jobs run
+type ReminderService interface {
+	notification.AppointmentNotifier
+	// SendReminder is the job handler of models.JobAppointmentReminder
+	SendReminder(ctx context.Context, job models.Job) error
+}
+
+type reminderService struct {
+	jobRepo  repository.JobRepository
+	repo     repository.AppointmentRepository
+	renderer *notification.Renderer
+	notifier notification.Notifier
+	offsets  []time.Duration
+	now      func() time.Time
+}
+
+func NewReminderService(jobRepo repository.JobRepository, repo repository.AppointmentRepository, renderer *notification.Renderer, notifier notification.Notifier, offsets []time.Duration) ReminderService {
+	if len(offsets) == 0 {
+		offsets = DefaultReminderOffsets
+	}
+	return &reminderService{
+		jobRepo:  jobRepo,
+		repo:     repo,
+		renderer: renderer,
+		notifier: notifier,
+		offsets:  offsets,
+		now:      time.Now,
+	}
+}
+
+// Notify keeps the planned reminders in line with the appointment: only confirmed appointments get them,
+// and moving the appointment plans them again for the new date
+func (s *reminderService) Notify(event notification.Event, ap models.Appointment) {
+	var err error
+	switch event {
+	case notification.EventConfirmed, notification.EventRescheduled:
+		err = s.plan(ap)
+	case notification.EventCanceled:
+		err = s.jobRepo.CancelPending(models.JobAppointmentReminder, ap.ID)
+	}
+	if err != nil {
+		log.Printf("reminders: planning reminders of appointment %d: %v", ap.ID, err)
+	}
+}
+
+func (s *reminderService) plan(ap models.Appointment) error {
+	if err := s.jobRepo.CancelPending(models.JobAppointmentReminder, ap.ID); err != nil {
+		return err
+	}
+	if ap.Status != models.StatusConfirmed {
+		return nil
+	}
+	now := s.now()
+	for _, offset := range s.offsets {
+		runAt := ap.Date.Add(-offset)
+		if !runAt.After(now) {
+			continue
+		}
+		id := ap.ID
+		job := models.Job{
+			Kind:          models.JobAppointmentReminder,
+			AppointmentID: &id,
+			Payload:       ap.Date.Format(time.RFC3339Nano),
+			RunAt:         runAt,
+		}
+		if _, err := s.jobRepo.Create(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendReminder sends the reminder unless the appointment was meanwhile canceled, finished or moved
+func (s *reminderService) SendReminder(ctx context.Context, job models.Job) error {
+	if job.AppointmentID == nil {
+		return nil
+	}
+	ap, err := s.repo.FindByID(*job.AppointmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ap.Status != models.StatusConfirmed {
+		return nil
+	}
+	plannedFor, err := time.Parse(time.RFC3339Nano, job.Payload)
+	if err != nil || !ap.Date.Equal(plannedFor) {
+		return nil
+	}
+
+	msg, err := s.renderer.Render(notification.EventReminder, ap)
+	if err != nil {
+		return err
+	}
+	return s.notifier.Send(ctx, msg)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestReminderService(t *testing.T, jobRepo *mocks.MockJobRepository, repo *mocks.MockAppointmentRepository, out *bytes.Buffer) ReminderService {
+	renderer, err := notification.NewRenderer(notification.DefaultLocale)
+	require.NoError(t, err)
+	return NewReminderService(jobRepo, repo, renderer, notification.NewLogNotifier(out), nil)
+}
+
+func TestReminderService_PlansConfirmedAppointments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	svc := newTestReminderService(t, mockJobRepo, nil, &bytes.Buffer{})
+
+	ap := models.Appointment{ID: 7, Date: futureDate(3), Status: models.StatusConfirmed}
+	mockJobRepo.EXPECT().CancelPending(models.JobAppointmentReminder, uint(7)).Return(nil)
+	var planned []time.Time
+	mockJobRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(job models.Job) (models.Job, error) {
+		assert.Equal(t, models.JobAppointmentReminder, job.Kind)
+		assert.Equal(t, uint(7), *job.AppointmentID)
+		planned = append(planned, job.RunAt)
+		return job, nil
+	}).Times(2)
+
+	svc.Notify(notification.EventConfirmed, ap)
+	assert.Equal(t, []time.Time{ap.Date.Add(-24 * time.Hour), ap.Date.Add(-2 * time.Hour)}, planned)
+}
+
+func TestReminderService_SkipsPastReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	svc := newTestReminderService(t, mockJobRepo, nil, &bytes.Buffer{})
+
+	// Only the 2 hour reminder is still ahead
+	ap := models.Appointment{ID: 7, Date: time.Now().Add(5 * time.Hour), Status: models.StatusConfirmed}
+	mockJobRepo.EXPECT().CancelPending(models.JobAppointmentReminder, uint(7)).Return(nil)
+	mockJobRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(job models.Job) (models.Job, error) {
+		assert.Equal(t, ap.Date.Add(-2*time.Hour), job.RunAt)
+		return job, nil
+	})
+
+	svc.Notify(notification.EventRescheduled, ap)
+}
+
+func TestReminderService_DropsReminders(t *testing.T) {
+	tests := []struct {
+		name  string
+		event notification.Event
+		ap    models.Appointment
+	}{
+		{"canceled", notification.EventCanceled, models.Appointment{ID: 7, Date: futureDate(3), Status: models.StatusCanceled}},
+		{"moved while pending", notification.EventRescheduled, models.Appointment{ID: 7, Date: futureDate(3), Status: models.StatusPending}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockJobRepo := mocks.NewMockJobRepository(ctrl)
+			svc := newTestReminderService(t, mockJobRepo, nil, &bytes.Buffer{})
+
+			mockJobRepo.EXPECT().CancelPending(models.JobAppointmentReminder, uint(7)).Return(nil)
+
+			svc.Notify(tt.event, tt.ap)
+		})
+	}
+
+	t.Run("booked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		svc := newTestReminderService(t, mockJobRepo, nil, &bytes.Buffer{})
+
+		svc.Notify(notification.EventBooked, models.Appointment{ID: 7, Date: futureDate(3), Status: models.StatusPending})
+	})
+}
+
+func TestReminderService_SendReminder(t *testing.T) {
+	date := futureDate(1)
+	id := uint(7)
+	job := models.Job{ID: 1, Kind: models.JobAppointmentReminder, AppointmentID: &id, Payload: date.Format(time.RFC3339Nano)}
+	customer := models.User{Name: "Maria", Email: "maria@example.com"}
+
+	tests := []struct {
+		name     string
+		ap       models.Appointment
+		findErr  error
+		wantSent bool
+	}{
+		{"confirmed", models.Appointment{ID: 7, User: customer, Date: date, Status: models.StatusConfirmed}, nil, true},
+		{"canceled", models.Appointment{ID: 7, User: customer, Date: date, Status: models.StatusCanceled}, nil, false},
+		{"moved", models.Appointment{ID: 7, User: customer, Date: date.Add(time.Hour), Status: models.StatusConfirmed}, nil, false},
+		{"deleted", models.Appointment{}, gorm.ErrRecordNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+			var out bytes.Buffer
+			svc := newTestReminderService(t, nil, mockRepo, &out)
+
+			mockRepo.EXPECT().FindByID(uint(7)).Return(tt.ap, tt.findErr)
+
+			err := svc.SendReminder(context.Background(), job)
+			assert.NoError(t, err)
+			if tt.wantSent {
+				assert.Contains(t, out.String(), "To: maria@example.com")
+				assert.Contains(t, out.String(), "Lembrete")
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
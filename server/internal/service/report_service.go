@@ -0,0 +1,85 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+// DefaultTopServices is how many services are ranked when no limit is informed
+const DefaultTopServices = 5
+
+type ReportService interface {
+	GetPerformance(start, end time.Time, bucket models.ReportBucket) (models.PerformanceReport, error)
+	GetTopServices(start, end time.Time, limit int) ([]models.ServiceRanking, error)
+	GetBusiestHours(start, end time.Time) ([]models.HourLoad, error)
+}
+
+type reportService struct {
+	repo repository.ReportRepository
+}
+
+func NewReportService(repo repository.ReportRepository) ReportService {
+	return &reportService{repo: repo}
+}
+
+// GetPerformance returns counts per status, rates and revenue for every period between start and end,
+// including the periods without any appointment
+func (s *reportService) GetPerformance(start, end time.Time, bucket models.ReportBucket) (models.PerformanceReport, error) {
+	if !bucket.IsValid() {
+		return models.PerformanceReport{}, models.ErrInvalidReportBucket
+	}
+	if !end.After(start) {
+		return models.PerformanceReport{}, models.ErrInvalidReportRange
+	}
+
+	counts, err := s.repo.CountByStatus(start, end, bucket)
+	if err != nil {
+		return models.PerformanceReport{}, err
+	}
+	revenue, err := s.repo.RevenueByPeriod(start, end, bucket)
+	if err != nil {
+		return models.PerformanceReport{}, err
+	}
+
+	report := models.PerformanceReport{Start: start, End: end, Bucket: bucket, Periods: []models.PeriodPerformance{}}
+	index := make(map[string]int)
+	for period := bucket.Truncate(start); period.Before(end); period = bucket.Next(period) {
+		key := period.Format(models.DateLayout)
+		index[key] = len(report.Periods)
+		report.Periods = append(report.Periods, models.PeriodPerformance{Period: key, ByStatus: map[models.AppointmentStatus]int64{}})
+	}
+
+	report.Totals.ByStatus = map[models.AppointmentStatus]int64{}
+	for _, c := range counts {
+		if i, ok := index[c.Period]; ok {
+			report.Periods[i].AddCount(c.Status, c.Count)
+		}
+		report.Totals.AddCount(c.Status, c.Count)
+	}
+	for _, r := range revenue {
+		if i, ok := index[r.Period]; ok {
+			report.Periods[i].Revenue = r.Revenue
+		}
+		report.Totals.Revenue += r.Revenue
+	}
+	return report, nil
+}
+
+func (s *reportService) GetTopServices(start, end time.Time, limit int) ([]models.ServiceRanking, error) {
+	if !end.After(start) {
+		return nil, models.ErrInvalidReportRange
+	}
+	if limit <= 0 {
+		limit = DefaultTopServices
+	}
+	return s.repo.TopServices(start, end, limit)
+}
+
+func (s *reportService) GetBusiestHours(start, end time.Time) ([]models.HourLoad, error) {
+	if !end.After(start) {
+		return nil, models.ErrInvalidReportRange
+	}
+	return s.repo.BusiestHours(start, end)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_GetPerformance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	svc := NewReportService(mockRepo)
+
+	start := time.Date(2030, 3, 4, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 3)
+
+	mockRepo.EXPECT().CountByStatus(start, end, models.BucketDay).Return([]models.StatusCount{
+		{Period: "2030-03-04", Status: models.StatusDone, Count: 3},
+		{Period: "2030-03-04", Status: models.StatusCanceled, Count: 1},
+		{Period: "2030-03-06", Status: models.StatusPending, Count: 2},
+	}, nil)
+	mockRepo.EXPECT().RevenueByPeriod(start, end, models.BucketDay).Return([]models.PeriodRevenue{
+		{Period: "2030-03-04", Revenue: 150},
+	}, nil)
+
+	report, err := svc.GetPerformance(start, end, models.BucketDay)
+	require.NoError(t, err)
+
+	// Days without appointments are still listed
+	require.Len(t, report.Periods, 3)
+	assert.Equal(t, "2030-03-04", report.Periods[0].Period)
+	assert.Equal(t, int64(4), report.Periods[0].Total)
+	assert.Equal(t, 0.75, report.Periods[0].CompletionRate)
+	assert.Equal(t, 0.25, report.Periods[0].CancellationRate)
+	assert.Equal(t, 150.0, report.Periods[0].Revenue)
+	assert.Equal(t, "2030-03-05", report.Periods[1].Period)
+	assert.Equal(t, int64(0), report.Periods[1].Total)
+	assert.Equal(t, int64(2), report.Periods[2].ByStatus[models.StatusPending])
+
+	assert.Equal(t, int64(6), report.Totals.Total)
+	assert.Equal(t, 0.5, report.Totals.CompletionRate)
+	assert.Equal(t, 150.0, report.Totals.Revenue)
+}
+
+func TestReportService_Buckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		bucket  models.ReportBucket
+		start   time.Time
+		end     time.Time
+		periods []string
+	}{
+		{"weeks start on monday", models.BucketWeek, time.Date(2030, 3, 6, 0, 0, 0, 0, time.Local), time.Date(2030, 3, 20, 0, 0, 0, 0, time.Local),
+			[]string{"2030-03-04", "2030-03-11", "2030-03-18"}},
+		{"months start on the first day", models.BucketMonth, time.Date(2030, 1, 15, 0, 0, 0, 0, time.Local), time.Date(2030, 3, 2, 0, 0, 0, 0, time.Local),
+			[]string{"2030-01-01", "2030-02-01", "2030-03-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mocks.NewMockReportRepository(ctrl)
+			svc := NewReportService(mockRepo)
+
+			mockRepo.EXPECT().CountByStatus(tt.start, tt.end, tt.bucket).Return(nil, nil)
+			mockRepo.EXPECT().RevenueByPeriod(tt.start, tt.end, tt.bucket).Return(nil, nil)
+
+			report, err := svc.GetPerformance(tt.start, tt.end, tt.bucket)
+			require.NoError(t, err)
+			periods := make([]string, 0, len(report.Periods))
+			for _, p := range report.Periods {
+				periods = append(periods, p.Period)
+			}
+			assert.Equal(t, tt.periods, periods)
+		})
+	}
+}
+
+func TestReportService_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := NewReportService(mocks.NewMockReportRepository(ctrl))
+
+	start := time.Date(2030, 3, 4, 0, 0, 0, 0, time.Local)
+
+	_, err := svc.GetPerformance(start, start.AddDate(0, 0, 7), models.ReportBucket("year"))
+	assert.ErrorIs(t, err, models.ErrInvalidReportBucket)
+
+	_, err = svc.GetPerformance(start, start, models.BucketDay)
+	assert.ErrorIs(t, err, models.ErrInvalidReportRange)
+
+	_, err = svc.GetTopServices(start, start.AddDate(0, 0, -1), 5)
+	assert.ErrorIs(t, err, models.ErrInvalidReportRange)
+}
+
+func TestReportService_TopServicesDefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	svc := NewReportService(mockRepo)
+
+	start := time.Date(2030, 3, 4, 0, 0, 0, 0, time.Local)
+	mockRepo.EXPECT().TopServices(start, start.AddDate(0, 0, 7), DefaultTopServices).Return(nil, nil)
+
+	_, err := svc.GetTopServices(start, start.AddDate(0, 0, 7), 0)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+type ScheduleService interface {
+	GetBusinessHours() ([]models.BusinessHours, error)
+	UpdateBusinessHours(hours []models.BusinessHours) ([]models.BusinessHours, error)
+	ListHolidays() ([]models.Holiday, error)
+	CreateHoliday(holiday models.Holiday) (models.Holiday, error)
+	DeleteHoliday(id uint) error
+}
+
+type scheduleService struct {
+	repo repository.ScheduleRepository
+}
+
+func NewScheduleService(repo repository.ScheduleRepository) ScheduleService {
+	return &scheduleService{repo: repo}
+}
+
+func (s *scheduleService) GetBusinessHours() ([]models.BusinessHours, error) {
+	return s.repo.ListBusinessHours()
+}
+
+func (s *scheduleService) UpdateBusinessHours(hours []models.BusinessHours) ([]models.BusinessHours, error) {
+	seen := make(map[time.Weekday]bool, len(hours))
+	for _, h := range hours {
+		if err := h.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[h.Weekday] {
+			return nil, models.ErrDuplicateWeekday
+		}
+		seen[h.Weekday] = true
+	}
+	if err := s.repo.SaveBusinessHours(hours); err != nil {
+		return nil, err
+	}
+	return s.repo.ListBusinessHours()
+}
+
+func (s *scheduleService) ListHolidays() ([]models.Holiday, error) {
+	return s.repo.ListHolidays()
+}
+
+func (s *scheduleService) CreateHoliday(holiday models.Holiday) (models.Holiday, error) {
+	if _, err := time.Parse(models.DateLayout, holiday.Date); err != nil {
+		return models.Holiday{}, models.ErrInvalidHolidayDate
+	}
+	return s.repo.CreateHoliday(holiday)
+}
+
+func (s *scheduleService) DeleteHoliday(id uint) error {
+	if id == 0 {
+		return errors.New("invalid holiday ID")
+	}
+	return s.repo.DeleteHoliday(id)
+}
+
+// workingWindow returns when the salon opens and closes on the given day, or one of
+// models.ErrSalonClosed and models.ErrHolidayClosure when it does not open at all.
+func workingWindow(scheduleRepo repository.ScheduleRepository, day time.Time) (time.Time, time.Time, error) {
+	day = day.In(time.Local)
+	holiday, err := scheduleRepo.IsHoliday(day)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if holiday {
+		return time.Time{}, time.Time{}, models.ErrHolidayClosure
+	}
+	hours, err := scheduleRepo.FindBusinessHours(day.Weekday())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !hours.IsOpen {
+		return time.Time{}, time.Time{}, models.ErrSalonClosed
+	}
+	return hours.Window(day)
+}
+
+// checkBusinessHours ensures the whole appointment happens while the salon is open
+func checkBusinessHours(scheduleRepo repository.ScheduleRepository, start time.Time, duration time.Duration) error {
+	opening, closing, err := workingWindow(scheduleRepo, start)
+	if err != nil {
+		return err
+	}
+	if start.Before(opening) || start.Add(duration).After(closing) {
+		return models.ErrOutsideBusinessHours
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleService_UpdateBusinessHours_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewScheduleService(mockRepo)
+
+	hours := []models.BusinessHours{
+		{Weekday: time.Saturday, IsOpen: true, OpensAt: "08:00", ClosesAt: "12:00"},
+		{Weekday: time.Monday, IsOpen: false},
+	}
+
+	mockRepo.EXPECT().SaveBusinessHours(hours).Return(nil)
+	mockRepo.EXPECT().ListBusinessHours().Return(hours, nil)
+
+	result, err := svc.UpdateBusinessHours(hours)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestScheduleService_UpdateBusinessHours_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours []models.BusinessHours
+		want  error
+	}{
+		{"closes before opening", []models.BusinessHours{{Weekday: time.Monday, IsOpen: true, OpensAt: "18:00", ClosesAt: "09:00"}}, models.ErrInvalidBusinessHours},
+		{"malformed time", []models.BusinessHours{{Weekday: time.Monday, IsOpen: true, OpensAt: "9h", ClosesAt: "18:00"}}, models.ErrInvalidBusinessHours},
+		{"invalid weekday", []models.BusinessHours{{Weekday: 9, IsOpen: false}}, models.ErrInvalidBusinessHours},
+		{"duplicated weekday", []models.BusinessHours{{Weekday: time.Monday}, {Weekday: time.Monday}}, models.ErrDuplicateWeekday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockScheduleRepository(ctrl)
+			svc := NewScheduleService(mockRepo)
+
+			_, err := svc.UpdateBusinessHours(tt.hours)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestScheduleService_CreateHoliday_InvalidDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewScheduleService(mockRepo)
+
+	_, err := svc.CreateHoliday(models.Holiday{Date: "25/12/2026"})
+	assert.ErrorIs(t, err, models.ErrInvalidHolidayDate)
+}
+
+func TestScheduleService_CreateHoliday_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockScheduleRepository(ctrl)
+	svc := NewScheduleService(mockRepo)
+
+	holiday := models.Holiday{Date: "2026-12-25", Description: "Natal"}
+	mockRepo.EXPECT().CreateHoliday(holiday).Return(models.Holiday{ID: 1, Date: "2026-12-25", Description: "Natal"}, nil)
+
+	created, err := svc.CreateHoliday(holiday)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), created.ID)
+}
@@ -10,9 +10,11 @@ import (
 type ServiceService interface {
     CreateService(service models.Service) (models.Service, error)
     GetService(id uint) (models.Service, error)
-    ListServices() ([]models.Service, error)
+    ListServices(page models.PageRequest) (models.ServicePage, error)
+    ListServicesIncludingInactive(page models.PageRequest) (models.ServicePage, error)
     UpdateService(service models.Service) (models.Service, error)
     DeleteService(id uint) error
+    RestoreService(id uint) (models.Service, error)
 }
 
 type serviceService struct {
@@ -43,8 +45,13 @@ func (s *serviceService) GetService(id uint) (models.Service, error) {
     return s.repo.FindByID(id)
 }
 
-func (s *serviceService) ListServices() ([]models.Service, error) {
-    return s.repo.FindAll()
+func (s *serviceService) ListServices(page models.PageRequest) (models.ServicePage, error) {
+    return s.repo.FindAll(page)
+}
+
+// ListServicesIncludingInactive also lists the services that are no longer offered
+func (s *serviceService) ListServicesIncludingInactive(page models.PageRequest) (models.ServicePage, error) {
+    return s.repo.FindAllIncludingInactive(page)
 }
 
 func (s *serviceService) UpdateService(service models.Service) (models.Service, error) {
@@ -76,4 +83,12 @@ func (s *serviceService) DeleteService(id uint) error {
         return err
     }
     return s.repo.Delete(id)
+}
+
+// RestoreService offers a deleted service again
+func (s *serviceService) RestoreService(id uint) (models.Service, error) {
+    if id == 0 {
+        return models.Service{}, errors.New("invalid service ID")
+    }
+    return s.repo.Restore(id)
 }
\ No newline at end of file
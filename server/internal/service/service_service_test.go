@@ -133,11 +133,12 @@ func TestServiceService_ListServices_Success(t *testing.T) {
 		{ID: 2, Name: "Escova", Price: 40.0, DurationMinutes: 45},
 	}
 
-	mockRepo.EXPECT().FindAll().Return(services, nil)
+	page := models.PageRequest{Limit: 10}
+	mockRepo.EXPECT().FindAll(page).Return(models.ServicePage{Items: services}, nil)
 
-	result, err := svc.ListServices()
+	result, err := svc.ListServices(page)
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
+	assert.Len(t, result.Items, 2)
 }
 
 func TestServiceService_UpdateService_Success(t *testing.T) {
@@ -220,3 +221,34 @@ func TestServiceService_DeleteService_NotFound(t *testing.T) {
 	err := svc.DeleteService(999)
 	assert.Error(t, err)
 }
+
+func TestServiceService_RestoreService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewServiceService(mockRepo)
+
+	mockRepo.EXPECT().Restore(uint(1)).Return(models.Service{ID: 1, Name: "Haircut"}, nil)
+
+	restored, err := svc.RestoreService(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Haircut", restored.Name)
+
+	_, err = svc.RestoreService(0)
+	assert.Error(t, err)
+}
+
+func TestServiceService_ListServicesIncludingInactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockServiceRepository(ctrl)
+	svc := NewServiceService(mockRepo)
+
+	mockRepo.EXPECT().FindAllIncludingInactive(models.PageRequest{}).Return(models.ServicePage{Items: []models.Service{{ID: 1}, {ID: 2}}}, nil)
+
+	services, err := svc.ListServicesIncludingInactive(models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, services.Items, 2)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenTTL is how long a session lasts without being used
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// SessionService issues access and refresh tokens and revokes them
+type SessionService interface {
+	StartSession(user models.User) (models.TokenPair, error)
+	// Refresh rotates the refresh token. Presenting a token that was already rotated revokes its whole family.
+	Refresh(refreshToken string) (models.TokenPair, error)
+	Logout(refreshToken string) error
+	// LogoutAll revokes every session of the user, for example after a password change
+	LogoutAll(userID uint) error
+}
+
+type sessionService struct {
+	authSvc  AuthService
+	repo     repository.RefreshTokenRepository
+	userRepo repository.UserRepository
+	now      func() time.Time
+}
+
+func NewSessionService(authSvc AuthService, repo repository.RefreshTokenRepository, userRepo repository.UserRepository) SessionService {
+	return &sessionService{authSvc: authSvc, repo: repo, userRepo: userRepo, now: time.Now}
+}
+
+func (s *sessionService) StartSession(user models.User) (models.TokenPair, error) {
+	family, err := randomToken(16)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	return s.issue(user, family)
+}
+
+func (s *sessionService) Refresh(refreshToken string) (models.TokenPair, error) {
+	token, err := s.find(refreshToken)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	if token.UsedAt != nil {
+		return models.TokenPair{}, s.reused(token)
+	}
+	if token.RevokedAt != nil || !s.now().Before(token.ExpiresAt) {
+		return models.TokenPair{}, models.ErrInvalidRefreshToken
+	}
+
+	// Deleted or deactivated users cannot extend their sessions
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil || !user.IsActive {
+		if err := s.repo.RevokeFamily(token.FamilyID); err != nil {
+			return models.TokenPair{}, err
+		}
+		return models.TokenPair{}, models.ErrInvalidRefreshToken
+	}
+
+	rotated, err := s.repo.MarkUsed(token.ID, s.now())
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	if !rotated {
+		// Another request rotated or revoked the token in the meantime
+		return models.TokenPair{}, s.reused(token)
+	}
+	return s.issue(user, token.FamilyID)
+}
+
+func (s *sessionService) Logout(refreshToken string) error {
+	token, err := s.find(refreshToken)
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeFamily(token.FamilyID)
+}
+
+func (s *sessionService) LogoutAll(userID uint) error {
+	return s.repo.RevokeUser(userID)
+}
+
+func (s *sessionService) find(refreshToken string) (models.RefreshToken, error) {
+	if refreshToken == "" {
+		return models.RefreshToken{}, models.ErrInvalidRefreshToken
+	}
+	token, err := s.repo.FindByHash(hashToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.RefreshToken{}, models.ErrInvalidRefreshToken
+	}
+	return token, err
+}
+
+// reused revokes the family of a token presented after it was rotated, since either the
+// client or an attacker holds a copy of it
+func (s *sessionService) reused(token models.RefreshToken) error {
+	if err := s.repo.RevokeFamily(token.FamilyID); err != nil {
+		return err
+	}
+	return models.ErrRefreshTokenReused
+}
+
+func (s *sessionService) issue(user models.User, family string) (models.TokenPair, error) {
+	access, err := s.authSvc.GenerateToken(user)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	refresh, err := randomToken(32)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	_, err = s.repo.Create(models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  family,
+		TokenHash: hashToken(refresh),
+		ExpiresAt: s.now().Add(RefreshTokenTTL),
+	})
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	return models.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// hashToken is how refresh tokens are stored, so a leaked table cannot be used to log in
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSessionService_StartSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	authSvc := NewAuthService(testKeySet(t))
+	svc := NewSessionService(authSvc, mockTokenRepo, nil)
+
+	var stored models.RefreshToken
+	mockTokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.RefreshToken) (models.RefreshToken, error) {
+		stored = token
+		return token, nil
+	})
+
+	pair, err := svc.StartSession(models.User{ID: 1, Email: "maria@example.com", Role: models.RoleCustomer})
+	require.NoError(t, err)
+	claims, err := authSvc.ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+	assert.NotEmpty(t, claims.ID, "access tokens carry an id")
+	assert.WithinDuration(t, time.Now().Add(AccessTokenTTL), claims.ExpiresAt.Time, time.Minute)
+
+	assert.Equal(t, uint(1), stored.UserID)
+	assert.NotEmpty(t, stored.FamilyID)
+	assert.Equal(t, hashToken(pair.RefreshToken), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, pair.RefreshToken, "only the hash is stored")
+}
+
+func TestSessionService_Refresh_Rotates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, mockUserRepo)
+
+	current := models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", TokenHash: hashToken("refresh"), ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.EXPECT().FindByHash(hashToken("refresh")).Return(current, nil)
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Role: models.RoleCustomer, IsActive: true}, nil)
+	mockTokenRepo.EXPECT().MarkUsed(uint(3), gomock.Any()).Return(true, nil)
+	mockTokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token models.RefreshToken) (models.RefreshToken, error) {
+		assert.Equal(t, "family", token.FamilyID, "the new token belongs to the same login")
+		return token, nil
+	})
+
+	pair, err := svc.Refresh("refresh")
+	require.NoError(t, err)
+	assert.NotEqual(t, "refresh", pair.RefreshToken)
+	assert.NotEmpty(t, pair.AccessToken)
+}
+
+func TestSessionService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, nil)
+
+	used := time.Now().Add(-time.Minute)
+	mockTokenRepo.EXPECT().FindByHash(hashToken("refresh")).Return(models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used}, nil)
+	mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+	_, err := svc.Refresh("refresh")
+	assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+}
+
+func TestSessionService_Refresh_ConcurrentRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, mockUserRepo)
+
+	mockTokenRepo.EXPECT().FindByHash(gomock.Any()).Return(models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, IsActive: true}, nil)
+	mockTokenRepo.EXPECT().MarkUsed(uint(3), gomock.Any()).Return(false, nil)
+	mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+	_, err := svc.Refresh("refresh")
+	assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+}
+
+func TestSessionService_Refresh_Invalid(t *testing.T) {
+	revoked := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name  string
+		token models.RefreshToken
+		err   error
+	}{
+		{"unknown", models.RefreshToken{}, gorm.ErrRecordNotFound},
+		{"expired", models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Minute)}, nil},
+		{"revoked", models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revoked}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+			svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, nil)
+
+			mockTokenRepo.EXPECT().FindByHash(hashToken("refresh")).Return(tt.token, tt.err)
+
+			_, err := svc.Refresh("refresh")
+			assert.ErrorIs(t, err, models.ErrInvalidRefreshToken)
+		})
+	}
+}
+
+func TestSessionService_Refresh_InactiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, mockUserRepo)
+
+	mockTokenRepo.EXPECT().FindByHash(gomock.Any()).Return(models.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, IsActive: false}, nil)
+	mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+
+	_, err := svc.Refresh("refresh")
+	assert.ErrorIs(t, err, models.ErrInvalidRefreshToken)
+}
+
+func TestSessionService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	svc := NewSessionService(NewAuthService(testKeySet(t)), mockTokenRepo, nil)
+
+	mockTokenRepo.EXPECT().FindByHash(hashToken("refresh")).Return(models.RefreshToken{ID: 3, FamilyID: "family"}, nil)
+	mockTokenRepo.EXPECT().RevokeFamily("family").Return(nil)
+	mockTokenRepo.EXPECT().RevokeUser(uint(1)).Return(nil)
+
+	assert.NoError(t, svc.Logout("refresh"))
+	assert.NoError(t, svc.LogoutAll(1))
+	assert.ErrorIs(t, svc.Logout(""), models.ErrInvalidRefreshToken)
+}
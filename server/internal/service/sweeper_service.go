@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+)
+
+// SweeperService closes the appointments left open after their date, following the configured rules
+type SweeperService interface {
+	GetRules() (models.SweeperRules, error)
+	UpdateRules(rules models.SweeperRules) (models.SweeperRules, error)
+	Sweep(ctx context.Context) (models.SweepResult, error)
+}
+
+type sweeperService struct {
+	repo      repository.AppointmentRepository
+	rulesRepo repository.SweeperRulesRepository
+	now       func() time.Time
+}
+
+func NewSweeperService(repo repository.AppointmentRepository, rulesRepo repository.SweeperRulesRepository) SweeperService {
+	return &sweeperService{repo: repo, rulesRepo: rulesRepo, now: time.Now}
+}
+
+func (s *sweeperService) GetRules() (models.SweeperRules, error) {
+	return s.rulesRepo.Get()
+}
+
+func (s *sweeperService) UpdateRules(rules models.SweeperRules) (models.SweeperRules, error) {
+	if err := rules.Validate(); err != nil {
+		return models.SweeperRules{}, err
+	}
+	return s.rulesRepo.Save(rules)
+}
+
+// Sweep moves every overdue appointment to its automatic status. The changes are recorded in the
+// status history without an author, which marks them as made by the system.
+func (s *sweeperService) Sweep(ctx context.Context) (models.SweepResult, error) {
+	var result models.SweepResult
+	rules, err := s.rulesRepo.Get()
+	if err != nil {
+		return result, err
+	}
+	statuses := rules.Statuses()
+	if len(statuses) == 0 {
+		return result, nil
+	}
+
+	now := s.now()
+	open, err := s.repo.ListStartedBefore(statuses, now)
+	if err != nil {
+		return result, err
+	}
+	for _, ap := range open {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		next, due := rules.NextStatus(ap, now)
+		if !due {
+			continue
+		}
+		if err := s.repo.ChangeStatus(ap.ID, ap.Status, next, nil, nil); err != nil {
+			// Someone changed the appointment since it was listed, their change wins
+			if errors.Is(err, models.ErrInvalidStatusTransition) {
+				result.Skipped++
+				continue
+			}
+			return result, err
+		}
+		if next == models.StatusExpired {
+			result.Expired++
+		} else {
+			result.Completed++
+		}
+	}
+	if result.Expired > 0 || result.Completed > 0 {
+		log.Printf("sweeper: %d appointments expired, %d completed", result.Expired, result.Completed)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSweeper(repo *mocks.MockAppointmentRepository, rulesRepo *mocks.MockSweeperRulesRepository, now time.Time) *sweeperService {
+	s := NewSweeperService(repo, rulesRepo).(*sweeperService)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSweeperService_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockRulesRepo := mocks.NewMockSweeperRulesRepository(ctrl)
+	now := time.Date(2026, 5, 4, 18, 0, 0, 0, time.Local)
+	svc := newTestSweeper(mockRepo, mockRulesRepo, now)
+
+	rules := models.SweeperRules{ExpirePending: true, ExpireAfterMinutes: 60, AutoComplete: true, CompleteAfterMinutes: 120}
+	items := []models.AppointmentItem{{DurationMinutes: 30}}
+	open := []models.Appointment{
+		// ended at 10:30, well past both grace periods
+		{ID: 1, Status: models.StatusPending, Date: now.Add(-8 * time.Hour), Items: items},
+		{ID: 2, Status: models.StatusConfirmed, Date: now.Add(-8 * time.Hour), Items: items},
+		// still within the grace periods
+		{ID: 3, Status: models.StatusPending, Date: now.Add(-80 * time.Minute), Items: items},
+		{ID: 4, Status: models.StatusConfirmed, Date: now.Add(-2 * time.Hour), Items: items},
+		// changed by an admin while the sweep ran
+		{ID: 5, Status: models.StatusPending, Date: now.Add(-8 * time.Hour), Items: items},
+	}
+	mockRulesRepo.EXPECT().Get().Return(rules, nil)
+	mockRepo.EXPECT().ListStartedBefore([]models.AppointmentStatus{models.StatusPending, models.StatusConfirmed}, now).Return(open, nil)
+	mockRepo.EXPECT().ChangeStatus(uint(1), models.StatusPending, models.StatusExpired, nil, nil).Return(nil)
+	mockRepo.EXPECT().ChangeStatus(uint(2), models.StatusConfirmed, models.StatusDone, nil, nil).Return(nil)
+	mockRepo.EXPECT().ChangeStatus(uint(5), models.StatusPending, models.StatusExpired, nil, nil).Return(models.ErrInvalidStatusTransition)
+
+	result, err := svc.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.SweepResult{Expired: 1, Completed: 1, Skipped: 1}, result)
+}
+
+func TestSweeperService_Sweep_CompletionDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockRulesRepo := mocks.NewMockSweeperRulesRepository(ctrl)
+	now := time.Now()
+	svc := newTestSweeper(mockRepo, mockRulesRepo, now)
+
+	mockRulesRepo.EXPECT().Get().Return(models.DefaultSweeperRules(), nil)
+	mockRepo.EXPECT().ListStartedBefore([]models.AppointmentStatus{models.StatusPending}, now).Return(nil, nil)
+
+	result, err := svc.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.SweepResult{}, result)
+}
+
+func TestSweeperService_Sweep_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockRulesRepo := mocks.NewMockSweeperRulesRepository(ctrl)
+	svc := newTestSweeper(mockRepo, mockRulesRepo, time.Now())
+
+	mockRulesRepo.EXPECT().Get().Return(models.SweeperRules{}, nil)
+
+	result, err := svc.Sweep(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.SweepResult{}, result)
+}
+
+func TestSweeperService_UpdateRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRulesRepo := mocks.NewMockSweeperRulesRepository(ctrl)
+	svc := NewSweeperService(nil, mockRulesRepo)
+
+	_, err := svc.UpdateRules(models.SweeperRules{ExpirePending: true, ExpireAfterMinutes: -1})
+	assert.ErrorIs(t, err, models.ErrInvalidSweeperRules)
+
+	rules := models.SweeperRules{AutoComplete: true, CompleteAfterMinutes: 30}
+	mockRulesRepo.EXPECT().Save(rules).Return(rules, nil)
+	saved, err := svc.UpdateRules(rules)
+	require.NoError(t, err)
+	assert.Equal(t, rules, saved)
+}
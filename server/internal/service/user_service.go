@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserService lets users manage their own account
+type UserService interface {
+	GetProfile(userID uint) (models.User, error)
+	UpdateProfile(userID uint, update models.ProfileUpdate) (models.User, error)
+	// ChangePassword ends every session of the user and starts a new one for the caller
+	ChangePassword(userID uint, current, password string) (models.TokenPair, error)
+	// CloseAccount cancels the upcoming appointments, anonymizes the user and archives it.
+	// Past appointments stay in the history.
+	CloseAccount(userID uint, password string) error
+}
+
+type userService struct {
+	repo       repository.UserRepository
+	apSvc      AppointmentService
+	sessionSvc SessionService
+}
+
+func NewUserService(repo repository.UserRepository, apSvc AppointmentService, sessionSvc SessionService) UserService {
+	return &userService{repo: repo, apSvc: apSvc, sessionSvc: sessionSvc}
+}
+
+func (s *userService) GetProfile(userID uint) (models.User, error) {
+	return s.repo.FindByID(userID)
+}
+
+func (s *userService) UpdateProfile(userID uint, update models.ProfileUpdate) (models.User, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return models.User{}, err
+	}
+	user.Name = update.Name
+	user.Phone = update.Phone
+	if err := s.repo.Update(user); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (s *userService) ChangePassword(userID uint, current, password string) (models.TokenPair, error) {
+	user, err := s.checkPassword(userID, current)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	user.Password = string(hashed)
+	user.MustChangePassword = false
+	if err := s.repo.Update(user); err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if err := s.sessionSvc.LogoutAll(user.ID); err != nil {
+		return models.TokenPair{}, err
+	}
+	return s.sessionSvc.StartSession(user)
+}
+
+func (s *userService) CloseAccount(userID uint, password string) error {
+	user, err := s.checkPassword(userID, password)
+	if err != nil {
+		return err
+	}
+
+	// Free the slots the customer will no longer attend, through the appointment service so that the
+	// reminders are dropped and the waitlist is offered the slots
+	if err := s.apSvc.CancelUpcoming(user.ID); err != nil {
+		return err
+	}
+
+	user.Anonymize()
+	if err := s.repo.Update(user); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(user.ID); err != nil {
+		return err
+	}
+	return s.sessionSvc.LogoutAll(user.ID)
+}
+
+func (s *userService) checkPassword(userID uint, password string) (models.User, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return models.User{}, models.ErrWrongPassword
+	}
+	return user, nil
+}
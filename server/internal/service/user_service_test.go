@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashedPassword(t *testing.T, password string) string {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hashed)
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewUserService(mockUserRepo, nil, nil)
+
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Email: "maria@example.com", Name: "Maria", Role: models.RoleCustomer}, nil)
+	mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+		assert.Equal(t, "maria@example.com", user.Email, "the email cannot be changed here")
+		assert.Equal(t, models.RoleCustomer, user.Role)
+		return nil
+	})
+
+	user, err := svc.UpdateProfile(1, models.ProfileUpdate{Name: "Maria Silva", Phone: "11999998888"})
+	require.NoError(t, err)
+	assert.Equal(t, "Maria Silva", user.Name)
+	assert.Equal(t, "11999998888", user.Phone)
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockSessionSvc := mocks.NewMockSessionService(ctrl)
+	svc := NewUserService(mockUserRepo, nil, mockSessionSvc)
+
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Password: hashedPassword(t, "oldpassword")}, nil)
+	mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("newpassword")))
+		return nil
+	})
+	gomock.InOrder(
+		mockSessionSvc.EXPECT().LogoutAll(uint(1)).Return(nil),
+		mockSessionSvc.EXPECT().StartSession(gomock.Any()).Return(models.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil),
+	)
+
+	tokens, err := svc.ChangePassword(1, "oldpassword", "newpassword")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", tokens.RefreshToken)
+}
+
+func TestUserService_ChangePassword_WrongCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewUserService(mockUserRepo, nil, nil)
+
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Password: hashedPassword(t, "oldpassword")}, nil)
+
+	_, err := svc.ChangePassword(1, "guess", "newpassword")
+	assert.ErrorIs(t, err, models.ErrWrongPassword)
+}
+
+func TestUserService_CloseAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockApSvc := mocks.NewMockAppointmentService(ctrl)
+	mockSessionSvc := mocks.NewMockSessionService(ctrl)
+	svc := NewUserService(mockUserRepo, mockApSvc, mockSessionSvc)
+
+	verified := time.Now()
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{
+		ID: 1, Email: "maria@example.com", Name: "Maria", Phone: "11999998888",
+		Password: hashedPassword(t, "password"), IsActive: true, EmailVerifiedAt: &verified,
+	}, nil)
+	gomock.InOrder(
+		mockApSvc.EXPECT().CancelUpcoming(uint(1)).Return(nil),
+		mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) error {
+			assert.Equal(t, "deleted-1@anonymized.invalid", user.Email)
+			assert.Equal(t, "Cliente removido", user.Name)
+			assert.Empty(t, user.Phone)
+			assert.Empty(t, user.Password)
+			assert.False(t, user.IsActive)
+			assert.False(t, user.EmailVerified())
+			return nil
+		}),
+		mockUserRepo.EXPECT().Delete(uint(1)).Return(nil),
+	)
+	mockSessionSvc.EXPECT().LogoutAll(uint(1)).Return(nil)
+
+	assert.NoError(t, svc.CloseAccount(1, "password"))
+}
+
+func TestUserService_CloseAccount_WrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	svc := NewUserService(mockUserRepo, nil, nil)
+
+	mockUserRepo.EXPECT().FindByID(uint(1)).Return(models.User{ID: 1, Password: hashedPassword(t, "password")}, nil)
+
+	assert.ErrorIs(t, svc.CloseAccount(1, "guess"), models.ErrWrongPassword)
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DefaultOfferHold is how long a waitlisted customer has to accept a freed slot before it goes to the next one
+const DefaultOfferHold = 2 * time.Hour
+
+// WaitlistService keeps customers waiting for a slot and offers them the slots freed by cancellations.
+// It is notified of the appointment events, like the reminders.
+type WaitlistService interface {
+	notification.AppointmentNotifier
+	Join(userID uint, entry models.WaitlistEntry) (models.WaitlistEntry, error)
+	// Leave removes the customer from the waitlist, passing any slot held for them to the next customer
+	Leave(userID, id uint) error
+	ListEntries(userID uint) ([]models.WaitlistEntry, error)
+	ListOffers(userID uint) ([]models.WaitlistOffer, error)
+	// AcceptOffer books the held slot for the customer
+	AcceptOffer(userID, id uint) (models.Appointment, error)
+	DeclineOffer(userID, id uint) error
+	// ExpireOffers closes the offers that were not accepted in time and passes their slots to the next customers
+	ExpireOffers(ctx context.Context) error
+	// SendOffer is the job handler of models.JobWaitlistOffer
+	SendOffer(ctx context.Context, job models.Job) error
+}
+
+type waitlistService struct {
+	repo             repository.WaitlistRepository
+	apRepo           repository.AppointmentRepository
+	serviceRepo      repository.ServiceRepository
+	professionalRepo repository.ProfessionalRepository
+	scheduleRepo     repository.ScheduleRepository
+	jobRepo          repository.JobRepository
+	renderer         *notification.Renderer
+	transport        notification.Notifier
+	// notifier tells customers about the appointments booked from offers, it may be nil
+	notifier notification.AppointmentNotifier
+	hold     time.Duration
+	now      func() time.Time
+}
+
+func NewWaitlistService(repo repository.WaitlistRepository, apRepo repository.AppointmentRepository, serviceRepo repository.ServiceRepository, professionalRepo repository.ProfessionalRepository, scheduleRepo repository.ScheduleRepository, jobRepo repository.JobRepository, renderer *notification.Renderer, transport notification.Notifier, notifier notification.AppointmentNotifier, hold time.Duration) WaitlistService {
+	if hold <= 0 {
+		hold = DefaultOfferHold
+	}
+	return &waitlistService{
+		repo:             repo,
+		apRepo:           apRepo,
+		serviceRepo:      serviceRepo,
+		professionalRepo: professionalRepo,
+		scheduleRepo:     scheduleRepo,
+		jobRepo:          jobRepo,
+		renderer:         renderer,
+		transport:        transport,
+		notifier:         notifier,
+		hold:             hold,
+		now:              time.Now,
+	}
+}
+
+func (s *waitlistService) Join(userID uint, entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+	services, err := resolveServices(s.serviceRepo, serviceIDs(entry.Services))
+	if err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	entry.ID = 0
+	entry.UserID = userID
+	entry.Services = services
+	entry.Status = models.WaitlistWaiting
+	if err := entry.Validate(); err != nil {
+		return models.WaitlistEntry{}, err
+	}
+	if entry.EndDate < s.now().Format(models.DateLayout) {
+		return models.WaitlistEntry{}, models.ErrInvalidWaitlistEntry
+	}
+	return s.repo.Create(entry)
+}
+
+func (s *waitlistService) Leave(userID, id uint) error {
+	entry, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if entry.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	if entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistOffered {
+		return models.ErrWaitlistEntryClosed
+	}
+	left, err := s.repo.ChangeStatus(id, entry.Status, models.WaitlistLeft)
+	if err != nil {
+		return err
+	}
+	if !left {
+		return models.ErrWaitlistEntryClosed
+	}
+	if entry.Status != models.WaitlistOffered {
+		return nil
+	}
+
+	offers, err := s.repo.ListPendingOffers(userID, s.now())
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		if offer.EntryID != id {
+			continue
+		}
+		if err := s.closeOffer(offer, models.OfferDeclined); err != nil && !errors.Is(err, models.ErrWaitlistOfferClosed) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *waitlistService) ListEntries(userID uint) ([]models.WaitlistEntry, error) {
+	return s.repo.ListByUser(userID)
+}
+
+func (s *waitlistService) ListOffers(userID uint) ([]models.WaitlistOffer, error) {
+	return s.repo.ListPendingOffers(userID, s.now())
+}
+
+func (s *waitlistService) AcceptOffer(userID, id uint) (models.Appointment, error) {
+	offer, err := s.findOffer(userID, id)
+	if err != nil {
+		return models.Appointment{}, err
+	}
+	// Claiming the offer first releases its hold, so the slot can be booked, and keeps it from expiring meanwhile
+	claimed, err := s.repo.CloseOffer(offer.ID, models.OfferAccepted)
+	if err != nil {
+		return models.Appointment{}, err
+	}
+	if !claimed {
+		return models.Appointment{}, models.ErrWaitlistOfferClosed
+	}
+
+	created, err := s.book(offer)
+	if err != nil {
+		// The slot is no longer bookable, the customer keeps waiting for another one
+		if _, resetErr := s.repo.ChangeStatus(offer.EntryID, models.WaitlistOffered, models.WaitlistWaiting); resetErr != nil {
+			log.Printf("waitlist: reopening entry %d: %v", offer.EntryID, resetErr)
+		}
+		return models.Appointment{}, err
+	}
+	if err := s.repo.SetOfferAppointment(offer.ID, created.ID); err != nil {
+		return created, err
+	}
+	if _, err := s.repo.ChangeStatus(offer.EntryID, models.WaitlistOffered, models.WaitlistBooked); err != nil {
+		return created, err
+	}
+	if s.notifier != nil {
+		s.notifier.Notify(notification.EventBooked, created)
+	}
+	return created, nil
+}
+
+func (s *waitlistService) DeclineOffer(userID, id uint) error {
+	offer, err := s.findOffer(userID, id)
+	if err != nil {
+		return err
+	}
+	return s.closeOffer(offer, models.OfferDeclined)
+}
+
+func (s *waitlistService) ExpireOffers(ctx context.Context) error {
+	offers, err := s.repo.ListExpiredOffers(s.now())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, offer := range offers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.closeOffer(offer, models.OfferExpired); err != nil && !errors.Is(err, models.ErrWaitlistOfferClosed) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Notify offers the slot of a canceled appointment to the first waitlisted customer it suits
+func (s *waitlistService) Notify(event notification.Event, ap models.Appointment) {
+	if event != notification.EventCanceled {
+		return
+	}
+	if err := s.offerSlot(ap); err != nil {
+		log.Printf("waitlist: offering the slot of appointment %d: %v", ap.ID, err)
+	}
+}
+
+// SendOffer tells the customer about the offer, unless it was meanwhile accepted, declined or expired
+func (s *waitlistService) SendOffer(ctx context.Context, job models.Job) error {
+	id, err := strconv.ParseUint(job.Payload, 10, 64)
+	if err != nil {
+		return nil
+	}
+	offer, err := s.repo.FindOffer(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if offer.Status != models.OfferPending || !s.now().Before(offer.ExpiresAt) {
+		return nil
+	}
+
+	ap := models.Appointment{
+		User:     offer.Entry.User,
+		Date:     offer.Date,
+		Services: offer.Entry.Services,
+	}
+	if offer.ProfessionalID != nil {
+		professional, err := s.professionalRepo.FindByID(*offer.ProfessionalID)
+		if err == nil {
+			ap.Professional = &professional
+		}
+	}
+	msg, err := s.renderer.RenderOffer(ap, offer.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return s.transport.Send(ctx, msg)
+}
+
+// findOffer returns the offer of the user while it can still be answered
+func (s *waitlistService) findOffer(userID, id uint) (models.WaitlistOffer, error) {
+	offer, err := s.repo.FindOffer(id)
+	if err != nil {
+		return models.WaitlistOffer{}, err
+	}
+	if offer.UserID != userID {
+		return models.WaitlistOffer{}, gorm.ErrRecordNotFound
+	}
+	if offer.Status != models.OfferPending || !s.now().Before(offer.ExpiresAt) {
+		return models.WaitlistOffer{}, models.ErrWaitlistOfferClosed
+	}
+	return offer, nil
+}
+
+// closeOffer ends an offer that was not accepted. The customer keeps waiting, unless they left,
+// and the slot goes to the next customer.
+func (s *waitlistService) closeOffer(offer models.WaitlistOffer, status models.WaitlistOfferStatus) error {
+	closed, err := s.repo.CloseOffer(offer.ID, status)
+	if err != nil {
+		return err
+	}
+	if !closed {
+		return models.ErrWaitlistOfferClosed
+	}
+	if _, err := s.repo.ChangeStatus(offer.EntryID, models.WaitlistOffered, models.WaitlistWaiting); err != nil {
+		return err
+	}
+
+	source, err := s.apRepo.FindByID(offer.SourceAppointmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if source.Status != models.StatusCanceled {
+		return nil
+	}
+	return s.offerSlot(source)
+}
+
+// offerSlot holds the slot freed by the canceled appointment for the first waitlisted customer it suits,
+// skipping the ones it was already offered to
+func (s *waitlistService) offerSlot(source models.Appointment) error {
+	now := s.now()
+	if !source.Date.After(now) {
+		return nil
+	}
+	expiresAt := now.Add(s.hold)
+	if expiresAt.After(source.Date) {
+		expiresAt = source.Date
+	}
+
+	entries, err := s.repo.ListWaiting(source.Date.In(time.Local).Format(models.DateLayout))
+	if err != nil {
+		return err
+	}
+	previous, err := s.repo.ListOffersBySource(source.ID)
+	if err != nil {
+		return err
+	}
+	offered := make(map[uint]bool, len(previous))
+	for _, offer := range previous {
+		offered[offer.EntryID] = true
+	}
+
+	for _, entry := range entries {
+		duration := models.TotalDuration(entry.Services)
+		if offered[entry.ID] || entry.UserID == source.UserID || duration > source.Duration() || !entry.Accepts(source.Date, duration) {
+			continue
+		}
+		professionalID, err := s.checkSlot(entry.Services, source.Date, source.ProfessionalID)
+		if isUnbookable(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		claimed, err := s.repo.ChangeStatus(entry.ID, models.WaitlistWaiting, models.WaitlistOffered)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		offer, err := s.repo.CreateOffer(models.WaitlistOffer{
+			EntryID:             entry.ID,
+			UserID:              entry.UserID,
+			SourceAppointmentID: source.ID,
+			Date:                source.Date,
+			DurationMinutes:     int(duration / time.Minute),
+			ProfessionalID:      professionalID,
+			Status:              models.OfferPending,
+			ExpiresAt:           expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s.jobRepo.Create(models.Job{
+			Kind:          models.JobWaitlistOffer,
+			AppointmentID: &source.ID,
+			Payload:       strconv.FormatUint(uint64(offer.ID), 10),
+			RunAt:         now,
+			Status:        models.JobPending,
+		})
+		return err
+	}
+	return nil
+}
+
+// checkSlot returns the agenda that can receive the services at the date, preferring the requested one
+func (s *waitlistService) checkSlot(services []models.Service, date time.Time, professionalID *uint) (*uint, error) {
+	if err := checkBusinessHours(s.scheduleRepo, date, models.TotalDuration(services)); err != nil {
+		return nil, err
+	}
+	return assignProfessional(s.apRepo, s.repo, s.professionalRepo, services, date, 0, professionalID)
+}
+
+// book creates the appointment of an accepted offer at the current catalog values
+func (s *waitlistService) book(offer models.WaitlistOffer) (models.Appointment, error) {
+	services, err := resolveServices(s.serviceRepo, serviceIDs(offer.Entry.Services))
+	if err != nil {
+		return models.Appointment{}, err
+	}
+	if err := checkBusinessHours(s.scheduleRepo, offer.Date, models.TotalDuration(services)); err != nil {
+		return models.Appointment{}, err
+	}
+	return s.apRepo.CreateIfFree(models.Appointment{
+		UserID:   offer.UserID,
+		Services: services,
+		Date:     offer.Date,
+		Status:   models.StatusPending,
+		Items:    snapshotItems(services, nil),
+	}, claimSlot(s.repo, s.professionalRepo, 0, offer.ProfessionalID))
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/mocks"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type waitlistMocks struct {
+	waitlist      *mocks.MockWaitlistRepository
+	appointments  *mocks.MockAppointmentRepository
+	services      *mocks.MockServiceRepository
+	professionals *mocks.MockProfessionalRepository
+	schedule      *mocks.MockScheduleRepository
+	jobs          *mocks.MockJobRepository
+	notifier      *mocks.MockAppointmentNotifier
+}
+
+// newTestWaitlistService builds the service for a salon open every day with no professionals registered,
+// writing the messages it sends to out
+func newTestWaitlistService(t *testing.T, ctrl *gomock.Controller, out *bytes.Buffer) (WaitlistService, waitlistMocks) {
+	m := waitlistMocks{
+		waitlist:      mocks.NewMockWaitlistRepository(ctrl),
+		appointments:  mocks.NewMockAppointmentRepository(ctrl),
+		services:      mocks.NewMockServiceRepository(ctrl),
+		professionals: mocks.NewMockProfessionalRepository(ctrl),
+		schedule:      mocks.NewMockScheduleRepository(ctrl),
+		jobs:          mocks.NewMockJobRepository(ctrl),
+		notifier:      mocks.NewMockAppointmentNotifier(ctrl),
+	}
+	expectSalonOpen(m.schedule)
+	m.professionals.EXPECT().FindActive().Return(nil, nil).AnyTimes()
+	renderer, err := notification.NewRenderer(notification.DefaultLocale)
+	require.NoError(t, err)
+	svc := NewWaitlistService(m.waitlist, m.appointments, m.services, m.professionals, m.schedule, m.jobs, renderer, notification.NewLogNotifier(out), m.notifier, 0)
+	return svc, m
+}
+
+// expectFreeAgenda makes the slot checks find no appointment nor hold in the way
+func expectFreeAgenda(m waitlistMocks) {
+	m.appointments.EXPECT().ListActiveByPeriod(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	m.waitlist.EXPECT().ListHolds(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+}
+
+func waitlistEntry(id, userID uint, minutes int, from, to string) models.WaitlistEntry {
+	day := futureDate(3).Format(models.DateLayout)
+	return models.WaitlistEntry{
+		ID:          id,
+		UserID:      userID,
+		Services:    []models.Service{{ID: id, Name: "Serviço", Price: 50, DurationMinutes: minutes}},
+		StartDate:   day,
+		EndDate:     day,
+		WindowStart: from,
+		WindowEnd:   to,
+		Status:      models.WaitlistWaiting,
+	}
+}
+
+func TestWaitlistService_OffersCanceledSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+	expectFreeAgenda(m)
+
+	// One hour freed at 10:00
+	canceled := models.Appointment{
+		ID:     5,
+		UserID: 1,
+		Date:   futureDate(3),
+		Status: models.StatusCanceled,
+		Items:  []models.AppointmentItem{{DurationMinutes: 60}},
+	}
+	m.waitlist.EXPECT().ListWaiting(canceled.Date.Format(models.DateLayout)).Return([]models.WaitlistEntry{
+		waitlistEntry(10, 1, 30, "09:00", "12:00"), // the customer who canceled
+		waitlistEntry(11, 2, 30, "14:00", "18:00"), // prefers the afternoon
+		waitlistEntry(12, 3, 90, "09:00", "12:00"), // needs more time than was freed
+		waitlistEntry(13, 4, 45, "09:00", "12:00"),
+		waitlistEntry(14, 5, 30, "09:00", "12:00"),
+	}, nil)
+	m.waitlist.EXPECT().ListOffersBySource(uint(5)).Return(nil, nil)
+	m.waitlist.EXPECT().ChangeStatus(uint(13), models.WaitlistWaiting, models.WaitlistOffered).Return(true, nil)
+	m.waitlist.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer models.WaitlistOffer) (models.WaitlistOffer, error) {
+		assert.Equal(t, uint(13), offer.EntryID)
+		assert.Equal(t, uint(4), offer.UserID)
+		assert.Equal(t, uint(5), offer.SourceAppointmentID)
+		assert.Equal(t, canceled.Date, offer.Date)
+		assert.Equal(t, 45, offer.DurationMinutes)
+		assert.Equal(t, models.OfferPending, offer.Status)
+		assert.WithinDuration(t, time.Now().Add(DefaultOfferHold), offer.ExpiresAt, 5*time.Second)
+		offer.ID = 20
+		return offer, nil
+	})
+	m.jobs.EXPECT().Create(gomock.Any()).DoAndReturn(func(job models.Job) (models.Job, error) {
+		assert.Equal(t, models.JobWaitlistOffer, job.Kind)
+		assert.Equal(t, "20", job.Payload)
+		return job, nil
+	})
+
+	// Only cancellations free a slot
+	svc.Notify(notification.EventConfirmed, canceled)
+	svc.Notify(notification.EventCanceled, canceled)
+}
+
+func TestWaitlistService_AcceptOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+	expectFreeAgenda(m)
+
+	entry := waitlistEntry(13, 4, 45, "09:00", "12:00")
+	offer := models.WaitlistOffer{ID: 20, EntryID: 13, Entry: entry, UserID: 4, SourceAppointmentID: 5, Date: futureDate(3), DurationMinutes: 45, Status: models.OfferPending, ExpiresAt: time.Now().Add(time.Hour)}
+	m.waitlist.EXPECT().FindOffer(uint(20)).Return(offer, nil)
+	m.waitlist.EXPECT().CloseOffer(uint(20), models.OfferAccepted).Return(true, nil)
+	m.services.EXPECT().FindByIDs([]uint{13}).Return(entry.Services, nil)
+	expectBooking(m.appointments)
+	m.appointments.EXPECT().Create(gomock.Any()).DoAndReturn(func(ap models.Appointment) (models.Appointment, error) {
+		assert.Equal(t, uint(4), ap.UserID)
+		assert.Equal(t, offer.Date, ap.Date)
+		assert.Equal(t, models.StatusPending, ap.Status)
+		assert.Len(t, ap.Items, 1)
+		ap.ID = 30
+		return ap, nil
+	})
+	m.waitlist.EXPECT().SetOfferAppointment(uint(20), uint(30)).Return(nil)
+	m.waitlist.EXPECT().ChangeStatus(uint(13), models.WaitlistOffered, models.WaitlistBooked).Return(true, nil)
+	m.notifier.EXPECT().Notify(notification.EventBooked, gomock.Any())
+
+	ap, err := svc.AcceptOffer(4, 20)
+	require.NoError(t, err)
+	assert.Equal(t, uint(30), ap.ID)
+}
+
+func TestWaitlistService_AcceptOffer_Refused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+
+	pending := models.WaitlistOffer{ID: 20, EntryID: 13, UserID: 4, Status: models.OfferPending, ExpiresAt: time.Now().Add(time.Hour)}
+	m.waitlist.EXPECT().FindOffer(uint(20)).Return(pending, nil).Times(2)
+	_, err := svc.AcceptOffer(5, 20)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "offers of other customers are not found")
+
+	// Expired or declined meanwhile
+	m.waitlist.EXPECT().CloseOffer(uint(20), models.OfferAccepted).Return(false, nil)
+	_, err = svc.AcceptOffer(4, 20)
+	assert.ErrorIs(t, err, models.ErrWaitlistOfferClosed)
+
+	expired := pending
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	m.waitlist.EXPECT().FindOffer(uint(20)).Return(expired, nil)
+	_, err = svc.AcceptOffer(4, 20)
+	assert.ErrorIs(t, err, models.ErrWaitlistOfferClosed)
+}
+
+// TestWaitlistService_ExpireOffers tests that a slot not accepted in time goes to the next customer
+func TestWaitlistService_ExpireOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+	expectFreeAgenda(m)
+
+	source := models.Appointment{ID: 5, UserID: 1, Date: futureDate(3), Status: models.StatusCanceled, Items: []models.AppointmentItem{{DurationMinutes: 60}}}
+	expired := models.WaitlistOffer{ID: 20, EntryID: 13, UserID: 4, SourceAppointmentID: 5, Date: source.Date, Status: models.OfferPending}
+	m.waitlist.EXPECT().ListExpiredOffers(gomock.Any()).Return([]models.WaitlistOffer{expired}, nil)
+	m.waitlist.EXPECT().CloseOffer(uint(20), models.OfferExpired).Return(true, nil)
+	m.waitlist.EXPECT().ChangeStatus(uint(13), models.WaitlistOffered, models.WaitlistWaiting).Return(true, nil)
+	m.appointments.EXPECT().FindByID(uint(5)).Return(source, nil)
+
+	// The first customer is waiting again, but already had their chance at this slot
+	m.waitlist.EXPECT().ListWaiting(gomock.Any()).Return([]models.WaitlistEntry{
+		waitlistEntry(13, 4, 45, "09:00", "12:00"),
+		waitlistEntry(14, 5, 30, "09:00", "12:00"),
+	}, nil)
+	m.waitlist.EXPECT().ListOffersBySource(uint(5)).Return([]models.WaitlistOffer{expired}, nil)
+	m.waitlist.EXPECT().ChangeStatus(uint(14), models.WaitlistWaiting, models.WaitlistOffered).Return(true, nil)
+	m.waitlist.EXPECT().CreateOffer(gomock.Any()).DoAndReturn(func(offer models.WaitlistOffer) (models.WaitlistOffer, error) {
+		assert.Equal(t, uint(5), offer.UserID)
+		offer.ID = 21
+		return offer, nil
+	})
+	m.jobs.EXPECT().Create(gomock.Any()).Return(models.Job{}, nil)
+
+	assert.NoError(t, svc.ExpireOffers(context.Background()))
+}
+
+func TestWaitlistService_Join(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+
+	services := []models.Service{{ID: 1, DurationMinutes: 30}}
+	m.services.EXPECT().FindByIDs([]uint{1}).Return(services, nil).AnyTimes()
+	day := futureDate(3).Format(models.DateLayout)
+
+	tests := []struct {
+		name  string
+		entry models.WaitlistEntry
+	}{
+		{"reversed window", models.WaitlistEntry{StartDate: day, EndDate: day, WindowStart: "12:00", WindowEnd: "09:00"}},
+		{"reversed period", models.WaitlistEntry{StartDate: day, EndDate: futureDate(1).Format(models.DateLayout), WindowStart: "09:00", WindowEnd: "12:00"}},
+		{"past period", models.WaitlistEntry{StartDate: "2020-01-01", EndDate: "2020-01-02", WindowStart: "09:00", WindowEnd: "12:00"}},
+		{"invalid time", models.WaitlistEntry{StartDate: day, EndDate: day, WindowStart: "9h", WindowEnd: "12:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.entry.Services = []models.Service{{ID: 1}}
+			_, err := svc.Join(7, tt.entry)
+			assert.ErrorIs(t, err, models.ErrInvalidWaitlistEntry)
+		})
+	}
+
+	m.waitlist.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry models.WaitlistEntry) (models.WaitlistEntry, error) {
+		assert.Equal(t, uint(7), entry.UserID)
+		assert.Equal(t, models.WaitlistWaiting, entry.Status)
+		assert.Equal(t, services, entry.Services)
+		return entry, nil
+	})
+	_, err := svc.Join(7, models.WaitlistEntry{Services: []models.Service{{ID: 1}}, StartDate: day, EndDate: day, WindowStart: "09:00", WindowEnd: "12:00", Status: models.WaitlistBooked})
+	assert.NoError(t, err)
+}
+
+// TestWaitlistService_Leave tests that leaving while holding an offer passes the slot on
+func TestWaitlistService_Leave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newTestWaitlistService(t, ctrl, &bytes.Buffer{})
+
+	entry := waitlistEntry(13, 4, 45, "09:00", "12:00")
+	entry.Status = models.WaitlistOffered
+	offer := models.WaitlistOffer{ID: 20, EntryID: 13, UserID: 4, SourceAppointmentID: 5, Status: models.OfferPending, ExpiresAt: time.Now().Add(time.Hour)}
+	m.waitlist.EXPECT().FindByID(uint(13)).Return(entry, nil)
+	m.waitlist.EXPECT().ChangeStatus(uint(13), models.WaitlistOffered, models.WaitlistLeft).Return(true, nil)
+	m.waitlist.EXPECT().ListPendingOffers(uint(4), gomock.Any()).Return([]models.WaitlistOffer{offer}, nil)
+	m.waitlist.EXPECT().CloseOffer(uint(20), models.OfferDeclined).Return(true, nil)
+	// The customer left, so the entry stays closed
+	m.waitlist.EXPECT().ChangeStatus(uint(13), models.WaitlistOffered, models.WaitlistWaiting).Return(false, nil)
+	// The appointment was booked again meanwhile, there is no slot to pass on
+	m.appointments.EXPECT().FindByID(uint(5)).Return(models.Appointment{ID: 5, Status: models.StatusPending}, nil)
+
+	assert.NoError(t, svc.Leave(4, 13))
+
+	m.waitlist.EXPECT().FindByID(uint(13)).Return(entry, nil)
+	assert.ErrorIs(t, svc.Leave(5, 13), gorm.ErrRecordNotFound)
+}
+
+func TestWaitlistService_SendOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	out := &bytes.Buffer{}
+	svc, m := newTestWaitlistService(t, ctrl, out)
+
+	entry := waitlistEntry(13, 4, 45, "09:00", "12:00")
+	entry.User = models.User{Name: "Maria", Email: "maria@example.com"}
+	offer := models.WaitlistOffer{ID: 20, EntryID: 13, Entry: entry, UserID: 4, Date: futureDate(3), Status: models.OfferPending, ExpiresAt: time.Now().Add(time.Hour)}
+	m.waitlist.EXPECT().FindOffer(uint(20)).Return(offer, nil)
+
+	job := models.Job{Kind: models.JobWaitlistOffer, Payload: strconv.Itoa(20)}
+	require.NoError(t, svc.SendOffer(context.Background(), job))
+	assert.Contains(t, out.String(), "maria@example.com")
+	assert.Contains(t, out.String(), "Horário disponível")
+
+	// Nothing is sent about an offer that was already answered
+	out.Reset()
+	offer.Status = models.OfferDeclined
+	m.waitlist.EXPECT().FindOffer(uint(20)).Return(offer, nil)
+	require.NoError(t, svc.SendOffer(context.Background(), job))
+	assert.Empty(t, out.String())
+}
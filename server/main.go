@@ -7,18 +7,29 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	_ "github.com/ViniciusBoroto/cabeleleila_leila/docs"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/database"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/handlers"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/jobs"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/keys"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/notification"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/oidc"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/repository"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/security"
 	"github.com/ViniciusBoroto/cabeleleila_leila/internal/service"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/glebarez/sqlite"
 	_ "github.com/joho/godotenv/autoload"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -27,41 +38,89 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
 	r := gin.Default()
+	// Only the listed proxies may set the client address with X-Forwarded-For, otherwise anyone could pick
+	// the address the failed logins are counted against
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Setup CORS middleware
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowHeaders:     []string{"Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", handlers.TotalCountHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600,
 	}))
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	db := setupSqliteDB()
-	migrateDatabase(db)
+	db := setupDatabase()
+	checkSchema(db)
 	seed(db)
+	requireDefaultPasswordChange(db)
 
 	// Setup repositories
 	userRepo := repository.NewUserRepository(db)
 	apRepo := repository.NewAppointmentRepository(db)
 	serviceRepo := repository.NewServiceRepository(db)
+	professionalRepo := repository.NewProfessionalRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	cancellationRepo := repository.NewCancellationPolicyRepository(db)
+	feeRepo := repository.NewFeeRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	sweeperRulesRepo := repository.NewSweeperRulesRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	accountTokenRepo := repository.NewAccountTokenRepository(db)
+	loginThrottleRepo := repository.NewLoginThrottleRepository(db)
+	identityRepo := repository.NewIdentityRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
 
 	// Setup services
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		panic("JWT_SECRET environment variable not set")
+	keySet := setupSigningKeys()
+	transport, renderer := setupNotifier()
+	dispatcher := notification.NewDispatcher(transport, renderer, notification.DefaultQueueSize)
+	defer dispatcher.Close()
+	reminderSvc := service.NewReminderService(jobRepo, apRepo, renderer, transport, service.DefaultReminderOffsets)
+
+	authSvc := service.NewAuthService(keySet)
+	sessionSvc := service.NewSessionService(authSvc, refreshTokenRepo, userRepo)
+	appURL := os.Getenv("APP_URL")
+	if appURL == "" {
+		appURL = "http://localhost:5173"
 	}
-	authSvc := service.NewAuthService(jwtSecret)
-	apSvc := service.NewAppointmentService(apRepo)
+	loginGuard := service.NewLoginGuard(loginThrottleRepo, setupSecurityRecorder(), service.DefaultAccountThrottle, service.DefaultIPThrottle)
+	accountSvc := service.NewAccountService(userRepo, accountTokenRepo, loginThrottleRepo, sessionSvc, renderer, dispatcher, appURL)
+	identitySvc := service.NewIdentityService(setupIdentityProviders(), identityRepo, userRepo, accountTokenRepo, sessionSvc)
+	// Slots freed by cancellations are offered to the waitlist, the appointments booked from the offers are notified like any other
+	waitlistSvc := service.NewWaitlistService(waitlistRepo, apRepo, serviceRepo, professionalRepo, scheduleRepo, jobRepo, renderer, transport, notification.Multi(dispatcher, reminderSvc), service.DefaultOfferHold)
+	apSvc := service.NewAppointmentService(apRepo, serviceRepo, professionalRepo, scheduleRepo, cancellationRepo, waitlistRepo, notification.Multi(dispatcher, reminderSvc, waitlistSvc))
+	userSvc := service.NewUserService(userRepo, apSvc, sessionSvc)
 	serviceSvc := service.NewServiceService(serviceRepo)
+	professionalSvc := service.NewProfessionalService(professionalRepo, serviceRepo)
+	scheduleSvc := service.NewScheduleService(scheduleRepo)
+	availabilitySvc := service.NewAvailabilityService(apRepo, serviceRepo, professionalRepo, scheduleRepo, waitlistRepo)
+	cancellationSvc := service.NewCancellationPolicyService(cancellationRepo, feeRepo)
+	reportSvc := service.NewReportService(reportRepo)
+	sweeperSvc := service.NewSweeperService(apRepo, sweeperRulesRepo)
 
 	// Setup handlers
-	authHandler := handlers.NewAuthHandler(authSvc, userRepo)
+	authHandler := handlers.NewAuthHandler(authSvc, sessionSvc, accountSvc, loginGuard, userRepo)
 	appointmentsHandler := handlers.NewAppointmentHandler(apSvc)
+	if os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true" {
+		appointmentsHandler.RequireVerifiedEmail(userRepo)
+	}
+
+	// Other services verify the access tokens with these keys
+	r.GET("/.well-known/jwks.json", handlers.JWKS(keySet))
 
 	// Public routes
 	public := r.Group("/api")
@@ -72,64 +131,340 @@ func main() {
 
 		public.POST("/auth/login", authHandler.Login)
 		public.POST("/auth/register", authHandler.Register)
+		public.POST("/auth/refresh", authHandler.RefreshToken)
+		public.POST("/auth/logout", authHandler.Logout)
+		public.POST("/auth/forgot-password", authHandler.ForgotPassword)
+		public.POST("/auth/reset-password", authHandler.ResetPassword)
+		public.POST("/auth/verify-email", authHandler.VerifyEmail)
+		public.GET("/auth/oidc/providers", handlers.ListIdentityProviders(identitySvc))
+		public.GET("/auth/oidc/:provider/login", handlers.BeginIdentityLogin(identitySvc))
+		public.GET("/auth/oidc/:provider/callback", handlers.IdentityLoginCallback(identitySvc, appURL))
+		public.POST("/auth/oidc/exchange", handlers.ExchangeLoginCode(identitySvc))
 		public.GET("/services", handlers.ListServices(serviceSvc))
 		public.GET("/services/:id", handlers.GetService(serviceSvc))
+		public.GET("/professionals", handlers.ListActiveProfessionals(professionalSvc))
+		public.GET("/availability", handlers.GetAvailability(availabilitySvc))
+		public.GET("/business-hours", handlers.GetBusinessHours(scheduleSvc))
+		public.GET("/cancellation-policy", handlers.GetCancellationPolicy(cancellationSvc))
 	}
 
 	// Protected routes - all authenticated users
 	protected := r.Group("/api")
 	protected.Use(handlers.JWTAuthMiddleware(authSvc))
+	// Accounts with a well-known password can only change it
+	protected.Use(handlers.RequirePasswordChange("/api/me", "/api/me/password", "/api/auth/validate", "/api/auth/logout-all"))
 	{
 		protected.GET("/auth/validate", authHandler.ValidateToken)
-		protected.POST("/auth/refresh", authHandler.RefreshToken)
+		protected.POST("/auth/logout-all", authHandler.LogoutAll)
+		protected.POST("/auth/resend-verification", authHandler.ResendVerification)
+
+		// Profile of the logged user
+		protected.GET("/me", handlers.GetMe(userSvc))
+		protected.PUT("/me", handlers.UpdateMe(userSvc))
+		protected.POST("/me/password", handlers.ChangeMyPassword(userSvc))
+		protected.DELETE("/me", handlers.DeleteMe(userSvc))
 
 		// Appointment routes (for all authenticated users)
 		appointmentsHandler.RegisterRoutes(protected)
+		protected.GET("/fees", handlers.ListMyFees(cancellationSvc))
+
+		// Waitlist for fully booked days
+		protected.POST("/waitlist", handlers.JoinWaitlist(waitlistSvc))
+		protected.GET("/waitlist", handlers.ListMyWaitlist(waitlistSvc))
+		protected.DELETE("/waitlist/:id", handlers.LeaveWaitlist(waitlistSvc))
+		protected.GET("/waitlist/offers", handlers.ListMyWaitlistOffers(waitlistSvc))
+		protected.POST("/waitlist/offers/:id/accept", handlers.AcceptWaitlistOffer(waitlistSvc))
+		protected.POST("/waitlist/offers/:id/decline", handlers.DeclineWaitlistOffer(waitlistSvc))
 
-		// User management routes (admin only)
+		// Staff routes, each group requires the permission granted by the roles allowed to use it
 		admin := protected.Group("/admin")
 		{
-			admin.GET("/users", handlers.GetAllUsers(userRepo))
-			admin.POST("/users", handlers.CreateUser(userRepo))
-			admin.GET("/users/:id", handlers.GetUser(userRepo))
-			admin.PUT("/users/:id", handlers.UpdateUser(userRepo))
-			admin.DELETE("/users/:id", handlers.DeleteUser(userRepo))
-
-			admin.PUT("/appointments/:id", appointmentsHandler.UpdateAppointment)
-			admin.GET("/appointments", appointmentsHandler.ListAllAppointments)
-
-			// Service management routes (admin only)
-			admin.POST("/services", handlers.CreateService(serviceSvc))
-			admin.PUT("/services/:id", handlers.UpdateService(serviceSvc))
-			admin.DELETE("/services/:id", handlers.DeleteService(serviceSvc))
+			// User management routes
+			users := admin.Group("", handlers.RequirePermission(models.PermManageUsers))
+			users.GET("/roles", handlers.ListRoles())
+			users.GET("/users", handlers.GetAllUsers(userRepo))
+			users.POST("/users", handlers.CreateUser(userRepo))
+			users.GET("/users/:id", handlers.GetUser(userRepo))
+			users.PUT("/users/:id", handlers.UpdateUser(userRepo, sessionSvc))
+			users.DELETE("/users/:id", handlers.DeleteUser(userRepo, sessionSvc))
+			users.POST("/users/:id/restore", handlers.RestoreUser(userRepo))
+			users.POST("/users/:id/unlock", handlers.UnlockUser(userRepo, loginGuard))
+			users.GET("/users/:id/fees", handlers.ListUserFees(cancellationSvc))
+
+			admin.GET("/appointments", handlers.RequirePermission(models.PermViewAppointments), appointmentsHandler.ListAllAppointments)
+			admin.PUT("/appointments/:id", handlers.RequirePermission(models.PermManageAppointments), appointmentsHandler.UpdateAppointment)
+
+			// Service management routes
+			catalog := admin.Group("", handlers.RequirePermission(models.PermManageCatalog))
+			catalog.GET("/services", handlers.ListAllServices(serviceSvc))
+			catalog.POST("/services", handlers.CreateService(serviceSvc))
+			catalog.PUT("/services/:id", handlers.UpdateService(serviceSvc))
+			catalog.DELETE("/services/:id", handlers.DeleteService(serviceSvc))
+			catalog.POST("/services/:id/restore", handlers.RestoreService(serviceSvc))
+
+			// Professional management routes
+			professionals := admin.Group("", handlers.RequirePermission(models.PermManageProfessionals))
+			professionals.GET("/professionals", handlers.ListProfessionals(professionalSvc))
+			professionals.POST("/professionals", handlers.CreateProfessional(professionalSvc))
+			professionals.GET("/professionals/:id", handlers.GetProfessional(professionalSvc))
+			professionals.PUT("/professionals/:id", handlers.UpdateProfessional(professionalSvc))
+			professionals.DELETE("/professionals/:id", handlers.DeleteProfessional(professionalSvc))
+
+			settings := admin.Group("", handlers.RequirePermission(models.PermManageSettings))
+			// Opening hours and closure calendar
+			settings.PUT("/business-hours", handlers.UpdateBusinessHours(scheduleSvc))
+			settings.GET("/holidays", handlers.ListHolidays(scheduleSvc))
+			settings.POST("/holidays", handlers.CreateHoliday(scheduleSvc))
+			settings.DELETE("/holidays/:id", handlers.DeleteHoliday(scheduleSvc))
+			// Cancellation and rescheduling rules
+			settings.PUT("/cancellation-policy", handlers.UpdateCancellationPolicy(cancellationSvc))
+			// Automatic closing of overdue appointments
+			settings.GET("/sweeper-rules", handlers.GetSweeperRules(sweeperSvc))
+			settings.PUT("/sweeper-rules", handlers.UpdateSweeperRules(sweeperSvc))
+			settings.POST("/sweeper/run", handlers.RunSweeper(sweeperSvc))
+
+			// Reports
+			reports := admin.Group("", handlers.RequirePermission(models.PermViewReports))
+			reports.GET("/reports/performance", handlers.GetPerformanceReport(reportSvc))
+			reports.GET("/reports/top-services", handlers.GetTopServicesReport(reportSvc))
+			reports.GET("/reports/busiest-hours", handlers.GetBusiestHoursReport(reportSvc))
 		}
 	}
 
+	// Background jobs
+	scheduler := jobs.NewScheduler(jobRepo, jobs.DefaultInterval)
+	scheduler.Register(models.JobAppointmentReminder, reminderSvc.SendReminder)
+	scheduler.Register(models.JobWaitlistOffer, waitlistSvc.SendOffer)
+	// Picks up keys added or retired in JWT_KEYS_DIR, so they can be rotated without a restart
+	scheduler.Every("signing-keys-reload", time.Minute, func(ctx context.Context) error {
+		return keySet.Reload()
+	})
+	scheduler.Every("waitlist-offer-expiry", time.Minute, waitlistSvc.ExpireOffers)
+	scheduler.Every("sweeper", 15*time.Minute, func(ctx context.Context) error {
+		_, err := sweeperSvc.Sweep(ctx)
+		return err
+	})
+	scheduler.Every("refresh-token-cleanup", 24*time.Hour, func(ctx context.Context) error {
+		return refreshTokenRepo.DeleteExpired(time.Now())
+	})
+	scheduler.Every("account-token-cleanup", 24*time.Hour, func(ctx context.Context) error {
+		return accountTokenRepo.DeleteExpired(time.Now())
+	})
+	scheduler.Every("login-state-cleanup", time.Hour, func(ctx context.Context) error {
+		return identityRepo.DeleteExpiredStates(time.Now())
+	})
+	scheduler.Every("login-throttle-cleanup", 24*time.Hour, func(ctx context.Context) error {
+		return loginThrottleRepo.DeleteStale(time.Now().Add(-24 * time.Hour))
+	})
+	scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
 	if err := r.Run(":8080"); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func setupSqliteDB() *gorm.DB {
-	path := os.Getenv("DB_PATH")
+// setupNotifier sends the emails through SMTP_HOST when it is set. Otherwise they are written to
+// NOTIFICATION_LOG_FILE, or to the standard output, so they can be checked during development.
+func setupNotifier() (notification.Notifier, *notification.Renderer) {
+	renderer, err := notification.NewRenderer(os.Getenv("NOTIFICATION_LOCALE"))
+	if err != nil {
+		panic(err)
+	}
+
+	var transport notification.Notifier
+	switch {
+	case os.Getenv("SMTP_HOST") != "":
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		transport = notification.NewSMTPNotifier(notification.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		})
+	case os.Getenv("NOTIFICATION_LOG_FILE") != "":
+		transport, err = notification.NewFileNotifier(os.Getenv("NOTIFICATION_LOG_FILE"))
+		if err != nil {
+			panic(err)
+		}
+	default:
+		transport = notification.NewLogNotifier(os.Stdout)
+	}
+	return transport, renderer
+}
+
+// setupSigningKeys loads the keys that sign the access tokens from JWT_KEYS_DIR. When the directory
+// has no keys, a new Ed25519 key is created there so development setups work out of the box.
+func setupSigningKeys() *keys.Set {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		dir = "keys"
+	}
+	found, err := keys.HasKeys(dir)
+	if err != nil {
+		panic(err)
+	}
+	if !found {
+		key, err := keys.GenerateEd25519(time.Now().Format("2006-01-02"))
+		if err != nil {
+			panic(err)
+		}
+		if err := keys.Write(dir, key); err != nil {
+			panic(err)
+		}
+		log.Printf("no signing keys found, created %s/%s.pem", dir, key.ID)
+	}
+
+	keySet, err := keys.LoadDir(dir, os.Getenv("JWT_SIGNING_KEY"))
+	if err != nil {
+		panic(err)
+	}
+	return keySet
+}
+
+// setupIdentityProviders registers the OpenID Connect providers listed in OIDC_PROVIDERS. Each one is
+// configured by OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and optionally
+// OIDC_<NAME>_SCOPES, and must accept <API_URL>/api/auth/oidc/<name>/callback as redirect URL.
+func setupIdentityProviders() []*oidc.Provider {
+	apiURL := os.Getenv("API_URL")
+	if apiURL == "" {
+		apiURL = "http://localhost:8080"
+	}
+
+	var providers []*oidc.Provider
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		cfg := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  strings.TrimSuffix(apiURL, "/") + "/api/auth/oidc/" + name + "/callback",
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			panic(prefix + "ISSUER and " + prefix + "CLIENT_ID environment variables must be set")
+		}
+		providers = append(providers, oidc.NewProvider(cfg, nil))
+	}
+	return providers
+}
+
+// setupSecurityRecorder writes the security events to SECURITY_LOG_FILE, or to the standard output
+func setupSecurityRecorder() security.Recorder {
+	path := os.Getenv("SECURITY_LOG_FILE")
 	if path == "" {
-		panic("DB_PATH environment variable not set")
+		return security.NewLogRecorder(os.Stdout)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		panic(err)
+	}
+	return security.NewLogRecorder(file)
+}
+
+func setupDatabase() *gorm.DB {
+	cfg, err := database.ConfigFromEnv()
+	if err != nil {
+		panic(err)
 	}
-	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	db, err := database.Open(cfg)
 	if err != nil {
 		panic(err)
 	}
 	return db
 }
 
-func migrateDatabase(db *gorm.DB) {
-	if err := db.AutoMigrate(
-		&models.User{},
-		&models.Service{},
-		&models.Appointment{},
-	); err != nil {
+// checkSchema refuses to start the server on a database the pending migrations were not applied to
+func checkSchema(db *gorm.DB) {
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
 		panic(err)
 	}
+	if err := migrator.Check(); err != nil {
+		log.Fatalf("%v. Run the migrations with: go run . migrate up", err)
+	}
+}
+
+// runMigrate is the migrate subcommand: migrate up | down | status | to <version>
+func runMigrate(args []string) {
+	usage := "usage: migrate up | down | status | to <version>"
+	if len(args) == 0 {
+		log.Fatal(usage)
+	}
+	migrator, err := database.NewMigrator(setupDatabase())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = migrator.Up()
+	case args[0] == "down" && len(args) == 1:
+		err = migrator.Down()
+	case args[0] == "to" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			log.Fatal(usage)
+		}
+		err = migrator.To(version)
+	case args[0] == "status" && len(args) == 1:
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	list, err := migrator.Status()
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, migration := range list {
+		appliedAt := "pending"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", migration.Version, migration.Name, appliedAt)
+	}
+	w.Flush()
+}
+
+// trustedProxies reads the comma-separated addresses or CIDR ranges of TRUSTED_PROXIES, none when empty
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// requireDefaultPasswordChange flags the seeded admin of databases created before the forced password change,
+// as long as it still uses the default password. The flag of those databases is NULL, the column was added to
+// existing rows without a value.
+func requireDefaultPasswordChange(db *gorm.DB) {
+	var admin models.User
+	if err := db.Where("email = ? AND (must_change_password IS NULL OR must_change_password = ?)", "admin@admin.com", false).First(&admin).Error; err != nil {
+		return
+	}
+	if bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte("admin123")) != nil {
+		return
+	}
+	if err := db.Model(&admin).Update("must_change_password", true).Error; err != nil {
+		log.Printf("Error flagging the default admin password: %v", err)
+	}
 }
 
 func seed(db *gorm.DB) {
@@ -143,13 +478,17 @@ func seed(db *gorm.DB) {
 		log.Printf("Error seeding admin failed to hash password: %v", err)
 		return
 	}
+	verifiedAt := time.Now()
 	admin := models.User{
-		Email:    "admin@admin.com",
-		Password: string(hashedPassword),
-		Name:     "admin",
-		Phone:    "123456789",
-		Role:     models.RoleAdmin,
-		IsActive: true,
+		Email:           "admin@admin.com",
+		Password:        string(hashedPassword),
+		Name:            "admin",
+		Phone:           "123456789",
+		Role:            models.RoleAdmin,
+		IsActive:        true,
+		EmailVerifiedAt: &verifiedAt,
+		// admin123 is public, it must be replaced on the first login
+		MustChangePassword: true,
 	}
 	if err := db.Create(&admin).Error; err != nil {
 		log.Printf("Error seeding admin: %v", err)
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/database"
+	"github.com/ViniciusBoroto/cabeleleila_leila/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := database.Open(database.Config{Driver: database.DriverSQLite, DSN: dsn})
+	require.NoError(t, err)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up())
+	return db
+}
+
+func createAdmin(t *testing.T, db *gorm.DB, password string) models.User {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	admin := models.User{Email: "admin@admin.com", Password: string(hashed), Role: models.RoleAdmin, Name: "Admin", IsActive: true}
+	require.NoError(t, db.Create(&admin).Error)
+	return admin
+}
+
+// TestRequireDefaultPasswordChange_NullFlag covers the admin of a database created before the flag existed
+func TestRequireDefaultPasswordChange_NullFlag(t *testing.T) {
+	db := setupTestDB(t)
+	admin := createAdmin(t, db, "admin123")
+	require.NoError(t, db.Exec("UPDATE users SET must_change_password = NULL WHERE id = ?", admin.ID).Error)
+
+	requireDefaultPasswordChange(db)
+
+	var found models.User
+	require.NoError(t, db.First(&found, admin.ID).Error)
+	assert.True(t, found.MustChangePassword)
+}
+
+func TestRequireDefaultPasswordChange_PasswordChanged(t *testing.T) {
+	db := setupTestDB(t)
+	admin := createAdmin(t, db, "a-new-password")
+	require.NoError(t, db.Exec("UPDATE users SET must_change_password = NULL WHERE id = ?", admin.ID).Error)
+
+	requireDefaultPasswordChange(db)
+
+	var found models.User
+	require.NoError(t, db.First(&found, admin.ID).Error)
+	assert.False(t, found.MustChangePassword)
+}
+
+func TestTrustedProxies(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "")
+	assert.Nil(t, trustedProxies())
+
+	t.Setenv("TRUSTED_PROXIES", " 10.0.0.1, 192.168.0.0/16 ,")
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, trustedProxies())
+}
@@ -6,6 +6,10 @@ import NotFoundPage from "./pages/NotFound.tsx";
 import ForbiddenPage from "./pages/Forbidden.tsx";
 import AdminDashboardPage from "./pages/AdminDashboard.tsx";
 import RegisterPage from "./pages/RegisterPage.tsx";
+import ChangePasswordPage from "./pages/ChangePasswordPage.tsx";
+import LoginCallbackPage from "./pages/LoginCallbackPage.tsx";
+import ResetPasswordPage from "./pages/ResetPasswordPage.tsx";
+import VerifyEmailPage from "./pages/VerifyEmailPage.tsx";
 
 function App() {
   return (
@@ -25,23 +29,33 @@ function App() {
 
         <Route path="/login" element={<LoginPage />} />
 
+        {/* Volta do login pelo provedor de identidade */}
+        <Route path="/login/callback" element={<LoginCallbackPage />} />
+
         <Route path="/registrar" element={<RegisterPage />} />
 
+        {/* Links enviados por email - o token vem na URL */}
+        <Route path="/reset-password" element={<ResetPasswordPage />} />
+        <Route path="/verify-email" element={<VerifyEmailPage />} />
+
+        {/* Troca de senha obrigatória - a própria página exige estar logado */}
+        <Route path="/trocar-senha" element={<ChangePasswordPage />} />
+
         {/* Rota de Dashboard - protegida, só acessa se estiver logado */}
         <Route
           path="/agendar"
           element={
-            <PrivateRoute allowedRoles={["admin", "customer"]}>
+            <PrivateRoute>
               <Dashboard />
             </PrivateRoute>
           }
         />
 
-        {/* Rota de Administração - protegida, só acessa se estiver logado e ser admin */}
+        {/* Rota de Administração - protegida, exige ver os agendamentos de todos os clientes */}
         <Route
           path="/admin/dashboard"
           element={
-            <PrivateRoute allowedRoles={["admin"]}>
+            <PrivateRoute requiredPermission="appointments:view">
               <AdminDashboardPage />
             </PrivateRoute>
           }
@@ -1,4 +1,5 @@
 import { useState } from "react";
+import { saveSession } from "../utils/session";
 
 export default function LoginPage() {
   const [email, setEmail] = useState("");
@@ -32,9 +33,8 @@ export default function LoginPage() {
         console.log("Login realizado com sucesso!");
         console.log("Token JWT recebido:", data.token);
 
-        // Salva o token JWT no localStorage
-        console.log("token: " + data.token);
-        localStorage.setItem("token", data.token);
+        // Salva os tokens da sessão no localStorage
+        saveSession(data);
 
         // Se o usuário marcou "Lembrar-me", salva o email também
         if (lembrarMe) {
@@ -1,12 +1,9 @@
 import { LogOut } from "lucide-react";
+import { logout } from "../utils/session";
 
 export default function LogoutButton() {
-  const handleLogout = () => {
-    localStorage.removeItem("token");
-    localStorage.removeItem("user");
-    localStorage.removeItem("role");
-    localStorage.removeItem("email");
-    localStorage.removeItem("name");
+  const handleLogout = async () => {
+    await logout();
     window.location.href = "/login";
   };
 
@@ -1,20 +1,24 @@
 import { useEffect, useState } from "react";
 import { Navigate } from "react-router-dom";
+import { authFetch } from "../utils/session";
 
 interface PrivateRouteProps {
   children: React.ReactNode;
-  allowedRoles?: string[];
+  // Permissão exigida pela página, como as das rotas da API
+  requiredPermission?: string;
 }
 
 const API_BASE = "http://localhost:8080/api";
 
 export default function PrivateRoute({
   children,
-  allowedRoles,
+  requiredPermission,
 }: PrivateRouteProps) {
   const [isAuthenticated, setIsAuthenticated] = useState<boolean>(false);
   const [isLoading, setIsLoading] = useState<boolean>(true);
   const [userRole, setUserRole] = useState<string | null>(null);
+  const [permissions, setPermissions] = useState<string[]>([]);
+  const [mustChangePassword, setMustChangePassword] = useState(false);
 
   const validateToken = async () => {
     const token = localStorage.getItem("token");
@@ -23,9 +27,9 @@ export default function PrivateRoute({
       return;
     }
 
-    const response = await fetch(`${API_BASE}/auth/validate`, {
+    // Renova a sessão se o token de acesso já expirou
+    const response = await authFetch(`${API_BASE}/auth/validate`, {
       headers: {
-        Authorization: `Bearer ${token}`,
         "Content-Type": "application/json",
       },
     });
@@ -33,9 +37,13 @@ export default function PrivateRoute({
     if (response.ok) {
       const data = await response.json();
       setUserRole(data.role);
-      localStorage.setItem("token", token);
-      localStorage.setItem("user", JSON.stringify(data.user));
+      setPermissions(data.permissions || []);
+      setMustChangePassword(Boolean(data.must_change_password));
       localStorage.setItem("role", data.role);
+      localStorage.setItem(
+        "permissions",
+        JSON.stringify(data.permissions || [])
+      );
       setIsAuthenticated(true);
     } else {
       console.error("Erro ao validar token");
@@ -57,7 +65,12 @@ export default function PrivateRoute({
     return <Navigate to="/login" replace />;
   }
 
-  if (allowedRoles && !allowedRoles.includes(userRole)) {
+  // A sessão só permite trocar a senha até que ela seja trocada
+  if (mustChangePassword) {
+    return <Navigate to="/trocar-senha" replace />;
+  }
+
+  if (requiredPermission && !permissions.includes(requiredPermission)) {
     return <Navigate to="/forbidden" replace />;
   }
 
@@ -1,5 +1,12 @@
-import { useState, useEffect, useMemo } from "react";
-import { Calendar, TrendingUp, DollarSign, Users, Clock } from "lucide-react";
+import { useState, useEffect, useMemo, useRef } from "react";
+import {
+  Calendar,
+  TrendingUp,
+  DollarSign,
+  Users,
+  CheckCircle,
+  Search,
+} from "lucide-react";
 import {
   LineChart,
   Line,
@@ -16,9 +23,12 @@ import LogoutButton from "../components/LogoutButton";
 import AppointmentFilter from "../components/AppointmentFilter";
 import type { FilterPeriod } from "../components/AppointmentFilter";
 import {
-  filterAppointmentsByPeriod,
+  formatDay,
   getDefaultCustomDates,
+  getPeriodRange,
 } from "../utils/filterHelpers";
+import { fetchPage } from "../utils/pagination";
+import { authFetch, hasPermission } from "../utils/session";
 
 // Tipos
 interface Service {
@@ -45,26 +55,46 @@ interface Appointment {
   updated_at?: string;
 }
 
-interface WeeklyStats {
-  week: string;
-  appointments: number;
+interface PeriodPerformance {
+  period: string;
+  total: number;
+  by_status: Partial<Record<Appointment["status"], number>> | null;
+  completion_rate: number;
+  cancellation_rate: number;
   revenue: number;
-  services: number;
 }
 
+interface PerformanceReport {
+  totals: PeriodPerformance;
+  periods: PeriodPerformance[];
+}
+
+type StatusFilter = "all" | Appointment["status"];
+type SortOrder = "date" | "-date" | "created_at" | "-created_at";
+
 const API_BASE = "http://localhost:8080/api";
+const PAGE_SIZE = "50";
+// O catálogo de serviços de um salão cabe em uma página
+const SERVICES_PAGE_SIZE = "200";
+// Semanas cobertas pelos cards de estatísticas e pelos gráficos
+const REPORT_WEEKS = 8;
 
 const AdminDashboardPage = () => {
   const [appointments, setAppointments] = useState<Appointment[]>([]);
+  const [nextCursor, setNextCursor] = useState("");
+  const [totalCount, setTotalCount] = useState(0);
+  const [report, setReport] = useState<PerformanceReport | null>(null);
   const [availableServices, setAvailableServices] = useState<Service[]>([]);
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState("");
   const [selectedAppointment, setSelectedAppointment] =
     useState<Appointment | null>(null);
   const [showEditModal, setShowEditModal] = useState(false);
-  const [filter, setFilter] = useState<
-    "all" | "PENDING" | "CONFIRMED" | "DONE" | "CANCELED"
-  >("all");
+  const [filter, setFilter] = useState<StatusFilter>("all");
+  const [serviceFilter, setServiceFilter] = useState("");
+  const [search, setSearch] = useState("");
+  const [query, setQuery] = useState("");
+  const [sort, setSort] = useState<SortOrder>("-date");
 
   // Estados do filtro de período
   const [filterPeriod, setFilterPeriod] = useState<FilterPeriod>("all");
@@ -77,9 +107,19 @@ const AdminDashboardPage = () => {
   const [editStatus, setEditStatus] =
     useState<Appointment["status"]>("PENDING");
 
+  // O painel atende papéis diferentes, cada um vê só o que pode usar
+  const canViewReports = hasPermission("reports:view");
+  const canManageAppointments = hasPermission("appointments:manage");
+
+  // Identifica a busca mais recente, para descartar respostas de filtros
+  // que já foram trocados
+  const latestRequest = useRef(0);
+
   useEffect(() => {
-    fetchAppointments();
     fetchServices();
+    if (canViewReports) {
+      fetchReport();
+    }
 
     // Inicializar datas personalizadas
     const defaultDates = getDefaultCustomDates();
@@ -87,34 +127,62 @@ const AdminDashboardPage = () => {
     setCustomEndDate(defaultDates.end);
   }, []);
 
-  // Aplicar filtro de período nos agendamentos
-  const periodFilteredAppointments = useMemo(() => {
-    return filterAppointmentsByPeriod(
-      appointments,
-      filterPeriod,
-      customStartDate,
-      customEndDate
-    );
-  }, [appointments, filterPeriod, customStartDate, customEndDate]);
+  // Espera o usuário parar de digitar antes de buscar
+  useEffect(() => {
+    const timeout = setTimeout(() => setQuery(search.trim()), 300);
+    return () => clearTimeout(timeout);
+  }, [search]);
+
+  // Filtros e ordenação são aplicados pela API, em GET /admin/appointments
+  const searchParams = useMemo(() => {
+    const range = getPeriodRange(filterPeriod, customStartDate, customEndDate);
+    return {
+      status: filter === "all" ? "" : filter,
+      services: serviceFilter,
+      q: query,
+      sort,
+      start_date: range.start,
+      end_date: range.end,
+      limit: PAGE_SIZE,
+    };
+  }, [
+    filter,
+    serviceFilter,
+    query,
+    sort,
+    filterPeriod,
+    customStartDate,
+    customEndDate,
+  ]);
 
-  const fetchAppointments = async () => {
+  useEffect(() => {
+    fetchAppointments();
+  }, [searchParams]);
+
+  // Sem cursor, busca a primeira página e substitui a lista; com o
+  // next_cursor, acrescenta a página seguinte
+  const fetchAppointments = async (cursor = "") => {
     const token = localStorage.getItem("token");
     if (!token) return;
 
+    const request = ++latestRequest.current;
     setLoading(true);
     setError("");
 
     try {
-      const response = await fetch(`${API_BASE}/admin/appointments`, {
-        headers: {
-          Authorization: `Bearer ${token}`,
-          "Content-Type": "application/json",
-        },
-      });
+      const page = await fetchPage<Appointment>(
+        `${API_BASE}/admin/appointments`,
+        { ...searchParams, total: cursor ? "" : "true" },
+        cursor
+      );
+      if (request !== latestRequest.current) return;
 
-      if (response.ok) {
-        const data = await response.json();
-        setAppointments(data || []);
+      if (page) {
+        setAppointments((prev) =>
+          cursor ? [...prev, ...page.items] : page.items
+        );
+        setNextCursor(page.next_cursor || "");
+        if (!cursor) setTotalCount(page.total ?? page.items.length);
       } else {
         setError("Erro ao carregar agendamentos");
       }
@@ -122,16 +190,39 @@ const AdminDashboardPage = () => {
       setError("Erro de conexão com o servidor");
       console.error(err);
     } finally {
-      setLoading(false);
+      if (request === latestRequest.current) setLoading(false);
     }
   };
 
-  const fetchServices = async () => {
+  // Estatísticas das últimas semanas, agregadas pela API
+  const fetchReport = async () => {
+    const end = new Date();
+    const start = new Date(end);
+    start.setDate(end.getDate() - 7 * REPORT_WEEKS);
+
     try {
-      const response = await fetch(`${API_BASE}/services`);
+      const response = await authFetch(
+        `${API_BASE}/admin/reports/performance?start=${formatDay(
+          start
+        )}&end=${formatDay(end)}&bucket=week`
+      );
       if (response.ok) {
-        const data = await response.json();
-        setAvailableServices(data || []);
+        setReport(await response.json());
+      } else {
+        console.error("Erro ao carregar estatísticas");
+      }
+    } catch (err) {
+      console.error("Erro de conexão ao carregar estatísticas:", err);
+    }
+  };
+
+  const fetchServices = async () => {
+    try {
+      const page = await fetchPage<Service>(`${API_BASE}/services`, {
+        limit: SERVICES_PAGE_SIZE,
+      });
+      if (page) {
+        setAvailableServices(page.items);
       }
     } catch (err) {
       console.error("Erro ao carregar serviços:", err);
@@ -164,12 +255,11 @@ const AdminDashboardPage = () => {
         editServices.includes(s.id)
       );
 
-      const response = await fetch(
+      const response = await authFetch(
         `${API_BASE}/admin/appointments/${selectedAppointment.id}`,
         {
           method: "PUT",
           headers: {
-            Authorization: `Bearer ${token}`,
             "Content-Type": "application/json",
           },
           body: JSON.stringify({
@@ -208,73 +298,35 @@ const AdminDashboardPage = () => {
     setCustomEndDate(end);
   };
 
-  // Calcular estatísticas semanais
-  const calculateWeeklyStats = (): WeeklyStats[] => {
-    const stats: { [key: string]: WeeklyStats } = {};
-
-    appointments
-      .filter((ap) => ap.status !== "CANCELED")
-      .forEach((ap) => {
-        const date = new Date(ap.date);
-        const weekStart = new Date(date);
-        weekStart.setDate(date.getDate() - date.getDay());
-        const weekKey = weekStart.toISOString().split("T")[0];
-
-        if (!stats[weekKey]) {
-          stats[weekKey] = {
-            week: new Date(weekKey).toLocaleDateString("pt-BR", {
-              day: "2-digit",
-              month: "short",
-            }),
-            appointments: 0,
-            revenue: 0,
-            services: 0,
-          };
-        }
-
-        stats[weekKey].appointments += 1;
-        stats[weekKey].revenue += ap.services.reduce(
-          (sum, s) => sum + s.price,
-          0
-        );
-        stats[weekKey].services += ap.services.length;
-      });
-
-    return Object.values(stats)
-      .sort((a, b) => new Date(a.week).getTime() - new Date(b.week).getTime())
-      .slice(-8);
-  };
-
-  const weeklyStats = calculateWeeklyStats();
+  // Séries dos gráficos, uma entrada por semana do relatório
+  const weeklyStats = (report?.periods || []).map((period) => ({
+    week: new Date(`${period.period}T00:00:00`).toLocaleDateString("pt-BR", {
+      day: "2-digit",
+      month: "short",
+    }),
+    appointments: period.total - (period.by_status?.CANCELED || 0),
+    revenue: period.revenue,
+  }));
 
   // Estatísticas gerais
-  const totalRevenue = appointments
-    .filter((ap) => ap.status !== "CANCELED")
-    .reduce(
-      (sum, ap) => sum + ap.services.reduce((s, srv) => s + srv.price, 0),
-      0
-    );
-
-  const totalAppointments = appointments.filter(
-    (ap) => ap.status !== "CANCELED"
-  ).length;
-  const pendingAppointments = appointments.filter(
-    (ap) => ap.status === "PENDING"
-  ).length;
-  const avgDuration =
-    appointments.length > 0
-      ? appointments.reduce(
-          (sum, ap) =>
-            sum + ap.services.reduce((s, srv) => s + srv.duration_minutes, 0),
-          0
-        ) / appointments.length
-      : 0;
-
-  // Aplicar filtro de status na lista já filtrada por período
-  const filteredAppointments =
-    filter === "all"
-      ? periodFilteredAppointments
-      : periodFilteredAppointments.filter((ap) => ap.status === filter);
+  const totals = report?.totals;
+  const totalRevenue = totals?.revenue || 0;
+  const totalAppointments =
+    (totals?.total || 0) - (totals?.by_status?.CANCELED || 0);
+  const pendingAppointments = totals?.by_status?.PENDING || 0;
+  const completionRate = (totals?.completion_rate || 0) * 100;
+
+  const statusButtons: {
+    value: StatusFilter;
+    label: string;
+    active: string;
+  }[] = [
+    { value: "all", label: "Todos", active: "bg-purple-600" },
+    { value: "PENDING", label: "Pendentes", active: "bg-yellow-600" },
+    { value: "CONFIRMED", label: "Confirmados", active: "bg-blue-600" },
+    { value: "DONE", label: "Concluídos", active: "bg-green-600" },
+    { value: "CANCELED", label: "Cancelados", active: "bg-red-600" },
+  ];
 
   const formatDate = (dateString: string) => {
     const date = new Date(dateString);
@@ -314,208 +366,193 @@ const AdminDashboardPage = () => {
               Dashboard Admin
             </h1>
             <p className="text-gray-600">
-              Visão completa dos agendamentos e performance
+              Visão completa dos agendamentos e performance das últimas{" "}
+              {REPORT_WEEKS} semanas
             </p>
           </div>
           <LogoutButton />
         </div>
 
-        {/* Cards de Estatísticas */}
-        <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-4 gap-6 mb-8">
-          <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-purple-500">
-            <div className="flex items-center justify-between">
-              <div>
-                <p className="text-sm text-gray-600 mb-1">Receita Total</p>
-                <p className="text-2xl font-bold text-gray-900">
-                  R$ {totalRevenue.toFixed(2)}
-                </p>
-              </div>
-              <div className="bg-purple-100 p-3 rounded-lg">
-                <DollarSign className="w-6 h-6 text-purple-600" />
+        {/* Estatísticas e gráficos - só para quem vê os relatórios */}
+        {canViewReports && (
+          <>
+            {/* Cards de Estatísticas */}
+            <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-4 gap-6 mb-8">
+              <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-purple-500">
+                <div className="flex items-center justify-between">
+                  <div>
+                    <p className="text-sm text-gray-600 mb-1">Receita Total</p>
+                    <p className="text-2xl font-bold text-gray-900">
+                      R$ {totalRevenue.toFixed(2)}
+                    </p>
+                  </div>
+                  <div className="bg-purple-100 p-3 rounded-lg">
+                    <DollarSign className="w-6 h-6 text-purple-600" />
+                  </div>
+                </div>
               </div>
-            </div>
-          </div>
 
-          <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-blue-500">
-            <div className="flex items-center justify-between">
-              <div>
-                <p className="text-sm text-gray-600 mb-1">
-                  Total de Agendamentos
-                </p>
-                <p className="text-2xl font-bold text-gray-900">
-                  {totalAppointments}
-                </p>
-              </div>
-              <div className="bg-blue-100 p-3 rounded-lg">
-                <Calendar className="w-6 h-6 text-blue-600" />
+              <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-blue-500">
+                <div className="flex items-center justify-between">
+                  <div>
+                    <p className="text-sm text-gray-600 mb-1">
+                      Total de Agendamentos
+                    </p>
+                    <p className="text-2xl font-bold text-gray-900">
+                      {totalAppointments}
+                    </p>
+                  </div>
+                  <div className="bg-blue-100 p-3 rounded-lg">
+                    <Calendar className="w-6 h-6 text-blue-600" />
+                  </div>
+                </div>
               </div>
-            </div>
-          </div>
 
-          <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-yellow-500">
-            <div className="flex items-center justify-between">
-              <div>
-                <p className="text-sm text-gray-600 mb-1">Pendentes</p>
-                <p className="text-2xl font-bold text-gray-900">
-                  {pendingAppointments}
-                </p>
+              <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-yellow-500">
+                <div className="flex items-center justify-between">
+                  <div>
+                    <p className="text-sm text-gray-600 mb-1">Pendentes</p>
+                    <p className="text-2xl font-bold text-gray-900">
+                      {pendingAppointments}
+                    </p>
+                  </div>
+                  <div className="bg-yellow-100 p-3 rounded-lg">
+                    <Users className="w-6 h-6 text-yellow-600" />
+                  </div>
+                </div>
               </div>
-              <div className="bg-yellow-100 p-3 rounded-lg">
-                <Users className="w-6 h-6 text-yellow-600" />
+
+              <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-green-500">
+                <div className="flex items-center justify-between">
+                  <div>
+                    <p className="text-sm text-gray-600 mb-1">
+                      Taxa de Conclusão
+                    </p>
+                    <p className="text-2xl font-bold text-gray-900">
+                      {completionRate.toFixed(0)}%
+                    </p>
+                  </div>
+                  <div className="bg-green-100 p-3 rounded-lg">
+                    <CheckCircle className="w-6 h-6 text-green-600" />
+                  </div>
+                </div>
               </div>
             </div>
-          </div>
 
-          <div className="bg-white rounded-xl shadow-sm p-6 border-l-4 border-green-500">
-            <div className="flex items-center justify-between">
-              <div>
-                <p className="text-sm text-gray-600 mb-1">Duração Média</p>
-                <p className="text-2xl font-bold text-gray-900">
-                  {avgDuration.toFixed(0)} min
-                </p>
+            {/* Gráficos */}
+            <div className="grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8">
+              <div className="bg-white rounded-xl shadow-sm p-6">
+                <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center gap-2">
+                  <TrendingUp className="w-5 h-5 text-purple-600" />
+                  Receita Semanal
+                </h3>
+                <ResponsiveContainer width="100%" height={250}>
+                  <LineChart data={weeklyStats}>
+                    <CartesianGrid strokeDasharray="3 3" />
+                    <XAxis dataKey="week" />
+                    <YAxis />
+                    <Tooltip
+                      formatter={(value: number) => `R$ ${value.toFixed(2)}`}
+                    />
+                    <Legend />
+                    <Line
+                      type="monotone"
+                      dataKey="revenue"
+                      stroke="#9333ea"
+                      strokeWidth={2}
+                      name="Receita"
+                    />
+                  </LineChart>
+                </ResponsiveContainer>
               </div>
-              <div className="bg-green-100 p-3 rounded-lg">
-                <Clock className="w-6 h-6 text-green-600" />
+
+              <div className="bg-white rounded-xl shadow-sm p-6">
+                <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center gap-2">
+                  <Calendar className="w-5 h-5 text-blue-600" />
+                  Agendamentos por Semana
+                </h3>
+                <ResponsiveContainer width="100%" height={250}>
+                  <BarChart data={weeklyStats}>
+                    <CartesianGrid strokeDasharray="3 3" />
+                    <XAxis dataKey="week" />
+                    <YAxis />
+                    <Tooltip />
+                    <Legend />
+                    <Bar
+                      dataKey="appointments"
+                      fill="#3b82f6"
+                      name="Agendamentos"
+                    />
+                  </BarChart>
+                </ResponsiveContainer>
               </div>
             </div>
-          </div>
-        </div>
-
-        {/* Gráficos */}
-        <div className="grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8">
-          <div className="bg-white rounded-xl shadow-sm p-6">
-            <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center gap-2">
-              <TrendingUp className="w-5 h-5 text-purple-600" />
-              Receita Semanal
-            </h3>
-            <ResponsiveContainer width="100%" height={250}>
-              <LineChart data={weeklyStats}>
-                <CartesianGrid strokeDasharray="3 3" />
-                <XAxis dataKey="week" />
-                <YAxis />
-                <Tooltip
-                  formatter={(value: number) => `R$ ${value.toFixed(2)}`}
-                />
-                <Legend />
-                <Line
-                  type="monotone"
-                  dataKey="revenue"
-                  stroke="#9333ea"
-                  strokeWidth={2}
-                  name="Receita"
-                />
-              </LineChart>
-            </ResponsiveContainer>
-          </div>
-
-          <div className="bg-white rounded-xl shadow-sm p-6">
-            <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center gap-2">
-              <Calendar className="w-5 h-5 text-blue-600" />
-              Agendamentos por Semana
-            </h3>
-            <ResponsiveContainer width="100%" height={250}>
-              <BarChart data={weeklyStats}>
-                <CartesianGrid strokeDasharray="3 3" />
-                <XAxis dataKey="week" />
-                <YAxis />
-                <Tooltip />
-                <Legend />
-                <Bar
-                  dataKey="appointments"
-                  fill="#3b82f6"
-                  name="Agendamentos"
-                />
-              </BarChart>
-            </ResponsiveContainer>
-          </div>
-        </div>
+          </>
+        )}
 
         {/* Filtro de Período */}
         <AppointmentFilter
           selectedPeriod={filterPeriod}
           customStartDate={customStartDate}
           customEndDate={customEndDate}
-          totalCount={appointments.length}
-          filteredCount={periodFilteredAppointments.length}
+          totalCount={totalCount}
+          filteredCount={appointments.length}
           onPeriodChange={handlePeriodChange}
           onCustomDateChange={handleCustomDateChange}
         />
 
         {/* Filtros */}
-        <div className="bg-white rounded-xl shadow-sm p-4 mb-6">
+        <div className="bg-white rounded-xl shadow-sm p-4 mb-6 space-y-4">
           <div className="flex gap-2 flex-wrap">
-            <button
-              onClick={() => setFilter("all")}
-              className={`px-4 py-2 rounded-lg transition ${
-                filter === "all"
-                  ? "bg-purple-600 text-white"
-                  : "bg-gray-100 text-gray-700 hover:bg-gray-200"
-              }`}
-            >
-              Todos ({periodFilteredAppointments.length})
-            </button>
-            <button
-              onClick={() => setFilter("PENDING")}
-              className={`px-4 py-2 rounded-lg transition ${
-                filter === "PENDING"
-                  ? "bg-yellow-600 text-white"
-                  : "bg-gray-100 text-gray-700 hover:bg-gray-200"
-              }`}
-            >
-              Pendentes (
-              {
-                periodFilteredAppointments.filter((a) => a.status === "PENDING")
-                  .length
-              }
-              )
-            </button>
-            <button
-              onClick={() => setFilter("CONFIRMED")}
-              className={`px-4 py-2 rounded-lg transition ${
-                filter === "CONFIRMED"
-                  ? "bg-blue-600 text-white"
-                  : "bg-gray-100 text-gray-700 hover:bg-gray-200"
-              }`}
-            >
-              Confirmados (
-              {
-                periodFilteredAppointments.filter(
-                  (a) => a.status === "CONFIRMED"
-                ).length
-              }
-              )
-            </button>
-            <button
-              onClick={() => setFilter("DONE")}
-              className={`px-4 py-2 rounded-lg transition ${
-                filter === "DONE"
-                  ? "bg-green-600 text-white"
-                  : "bg-gray-100 text-gray-700 hover:bg-gray-200"
-              }`}
+            {statusButtons.map((button) => (
+              <button
+                key={button.value}
+                onClick={() => setFilter(button.value)}
+                className={`px-4 py-2 rounded-lg transition ${
+                  filter === button.value
+                    ? `${button.active} text-white`
+                    : "bg-gray-100 text-gray-700 hover:bg-gray-200"
+                }`}
+              >
+                {button.label}
+                {filter === button.value && ` (${totalCount})`}
+              </button>
+            ))}
+          </div>
+
+          <div className="grid grid-cols-1 md:grid-cols-3 gap-4">
+            <div className="relative">
+              <Search className="w-4 h-4 text-gray-400 absolute left-3 top-3" />
+              <input
+                type="text"
+                value={search}
+                onChange={(e) => setSearch(e.target.value)}
+                placeholder="Nome, email ou telefone do cliente"
+                className="w-full pl-9 pr-4 py-2 border border-gray-300 rounded-lg focus:ring-2 focus:ring-purple-600"
+              />
+            </div>
+            <select
+              value={serviceFilter}
+              onChange={(e) => setServiceFilter(e.target.value)}
+              className="w-full px-4 py-2 border border-gray-300 rounded-lg focus:ring-2 focus:ring-purple-600"
             >
-              Concluídos (
-              {
-                periodFilteredAppointments.filter((a) => a.status === "DONE")
-                  .length
-              }
-              )
-            </button>
-            <button
-              onClick={() => setFilter("CANCELED")}
-              className={`px-4 py-2 rounded-lg transition ${
-                filter === "CANCELED"
-                  ? "bg-red-600 text-white"
-                  : "bg-gray-100 text-gray-700 hover:bg-gray-200"
-              }`}
+              <option value="">Todos os serviços</option>
+              {availableServices.map((service) => (
+                <option key={service.id} value={String(service.id)}>
+                  {service.name}
+                </option>
+              ))}
+            </select>
+            <select
+              value={sort}
+              onChange={(e) => setSort(e.target.value as SortOrder)}
+              className="w-full px-4 py-2 border border-gray-300 rounded-lg focus:ring-2 focus:ring-purple-600"
             >
-              Cancelados (
-              {
-                periodFilteredAppointments.filter(
-                  (a) => a.status === "CANCELED"
-                ).length
-              }
-              )
-            </button>
+              <option value="-date">Data mais recente</option>
+              <option value="date">Data mais antiga</option>
+              <option value="-created_at">Criados recentemente</option>
+              <option value="created_at">Criados há mais tempo</option>
+            </select>
           </div>
         </div>
 
@@ -546,17 +583,19 @@ const AdminDashboardPage = () => {
                 </tr>
               </thead>
               <tbody className="divide-y divide-gray-200">
-                {filteredAppointments.length === 0 ? (
+                {appointments.length === 0 ? (
                   <tr>
                     <td
                       colSpan={6}
                       className="px-6 py-8 text-center text-gray-500"
                     >
-                      Nenhum agendamento encontrado
+                      {loading
+                        ? "Carregando agendamentos..."
+                        : "Nenhum agendamento encontrado"}
                     </td>
                   </tr>
                 ) : (
-                  filteredAppointments.map((appointment) => (
+                  appointments.map((appointment) => (
                     <tr key={appointment.id} className="hover:bg-gray-50">
                       <td className="px-6 py-4 whitespace-nowrap">
                         <div className="text-sm font-medium text-gray-900">
@@ -585,12 +624,14 @@ const AdminDashboardPage = () => {
                           .toFixed(2)}
                       </td>
                       <td className="px-6 py-4 whitespace-nowrap text-sm">
-                        <button
-                          onClick={() => handleEdit(appointment)}
-                          className="text-purple-600 hover:text-purple-900 font-medium"
-                        >
-                          Editar
-                        </button>
+                        {canManageAppointments && (
+                          <button
+                            onClick={() => handleEdit(appointment)}
+                            className="text-purple-600 hover:text-purple-900 font-medium"
+                          >
+                            Editar
+                          </button>
+                        )}
                       </td>
                     </tr>
                   ))
@@ -598,6 +639,17 @@ const AdminDashboardPage = () => {
               </tbody>
             </table>
           </div>
+          {nextCursor && (
+            <div className="border-t px-6 py-4 text-center">
+              <button
+                onClick={() => fetchAppointments(nextCursor)}
+                disabled={loading}
+                className="px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 disabled:text-gray-400"
+              >
+                {loading ? "Carregando..." : "Carregar mais"}
+              </button>
+            </div>
+          )}
         </div>
 
         {/* Modal de Edição */}
@@ -11,6 +11,8 @@ import {
   filterAppointmentsByPeriod,
   getDefaultCustomDates,
 } from "../utils/filterHelpers";
+import { fetchPage } from "../utils/pagination";
+import { authFetch, hasPermission } from "../utils/session";
 
 // Tipos baseados na API
 interface Service {
@@ -36,6 +38,9 @@ interface Appointment {
 }
 
 const API_BASE = "http://localhost:8080/api";
+const PAGE_SIZE = "50";
+// O catálogo de serviços de um salão cabe em uma página
+const SERVICES_PAGE_SIZE = "200";
 
 const formatDateToISO = (dateString: string) => {
   const date = new Date(dateString);
@@ -43,12 +48,14 @@ const formatDateToISO = (dateString: string) => {
 };
 
 const AgendarPage = () => {
-  const isAdmin = localStorage.getItem("role") === "admin";
-  if (isAdmin) {
+  // A equipe que acompanha a agenda de todos os clientes usa o painel
+  if (hasPermission("appointments:view")) {
     window.location.href = "/admin/dashboard";
   }
 
   const [appointments, setAppointments] = useState<Appointment[]>([]);
+  const [nextCursor, setNextCursor] = useState("");
+  const [loadingMore, setLoadingMore] = useState(false);
   const [availableServices, setAvailableServices] = useState<Service[]>([]);
   const [showNewAppointment, setShowNewAppointment] = useState(false);
   const [editingAppointment, setEditingAppointment] = useState<
@@ -89,27 +96,29 @@ const AgendarPage = () => {
     );
   }, [appointments, filterPeriod, customStartDate, customEndDate]);
 
-  const fetchAppointments = async () => {
+  // Sem cursor, busca a primeira página; com o next_cursor, acrescenta a
+  // página seguinte à lista
+  const fetchAppointments = async (cursor = "") => {
     const token = localStorage.getItem("token");
     if (!token) return;
 
-    setLoading(true);
+    const setBusy = cursor ? setLoadingMore : setLoading;
+    setBusy(true);
     setError("");
 
     try {
-      const response = await fetch(`${API_BASE}/appointments`, {
-        headers: {
-          Authorization: `Bearer ${token}`,
-          "Content-Type": "application/json",
-        },
-      });
+      const page = await fetchPage<Appointment>(
+        `${API_BASE}/appointments`,
+        { limit: PAGE_SIZE },
+        cursor
+      );
 
-      if (response.ok) {
-        let data = await response.json();
-        data = data.filter(
+      if (page) {
+        const active = page.items.filter(
           (ap: Appointment): boolean => ap.status !== "CANCELED"
         );
-        setAppointments(data || []);
+        setAppointments((prev) => (cursor ? [...prev, ...active] : active));
+        setNextCursor(page.next_cursor || "");
       } else {
         setError("Erro ao carregar agendamentos");
       }
@@ -117,21 +126,18 @@ const AgendarPage = () => {
       setError("Erro de conexão com o servidor");
       console.error(err);
     } finally {
-      setLoading(false);
+      setBusy(false);
     }
   };
 
   const fetchServices = async () => {
     try {
-      const response = await fetch(`${API_BASE}/services`, {
-        headers: {
-          "Content-Type": "application/json",
-        },
+      const page = await fetchPage<Service>(`${API_BASE}/services`, {
+        limit: SERVICES_PAGE_SIZE,
       });
 
-      if (response.ok) {
-        const data = await response.json();
-        setAvailableServices(data || []);
+      if (page) {
+        setAvailableServices(page.items);
       } else {
         console.error("Erro ao carregar serviços");
       }
@@ -162,10 +168,9 @@ const AgendarPage = () => {
 
       const method = appointmentId ? "PUT" : "POST";
 
-      const response = await fetch(url, {
+      const response = await authFetch(url, {
         method,
         headers: {
-          Authorization: `Bearer ${token}`,
           "Content-Type": "application/json",
         },
         body: JSON.stringify({
@@ -220,12 +225,11 @@ const AgendarPage = () => {
     setError("");
 
     try {
-      const response = await fetch(
+      const response = await authFetch(
         `${API_BASE}/appointments/${cancelingAppointment.id}/cancel`,
         {
           method: "POST",
           headers: {
-            Authorization: `Bearer ${token}`,
             "Content-Type": "application/json",
           },
         }
@@ -279,12 +283,11 @@ const AgendarPage = () => {
     setError("");
 
     try {
-      const response = await fetch(
+      const response = await authFetch(
         `${API_BASE}/appointments/${suggestion.appointment.id}/merge`,
         {
           method: "POST",
           headers: {
-            Authorization: `Bearer ${token}`,
             "Content-Type": "application/json",
           },
           body: JSON.stringify({
@@ -321,10 +324,9 @@ const AgendarPage = () => {
     setError("");
 
     try {
-      const response = await fetch(`${API_BASE}/appointments`, {
+      const response = await authFetch(`${API_BASE}/appointments`, {
         method: "POST",
         headers: {
-          Authorization: `Bearer ${token}`,
           "Content-Type": "application/json",
         },
         body: JSON.stringify({
@@ -413,6 +415,17 @@ const AgendarPage = () => {
           onEdit={handleEdit}
           onCancel={handleCancelClick}
         />
+        {nextCursor && !loading && (
+          <div className="mt-6 text-center">
+            <button
+              onClick={() => fetchAppointments(nextCursor)}
+              disabled={loadingMore}
+              className="px-4 py-2 bg-white border border-gray-300 text-gray-700 rounded-lg hover:bg-gray-50 disabled:text-gray-400"
+            >
+              {loadingMore ? "Carregando..." : "Carregar mais"}
+            </button>
+          </div>
+        )}
 
         {/* Modal Novo/Editar Agendamento */}
         {showNewAppointment && (
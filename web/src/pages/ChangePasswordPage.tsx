@@ -0,0 +1,166 @@
+import { useState } from "react";
+import { Navigate } from "react-router-dom";
+import { authFetch, saveSession } from "../utils/session";
+
+const API_BASE = "http://localhost:8080/api";
+
+/**
+ * Troca de senha obrigatória. Contas criadas com uma senha conhecida, como o
+ * admin inicial, só podem usar o sistema depois de escolher uma nova senha.
+ */
+export default function ChangePasswordPage() {
+  const [senhaAtual, setSenhaAtual] = useState("");
+  const [novaSenha, setNovaSenha] = useState("");
+  const [confirmarSenha, setConfirmarSenha] = useState("");
+  const [carregando, setCarregando] = useState(false);
+  const [erro, setErro] = useState("");
+
+  if (!localStorage.getItem("token")) {
+    return <Navigate to="/login" replace />;
+  }
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setErro("");
+
+    if (novaSenha !== confirmarSenha) {
+      setErro("As senhas não coincidem");
+      return;
+    }
+
+    if (novaSenha.length < 6) {
+      setErro("A senha deve ter no mínimo 6 caracteres");
+      return;
+    }
+
+    setCarregando(true);
+
+    try {
+      const response = await authFetch(`${API_BASE}/me/password`, {
+        method: "POST",
+        headers: {
+          "Content-Type": "application/json",
+        },
+        body: JSON.stringify({
+          current_password: senhaAtual,
+          new_password: novaSenha,
+        }),
+      });
+
+      const data = await response.json();
+
+      if (response.ok) {
+        // A troca devolve uma sessão nova, sem a restrição
+        saveSession(data);
+        const user = JSON.parse(localStorage.getItem("user") || "{}");
+        localStorage.setItem(
+          "user",
+          JSON.stringify({ ...user, must_change_password: false })
+        );
+        window.location.href = "/";
+      } else {
+        setErro(data.error || "Não foi possível trocar a senha.");
+      }
+    } catch (error) {
+      console.error("Erro ao trocar a senha:", error);
+      setErro("Erro ao conectar com o servidor. Verifique sua conexão.");
+    } finally {
+      setCarregando(false);
+    }
+  };
+
+  const inputClassName =
+    "w-full px-4 py-3 border border-gray-300 rounded-lg focus:ring-2 focus:ring-indigo-500 focus:border-transparent transition";
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
+      <div className="bg-white rounded-2xl shadow-xl w-full max-w-md p-8">
+        {/* Cabeçalho */}
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold text-gray-900 mb-2">
+            Troque sua senha
+          </h1>
+          <p className="text-gray-600">
+            Por segurança, escolha uma nova senha antes de continuar
+          </p>
+        </div>
+
+        <form onSubmit={handleSubmit} className="space-y-5">
+          {/* Mensagem de Erro */}
+          {erro && (
+            <div className="p-3 bg-red-50 border border-red-200 rounded-lg">
+              <p className="text-red-800 text-sm">{erro}</p>
+            </div>
+          )}
+
+          {/* Campo Senha Atual */}
+          <div>
+            <label
+              htmlFor="senhaAtual"
+              className="block text-sm font-medium text-gray-700 mb-2"
+            >
+              Senha atual
+            </label>
+            <input
+              id="senhaAtual"
+              type="password"
+              value={senhaAtual}
+              onChange={(e) => setSenhaAtual(e.target.value)}
+              required
+              className={inputClassName}
+              disabled={carregando}
+            />
+          </div>
+
+          {/* Campo Nova Senha */}
+          <div>
+            <label
+              htmlFor="novaSenha"
+              className="block text-sm font-medium text-gray-700 mb-2"
+            >
+              Nova senha
+            </label>
+            <input
+              id="novaSenha"
+              type="password"
+              value={novaSenha}
+              onChange={(e) => setNovaSenha(e.target.value)}
+              required
+              className={inputClassName}
+              placeholder="Mínimo 6 caracteres"
+              disabled={carregando}
+            />
+          </div>
+
+          {/* Campo Confirmar Senha */}
+          <div>
+            <label
+              htmlFor="confirmarSenha"
+              className="block text-sm font-medium text-gray-700 mb-2"
+            >
+              Confirmar nova senha
+            </label>
+            <input
+              id="confirmarSenha"
+              type="password"
+              value={confirmarSenha}
+              onChange={(e) => setConfirmarSenha(e.target.value)}
+              required
+              className={inputClassName}
+              disabled={carregando}
+            />
+          </div>
+
+          {/* Botão de Submit */}
+          <button
+            type="submit"
+            disabled={carregando}
+            className="w-full bg-indigo-600 hover:bg-indigo-700 text-white font-semibold py-3 px-4 rounded-lg transition duration-200 disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {carregando ? "Salvando..." : "Trocar senha"}
+          </button>
+        </form>
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,88 @@
+import { useEffect, useRef, useState } from "react";
+import { useSearchParams } from "react-router-dom";
+import { homePath, saveSession } from "../utils/session";
+
+const API_BASE = "http://localhost:8080/api";
+
+// Mensagens para os erros com que o servidor volta do provedor
+const mensagensDeErro: Record<string, string> = {
+  provider_error: "O provedor não concluiu o login.",
+  invalid_state: "O login expirou ou foi iniciado em outro navegador.",
+  unknown_provider: "Provedor de login desconhecido.",
+  email_not_verified: "O provedor não confirmou o seu email.",
+  account_inactive: "Sua conta está inativa.",
+  login_failed: "Não foi possível entrar com o provedor.",
+};
+
+/**
+ * Volta do login pelo provedor de identidade. O servidor redireciona para cá
+ * com um código de uso único, trocado aqui pelos tokens da sessão.
+ */
+export default function LoginCallbackPage() {
+  const [searchParams] = useSearchParams();
+  const [erro, setErro] = useState("");
+  // O código só pode ser trocado uma vez, mesmo que o efeito rode de novo
+  const trocado = useRef(false);
+
+  useEffect(() => {
+    if (trocado.current) return;
+    trocado.current = true;
+
+    const codigo = searchParams.get("code");
+    const erroDoProvedor = searchParams.get("error");
+    if (erroDoProvedor || !codigo) {
+      setErro(
+        mensagensDeErro[erroDoProvedor || ""] || mensagensDeErro.login_failed
+      );
+      return;
+    }
+
+    const trocarCodigo = async () => {
+      try {
+        const response = await fetch(`${API_BASE}/auth/oidc/exchange`, {
+          method: "POST",
+          headers: {
+            "Content-Type": "application/json",
+          },
+          body: JSON.stringify({ code: codigo }),
+        });
+
+        const data = await response.json();
+
+        if (response.ok) {
+          saveSession(data);
+          localStorage.setItem("user", JSON.stringify(data.user));
+          window.location.replace(homePath(data.user));
+        } else {
+          setErro(data.error || mensagensDeErro.login_failed);
+        }
+      } catch (error) {
+        console.error("Erro ao concluir o login:", error);
+        setErro("Erro ao conectar com o servidor. Verifique sua conexão.");
+      }
+    };
+    trocarCodigo();
+  }, [searchParams]);
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
+      <div className="bg-white rounded-2xl shadow-xl w-full max-w-md p-8 text-center">
+        {erro ? (
+          <>
+            <div className="mb-6 p-3 bg-red-50 border border-red-200 rounded-lg">
+              <p className="text-red-800 text-sm">{erro}</p>
+            </div>
+            <a
+              href="/login"
+              className="text-indigo-600 hover:text-indigo-700 font-medium"
+            >
+              Voltar para o login
+            </a>
+          </>
+        ) : (
+          <p className="text-gray-600">Entrando...</p>
+        )}
+      </div>
+    </div>
+  );
+}
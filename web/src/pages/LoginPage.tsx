@@ -1,9 +1,23 @@
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import LoginForm from "../components/LoginForm";
+import { homePath, saveSession } from "../utils/session";
+
+const API_BASE = "http://localhost:8080/api";
 
 export default function LoginPage() {
   const [carregando, setCarregando] = useState(false);
   const [erro, setErro] = useState("");
+  const [provedores, setProvedores] = useState<string[]>([]);
+
+  // Provedores de identidade configurados no servidor, como o Google
+  useEffect(() => {
+    fetch(`${API_BASE}/auth/oidc/providers`)
+      .then((response) => (response.ok ? response.json() : { providers: [] }))
+      .then((data) => setProvedores(data.providers || []))
+      .catch((error) =>
+        console.error("Erro ao carregar os provedores de login:", error)
+      );
+  }, []);
 
   const handleLogin = async (
     email: string,
@@ -34,8 +48,8 @@ export default function LoginPage() {
         console.log("Login realizado com sucesso!");
         console.log("Dados do usuário:", data.user);
 
-        // Salva o token JWT e os dados do usuário no localStorage
-        localStorage.setItem("token", data.token);
+        // Salva os tokens da sessão e os dados do usuário no localStorage
+        saveSession(data);
         localStorage.setItem("user", JSON.stringify(data.user));
 
         // Se o usuário marcou "Lembrar-me", salva o email também
@@ -43,8 +57,8 @@ export default function LoginPage() {
           localStorage.setItem("email", email);
         }
 
-        // Redireciona para o dashboard
-        window.location.href = "/agendar";
+        // Redireciona para o dashboard, ou para a troca de senha obrigatória
+        window.location.href = homePath(data.user);
       } else {
         // Erro no login (credenciais inválidas, etc)
         setErro(data.message || "Email ou senha incorretos");
@@ -72,6 +86,29 @@ export default function LoginPage() {
         {/* Formulário de Login */}
         <LoginForm onSubmit={handleLogin} loading={carregando} error={erro} />
 
+        {/* Login pelos provedores de identidade - o servidor conduz o fluxo e volta para /login/callback */}
+        {provedores.length > 0 && (
+          <div className="mt-6 space-y-3">
+            <div className="flex items-center gap-3 text-sm text-gray-500">
+              <div className="flex-1 border-t border-gray-200" />
+              ou
+              <div className="flex-1 border-t border-gray-200" />
+            </div>
+            {provedores.map((provedor) => (
+              <a
+                key={provedor}
+                href={`${API_BASE}/auth/oidc/${encodeURIComponent(
+                  provedor
+                )}/login`}
+                className="block w-full text-center border border-gray-300 hover:bg-gray-50 text-gray-700 font-semibold py-3 px-4 rounded-lg transition duration-200"
+              >
+                Entrar com{" "}
+                {provedor.charAt(0).toUpperCase() + provedor.slice(1)}
+              </a>
+            ))}
+          </div>
+        )}
+
         {/* Link para criar conta */}
         <div className="mt-6 text-center">
           <p className="text-sm text-gray-600">
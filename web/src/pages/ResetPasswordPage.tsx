@@ -0,0 +1,160 @@
+import { useState } from "react";
+import { useSearchParams } from "react-router-dom";
+
+const API_BASE = "http://localhost:8080/api";
+
+/**
+ * Nova senha pelo link enviado por email. Trocar a senha encerra todas as
+ * sessões do usuário, que entra de novo com a senha nova.
+ */
+export default function ResetPasswordPage() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get("token") || "";
+  const [senha, setSenha] = useState("");
+  const [confirmarSenha, setConfirmarSenha] = useState("");
+  const [carregando, setCarregando] = useState(false);
+  const [erro, setErro] = useState("");
+  const [sucesso, setSucesso] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setErro("");
+
+    if (senha !== confirmarSenha) {
+      setErro("As senhas não coincidem");
+      return;
+    }
+
+    if (senha.length < 6) {
+      setErro("A senha deve ter no mínimo 6 caracteres");
+      return;
+    }
+
+    setCarregando(true);
+
+    try {
+      const response = await fetch(`${API_BASE}/auth/reset-password`, {
+        method: "POST",
+        headers: {
+          "Content-Type": "application/json",
+        },
+        body: JSON.stringify({ token, password: senha }),
+      });
+
+      if (response.ok) {
+        setSucesso(true);
+
+        // Aguarda 2 segundos e redireciona para o login
+        setTimeout(() => {
+          window.location.href = "/login";
+        }, 2000);
+      } else {
+        const data = await response.json();
+        setErro(data.error || "Não foi possível redefinir a senha.");
+      }
+    } catch (error) {
+      console.error("Erro ao redefinir a senha:", error);
+      setErro("Erro ao conectar com o servidor. Verifique sua conexão.");
+    } finally {
+      setCarregando(false);
+    }
+  };
+
+  const inputClassName =
+    "w-full px-4 py-3 border border-gray-300 rounded-lg focus:ring-2 focus:ring-indigo-500 focus:border-transparent transition";
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
+      <div className="bg-white rounded-2xl shadow-xl w-full max-w-md p-8">
+        {/* Cabeçalho */}
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold text-gray-900 mb-2">
+            Redefinir senha
+          </h1>
+          <p className="text-gray-600">Escolha uma nova senha para sua conta</p>
+        </div>
+
+        {!token ? (
+          <div className="p-3 bg-red-50 border border-red-200 rounded-lg">
+            <p className="text-red-800 text-sm">
+              Link inválido. Use o link enviado para o seu email.
+            </p>
+          </div>
+        ) : sucesso ? (
+          <div className="p-3 bg-green-50 border border-green-200 rounded-lg">
+            <p className="text-green-800 text-sm">
+              Senha redefinida! Redirecionando para o login...
+            </p>
+          </div>
+        ) : (
+          <form onSubmit={handleSubmit} className="space-y-5">
+            {/* Mensagem de Erro */}
+            {erro && (
+              <div className="p-3 bg-red-50 border border-red-200 rounded-lg">
+                <p className="text-red-800 text-sm">{erro}</p>
+              </div>
+            )}
+
+            {/* Campo Nova Senha */}
+            <div>
+              <label
+                htmlFor="senha"
+                className="block text-sm font-medium text-gray-700 mb-2"
+              >
+                Nova senha
+              </label>
+              <input
+                id="senha"
+                type="password"
+                value={senha}
+                onChange={(e) => setSenha(e.target.value)}
+                required
+                className={inputClassName}
+                placeholder="Mínimo 6 caracteres"
+                disabled={carregando}
+              />
+            </div>
+
+            {/* Campo Confirmar Senha */}
+            <div>
+              <label
+                htmlFor="confirmarSenha"
+                className="block text-sm font-medium text-gray-700 mb-2"
+              >
+                Confirmar nova senha
+              </label>
+              <input
+                id="confirmarSenha"
+                type="password"
+                value={confirmarSenha}
+                onChange={(e) => setConfirmarSenha(e.target.value)}
+                required
+                className={inputClassName}
+                disabled={carregando}
+              />
+            </div>
+
+            {/* Botão de Submit */}
+            <button
+              type="submit"
+              disabled={carregando}
+              className="w-full bg-indigo-600 hover:bg-indigo-700 text-white font-semibold py-3 px-4 rounded-lg transition duration-200 disabled:opacity-50 disabled:cursor-not-allowed"
+            >
+              {carregando ? "Salvando..." : "Redefinir senha"}
+            </button>
+          </form>
+        )}
+
+        {/* Link para o login */}
+        <div className="mt-6 text-center">
+          <a
+            href="/login"
+            className="text-sm text-indigo-600 hover:text-indigo-700 font-medium"
+          >
+            Voltar para o login
+          </a>
+        </div>
+      </div>
+    </div>
+  );
+}
@@ -0,0 +1,93 @@
+import { useEffect, useRef, useState } from "react";
+import { useSearchParams } from "react-router-dom";
+
+const API_BASE = "http://localhost:8080/api";
+
+type Situacao = "verificando" | "verificado" | "erro";
+
+/**
+ * Confirmação do email pelo link enviado ao usuário. O token é enviado assim
+ * que a página abre.
+ */
+export default function VerifyEmailPage() {
+  const [searchParams] = useSearchParams();
+  const [situacao, setSituacao] = useState<Situacao>("verificando");
+  const [erro, setErro] = useState("");
+  // O token só pode ser usado uma vez, mesmo que o efeito rode de novo
+  const enviado = useRef(false);
+
+  useEffect(() => {
+    if (enviado.current) return;
+    enviado.current = true;
+
+    const token = searchParams.get("token");
+    if (!token) {
+      setErro("Link inválido. Use o link enviado para o seu email.");
+      setSituacao("erro");
+      return;
+    }
+
+    const verificar = async () => {
+      try {
+        const response = await fetch(`${API_BASE}/auth/verify-email`, {
+          method: "POST",
+          headers: {
+            "Content-Type": "application/json",
+          },
+          body: JSON.stringify({ token }),
+        });
+
+        // Um email já confirmado também está confirmado
+        if (response.ok || response.status === 409) {
+          setSituacao("verificado");
+        } else {
+          const data = await response.json();
+          setErro(data.error || "Não foi possível confirmar o email.");
+          setSituacao("erro");
+        }
+      } catch (error) {
+        console.error("Erro ao confirmar o email:", error);
+        setErro("Erro ao conectar com o servidor. Verifique sua conexão.");
+        setSituacao("erro");
+      }
+    };
+    verificar();
+  }, [searchParams]);
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
+      <div className="bg-white rounded-2xl shadow-xl w-full max-w-md p-8 text-center">
+        <h1 className="text-3xl font-bold text-gray-900 mb-6">
+          Confirmação de email
+        </h1>
+
+        {situacao === "verificando" && (
+          <p className="text-gray-600">Confirmando seu email...</p>
+        )}
+
+        {situacao === "verificado" && (
+          <div className="p-3 bg-green-50 border border-green-200 rounded-lg">
+            <p className="text-green-800 text-sm">Seu email foi confirmado!</p>
+          </div>
+        )}
+
+        {situacao === "erro" && (
+          <div className="p-3 bg-red-50 border border-red-200 rounded-lg">
+            <p className="text-red-800 text-sm">{erro}</p>
+          </div>
+        )}
+
+        {situacao !== "verificando" && (
+          <div className="mt-6">
+            <a
+              href="/"
+              className="text-sm text-indigo-600 hover:text-indigo-700 font-medium"
+            >
+              Continuar
+            </a>
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
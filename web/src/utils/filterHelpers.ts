@@ -102,20 +102,61 @@ export const filterAppointmentsByPeriod = (
   }
 };
 
+export const formatDay = (date: Date) => {
+  const year = date.getFullYear();
+  const month = String(date.getMonth() + 1).padStart(2, "0");
+  const day = String(date.getDate()).padStart(2, "0");
+  return `${year}-${month}-${day}`;
+};
+
+/**
+ * Converte o período escolhido no primeiro e no último dia (YYYY-MM-DD)
+ * enviados à API como start_date e end_date. O período "all" não tem
+ * limites.
+ */
+export const getPeriodRange = (
+  period: FilterPeriod,
+  customStartDate?: string,
+  customEndDate?: string
+): { start: string; end: string } => {
+  const now = new Date();
+
+  switch (period) {
+    case "today":
+      return { start: formatDay(now), end: formatDay(now) };
+
+    case "week": {
+      const startOfWeek = new Date(now);
+      startOfWeek.setDate(now.getDate() - now.getDay()); // Domingo como início da semana
+      const endOfWeek = new Date(startOfWeek);
+      endOfWeek.setDate(startOfWeek.getDate() + 6);
+      return { start: formatDay(startOfWeek), end: formatDay(endOfWeek) };
+    }
+
+    case "month": {
+      const startOfMonth = new Date(now.getFullYear(), now.getMonth(), 1);
+      const endOfMonth = new Date(now.getFullYear(), now.getMonth() + 1, 0);
+      return { start: formatDay(startOfMonth), end: formatDay(endOfMonth) };
+    }
+
+    case "custom":
+      if (!customStartDate || !customEndDate) {
+        return { start: "", end: "" };
+      }
+      return { start: customStartDate, end: customEndDate };
+
+    default:
+      return { start: "", end: "" };
+  }
+};
+
 export const getDefaultCustomDates = () => {
   const today = new Date();
   const startDate = new Date(today);
   startDate.setDate(today.getDate() - 7); // 7 dias atrás
 
-  const formatDate = (date: Date) => {
-    const year = date.getFullYear();
-    const month = String(date.getMonth() + 1).padStart(2, "0");
-    const day = String(date.getDate()).padStart(2, "0");
-    return `${year}-${month}-${day}`;
-  };
-
   return {
-    start: formatDate(startDate),
-    end: formatDate(today),
+    start: formatDay(startDate),
+    end: formatDay(today),
   };
 };
\ No newline at end of file
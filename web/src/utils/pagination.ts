@@ -0,0 +1,37 @@
+import { authFetch } from "./session";
+
+export interface Page<T> {
+  items: T[];
+  next_cursor?: string;
+  // Total da listagem, presente quando pedido com total=true
+  total?: number;
+}
+
+/**
+ * Busca uma página de uma listagem paginada. O cursor é o next_cursor da
+ * página anterior, vazio para a primeira, e os filtros vão como query
+ * string. Retorna null se a requisição falhar.
+ */
+export const fetchPage = async <T>(
+  url: string,
+  params: Record<string, string> = {},
+  cursor = "",
+  init?: RequestInit
+): Promise<Page<T> | null> => {
+  const pageUrl = new URL(url);
+  Object.entries(params).forEach(([key, value]) => {
+    if (value) pageUrl.searchParams.set(key, value);
+  });
+  if (cursor) pageUrl.searchParams.set("cursor", cursor);
+
+  const response = await authFetch(pageUrl, init);
+  if (!response.ok) return null;
+
+  const page: Page<T> = await response.json();
+  const total = response.headers.get("X-Total-Count");
+  return {
+    items: page.items || [],
+    next_cursor: page.next_cursor,
+    total: total ? Number(total) : undefined,
+  };
+};
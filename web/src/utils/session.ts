@@ -0,0 +1,126 @@
+const API_BASE = "http://localhost:8080/api";
+
+interface SessionTokens {
+  token: string;
+  refresh_token: string;
+}
+
+/**
+ * Guarda o token de acesso e o refresh token devolvidos pelo login ou
+ * pela renovação da sessão.
+ */
+export const saveSession = (data: SessionTokens) => {
+  localStorage.setItem("token", data.token);
+  localStorage.setItem("refresh_token", data.refresh_token);
+};
+
+/**
+ * Página inicial depois do login. Contas com senha conhecida precisam
+ * trocá-la antes de continuar.
+ */
+export const homePath = (user: { must_change_password?: boolean }) =>
+  user.must_change_password ? "/trocar-senha" : "/agendar";
+
+/**
+ * Remove a sessão do navegador. O email do "Lembrar-me" também é apagado,
+ * como sempre foi ao sair.
+ */
+export const clearSession = () => {
+  localStorage.removeItem("token");
+  localStorage.removeItem("refresh_token");
+  localStorage.removeItem("user");
+  localStorage.removeItem("role");
+  localStorage.removeItem("permissions");
+  localStorage.removeItem("email");
+  localStorage.removeItem("name");
+};
+
+/**
+ * Indica se o papel do usuário concede a permissão, conforme a última
+ * validação da sessão. As telas decidem pelo que o papel permite, nunca
+ * pelo nome do papel.
+ */
+export const hasPermission = (permission: string): boolean => {
+  const permissions: string[] = JSON.parse(
+    localStorage.getItem("permissions") || "[]"
+  );
+  return permissions.includes(permission);
+};
+
+// Requisições que recebem 401 ao mesmo tempo esperam pela mesma renovação,
+// já que cada refresh token só pode ser usado uma vez
+let refreshing: Promise<boolean> | null = null;
+
+const refreshSession = (): Promise<boolean> => {
+  if (!refreshing) {
+    refreshing = (async () => {
+      const refreshToken = localStorage.getItem("refresh_token");
+      if (!refreshToken) return false;
+      try {
+        const response = await fetch(`${API_BASE}/auth/refresh`, {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify({ refresh_token: refreshToken }),
+        });
+        if (!response.ok) return false;
+        saveSession(await response.json());
+        return true;
+      } catch {
+        return false;
+      }
+    })().finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+};
+
+/**
+ * Faz a requisição com o token de acesso. Quando ele expirou, renova a
+ * sessão com o refresh token e repete a requisição uma vez; se não for
+ * possível renovar, a sessão é encerrada e o usuário volta para o login.
+ */
+export const authFetch = async (
+  input: string | URL,
+  init: RequestInit = {}
+): Promise<Response> => {
+  const send = (token: string | null) => {
+    const headers = new Headers(init.headers);
+    if (token) headers.set("Authorization", `Bearer ${token}`);
+    return fetch(input, { ...init, headers });
+  };
+
+  const token = localStorage.getItem("token");
+  const response = await send(token);
+  if (response.status !== 401 || !token) return response;
+
+  // Outra aba pode já ter renovado a sessão
+  const current = localStorage.getItem("token");
+  if (current && current !== token) return send(current);
+
+  if (await refreshSession()) {
+    return send(localStorage.getItem("token"));
+  }
+  clearSession();
+  window.location.href = "/login";
+  return response;
+};
+
+/**
+ * Encerra a sessão no servidor, revogando o refresh token, e no navegador.
+ */
+export const logout = async () => {
+  const refreshToken = localStorage.getItem("refresh_token");
+  if (refreshToken) {
+    try {
+      await fetch(`${API_BASE}/auth/logout`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ refresh_token: refreshToken }),
+      });
+    } catch (error) {
+      console.error("Erro ao encerrar a sessão no servidor:", error);
+    }
+  }
+  clearSession();
+};